@@ -0,0 +1,81 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Snapshots
+
+	ListSnapshot(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) ([]assetmanager.Snapshot, *oc.Response, error)
+	ListSnapshotRequest(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetSnapshot(ctx context.Context, mediaPackageID string, version int, opts ...oc.RequestOpts) (*assetmanager.Snapshot, *oc.Response, error)
+	GetSnapshotRequest(ctx context.Context, mediaPackageID string, version int, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetMediaPackage(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error)
+	GetMediaPackageRequest(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	CreateSnapshot(ctx context.Context, mp *mediapackage.MediaPackage, opts ...oc.RequestOpts) (*oc.Response, error)
+	CreateSnapshotRequest(ctx context.Context, mp *mediapackage.MediaPackage, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	DeleteMediaPackage(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Response, error)
+	DeleteMediaPackageRequest(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Assets
+
+	DownloadAsset(ctx context.Context, mediaPackageID, elementID string, version int, filename string, w io.Writer, opts ...oc.RequestOpts) (*oc.Response, error)
+	DownloadAssetRequest(ctx context.Context, mediaPackageID, elementID string, version int, filename string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Properties
+
+	ListProperty(ctx context.Context, mediaPackageID, namespace string, opts ...oc.RequestOpts) ([]assetmanager.Property, *oc.Response, error)
+	ListPropertyRequest(ctx context.Context, mediaPackageID, namespace string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	SetProperty(ctx context.Context, mediaPackageID string, property assetmanager.Property, opts ...oc.RequestOpts) (*oc.Response, error)
+	SetPropertyRequest(ctx context.Context, mediaPackageID string, property assetmanager.Property, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+// DownloadAsset writes the file of the element archived in the given version
+// of the media package to w. filename is the last path segment of the element
+// URL in the snapshot. Opencast ignores it, but the route requires it.
+func (c *client) DownloadAsset(ctx context.Context, mediaPackageID, elementID string, version int, filename string, w io.Writer, opts ...oc.RequestOpts) (*oc.Response, error) {
+	resp, err := oc.GenericDo(c, func() (*oc.Request, error) {
+		return c.DownloadAssetRequest(ctx, mediaPackageID, elementID, version, filename, opts...)
+	})
+	if err != nil {
+		return resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, err = io.Copy(w, resp.Body)
+	return resp, err
+}
+
+func (c *client) DownloadAssetRequest(ctx context.Context, mediaPackageID, elementID string, version int, filename string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	opts = slices.Concat([]oc.RequestOpts{oc.WithStream()}, opts)
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		assetmanager.ServiceType,
+		"/assets/assets/"+url.PathEscape(mediaPackageID)+"/"+url.PathEscape(elementID)+"/"+strconv.Itoa(version)+"/"+url.PathEscape(filename),
+		oc.NoBody,
+		opts...,
+	)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	assetmanagerclient "shio.solutions/tales.media/opencast-client-go/apis/assetmanager/client"
+	ingestclient "shio.solutions/tales.media/opencast-client-go/apis/ingest/client"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+var testMedia = []byte("not really a video")
+
+// newClient returns a client of a server with an ingested media package
+// with a single track.
+func newClient(t *testing.T) (assetmanagerclient.Client, *mediapackage.MediaPackage) {
+	t.Helper()
+	srv := opencasttest.NewServer()
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "presenter.mp4")
+	if err := os.WriteFile(file, testMedia, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	ingest := ingestclient.New(occ)
+	mp, _, err := ingest.CreateMediaPackageWithID(ctx, "mp1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp.Title = "Lecture"
+	mp, _, err = ingest.AddTrack(ctx, &ingestclient.AddTrackRequestBody{MediaPackage: mp, Flavor: "presenter/source", File: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ingest.Ingest(ctx, &ingestclient.IngestRequestBody{MediaPackage: mp}); err != nil {
+		t.Fatal(err)
+	}
+	return assetmanagerclient.New(occ), mp
+}
+
+func TestSnapshots(t *testing.T) {
+	c, mp := newClient(t)
+	ctx := context.Background()
+
+	snapshots, _, err := c.ListSnapshot(ctx, mp.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Version != 0 || snapshots[0].MediaPackage.ID != mp.ID {
+		t.Fatalf("got snapshots %+v", snapshots)
+	}
+
+	mp.Title = "Lecture, revised"
+	if _, err := c.CreateSnapshot(ctx, mp); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, _, err := c.GetSnapshot(ctx, mp.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Version != 1 || snapshot.Availability != assetmanager.OnlineAvailability || snapshot.MediaPackage.Title != "Lecture, revised" {
+		t.Fatalf("got snapshot %+v", snapshot)
+	}
+	if _, _, err := c.GetSnapshot(ctx, mp.ID, 2); err == nil {
+		t.Error("GetSnapshot of a missing version succeeded")
+	}
+
+	latest, _, err := c.GetMediaPackage(ctx, mp.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Title != "Lecture, revised" || len(latest.Tracks()) != 1 {
+		t.Fatalf("got media package %+v", latest)
+	}
+
+	if _, err := c.DeleteMediaPackage(ctx, mp.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.ListSnapshot(ctx, mp.ID); err == nil {
+		t.Error("ListSnapshot after delete succeeded")
+	}
+}
+
+func TestDownloadAsset(t *testing.T) {
+	c, mp := newClient(t)
+	ctx := context.Background()
+
+	snapshot, _, err := c.GetSnapshot(ctx, mp.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	track := snapshot.MediaPackage.Tracks()[0]
+	u, err := url.Parse(track.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := path.Base(u.Path)
+
+	var buf bytes.Buffer
+	if _, err := c.DownloadAsset(ctx, mp.ID, track.ID, 0, filename, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), testMedia) {
+		t.Errorf("got asset %q, want %q", buf.Bytes(), testMedia)
+	}
+
+	if _, err := c.DownloadAsset(ctx, mp.ID, "missing", 0, filename, &buf); err == nil {
+		t.Error("DownloadAsset of a missing element succeeded")
+	}
+
+	req, err := c.DownloadAssetRequest(ctx, mp.ID, track.ID, 0, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !req.Stream {
+		t.Error("DownloadAssetRequest is not streamed")
+	}
+	// the asset manager serves files at
+	// /assets/assets/{mediaPackageID}/{elementID}/{version}/{filename}
+	if want := "/assets/assets/mp1/" + track.ID + "/0/presenter.mp4"; req.Path != want {
+		t.Errorf("got path %q, want %q", req.Path, want)
+	}
+	if req.Path != u.Path {
+		t.Errorf("got path %q, want the path of the element URL %q", req.Path, u.Path)
+	}
+}
+
+func TestProperties(t *testing.T) {
+	c, mp := newClient(t)
+	ctx := context.Background()
+	reviewed := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+	for _, p := range []assetmanager.Property{
+		assetmanager.NewStringProperty("org.example", "editor", "jane"),
+		assetmanager.NewBooleanProperty("org.example", "approved", false),
+		assetmanager.NewDateProperty("org.example.review", "reviewed", reviewed),
+		assetmanager.NewBooleanProperty("org.example", "approved", true),
+	} {
+		if _, err := c.SetProperty(ctx, mp.ID, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	props, _, err := c.ListProperty(ctx, mp.ID, "org.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(props) != 2 {
+		t.Fatalf("got properties %+v, want 2", props)
+	}
+	if approved, err := props[1].Boolean(); props[1].Name != "approved" || err != nil || !approved {
+		t.Errorf("got property %+v", props[1])
+	}
+
+	props, _, err = c.ListProperty(ctx, mp.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(props) != 3 {
+		t.Fatalf("got properties %+v, want 3", props)
+	}
+	if d, err := props[2].Date(); err != nil || !d.Equal(reviewed) {
+		t.Errorf("got date %s, %v, want %s", d, err, reviewed)
+	}
+
+	invalid := assetmanager.Property{Namespace: "org.example", Name: "count", Type: assetmanager.LongPropertyType, Value: "many"}
+	if _, err := c.SetProperty(ctx, mp.ID, invalid); err == nil {
+		t.Error("SetProperty with an invalid long succeeded")
+	}
+	if _, err := c.SetProperty(ctx, "missing", assetmanager.NewLongProperty("org.example", "count", 1)); err == nil {
+		t.Error("SetProperty of a missing media package succeeded")
+	}
+}
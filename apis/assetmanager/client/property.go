@@ -0,0 +1,75 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+// ListProperty returns the properties of the media package in the namespace.
+// An empty namespace returns all properties.
+func (c *client) ListProperty(ctx context.Context, mediaPackageID, namespace string, opts ...oc.RequestOpts) ([]assetmanager.Property, *oc.Response, error) {
+	props, resp, err := oc.GenericAutoDecodedDo[*assetmanager.PropertiesResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListPropertyRequest(ctx, mediaPackageID, namespace, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return props.Properties.Property, resp, nil
+}
+
+func (c *client) ListPropertyRequest(ctx context.Context, mediaPackageID, namespace string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	if namespace != "" {
+		opts = append([]oc.RequestOpts{oc.WithQuery("namespace", namespace)}, opts...)
+	}
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		assetmanager.ServiceType,
+		"/assets/"+url.PathEscape(mediaPackageID)+"/properties.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// SetProperty creates or replaces a property of the media package.
+func (c *client) SetProperty(ctx context.Context, mediaPackageID string, property assetmanager.Property, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(c, func() (*oc.Request, error) { return c.SetPropertyRequest(ctx, mediaPackageID, property, opts...) })
+}
+
+func (c *client) SetPropertyRequest(ctx context.Context, mediaPackageID string, property assetmanager.Property, opts ...oc.RequestOpts) (*oc.Request, error) {
+	body := multipart.New()
+	body.AddPart(multipart.FormFieldString("namespace", property.Namespace))
+	body.AddPart(multipart.FormFieldString("name", property.Name))
+	body.AddPart(multipart.FormFieldString("type", string(property.Type)))
+	body.AddPart(multipart.FormFieldString("value", property.Value))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		assetmanager.ServiceType,
+		"/assets/"+url.PathEscape(mediaPackageID)+"/properties",
+		oc.NewMultipartBody(body),
+		opts...,
+	)
+}
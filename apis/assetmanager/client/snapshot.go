@@ -0,0 +1,133 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+// ListSnapshot returns all versions of the media package, oldest first.
+func (c *client) ListSnapshot(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) ([]assetmanager.Snapshot, *oc.Response, error) {
+	snapshots, resp, err := oc.GenericAutoDecodedDo[*assetmanager.SnapshotsResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListSnapshotRequest(ctx, mediaPackageID, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return snapshots.Snapshots.Snapshot, resp, nil
+}
+
+func (c *client) ListSnapshotRequest(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		assetmanager.ServiceType,
+		"/assets/"+url.PathEscape(mediaPackageID)+"/snapshots.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetSnapshot(ctx context.Context, mediaPackageID string, version int, opts ...oc.RequestOpts) (*assetmanager.Snapshot, *oc.Response, error) {
+	snapshot, resp, err := oc.GenericAutoDecodedDo[*assetmanager.SnapshotResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetSnapshotRequest(ctx, mediaPackageID, version, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &snapshot.Snapshot, resp, nil
+}
+
+func (c *client) GetSnapshotRequest(ctx context.Context, mediaPackageID string, version int, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		assetmanager.ServiceType,
+		"/assets/"+url.PathEscape(mediaPackageID)+"/snapshots/"+strconv.Itoa(version)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// GetMediaPackage returns the media package of the latest snapshot.
+func (c *client) GetMediaPackage(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*mediapackage.MediaPackage](
+		c,
+		func() (*oc.Request, error) { return c.GetMediaPackageRequest(ctx, mediaPackageID, opts...) },
+	)
+}
+
+func (c *client) GetMediaPackageRequest(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		assetmanager.ServiceType,
+		"/assets/episode/"+url.PathEscape(mediaPackageID),
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// CreateSnapshot archives the media package as new version. The elements
+// must be accessible by the asset manager.
+func (c *client) CreateSnapshot(ctx context.Context, mp *mediapackage.MediaPackage, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(c, func() (*oc.Request, error) { return c.CreateSnapshotRequest(ctx, mp, opts...) })
+}
+
+func (c *client) CreateSnapshotRequest(ctx context.Context, mp *mediapackage.MediaPackage, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mpXML, err := xml.Marshal(mp)
+	if err != nil {
+		return nil, err
+	}
+	body := multipart.New()
+	body.AddPart(multipart.FormField("mediapackage", mpXML))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		assetmanager.ServiceType,
+		"/assets/add",
+		oc.NewMultipartBody(body),
+		opts...,
+	)
+}
+
+// DeleteMediaPackage removes all snapshots of the media package.
+func (c *client) DeleteMediaPackage(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(c, func() (*oc.Request, error) { return c.DeleteMediaPackageRequest(ctx, mediaPackageID, opts...) })
+}
+
+func (c *client) DeleteMediaPackageRequest(ctx context.Context, mediaPackageID string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodDelete,
+		assetmanager.ServiceType,
+		"/assets/delete/"+url.PathEscape(mediaPackageID),
+		oc.NoBody,
+		opts...,
+	)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package assetmanager contains the types of the Opencast asset manager,
+// which archives every version of a media package as snapshot.
+package assetmanager
+
+import (
+	"strconv"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+const ServiceType = "org.opencastproject.assetmanager"
+
+type SnapshotsResponse struct {
+	Snapshots SnapshotList `json:"snapshots"`
+}
+
+type SnapshotList struct {
+	Snapshot mediapackage.List[Snapshot] `json:"snapshot"`
+}
+
+type SnapshotResponse struct {
+	Snapshot Snapshot `json:"snapshot"`
+}
+
+// Snapshot is an archived version of a media package. The version matches
+// Event.ArchiveVersion of the External API.
+type Snapshot struct {
+	Version        base.Int                  `json:"version"`
+	OrganizationID string                    `json:"organizationId,omitempty"`
+	ArchivalDate   base.DateTime             `json:"archivalDate,omitzero"`
+	Availability   Availability              `json:"availability,omitempty"`
+	StorageID      string                    `json:"storageId,omitempty"`
+	Owner          string                    `json:"owner,omitempty"`
+	MediaPackage   mediapackage.MediaPackage `json:"mediapackage"`
+}
+
+type Availability string
+
+const (
+	OnlineAvailability    = Availability("ONLINE")
+	OfflineAvailability   = Availability("OFFLINE")
+	RestoringAvailability = Availability("RESTORING")
+)
+
+type PropertiesResponse struct {
+	Properties PropertyList `json:"properties"`
+}
+
+type PropertyList struct {
+	Property mediapackage.List[Property] `json:"property"`
+}
+
+// Property is a typed value the asset manager stores per media package,
+// independent of snapshots.
+type Property struct {
+	Namespace string       `json:"namespace"`
+	Name      string       `json:"name"`
+	Type      PropertyType `json:"type"`
+	Value     string       `json:"value"`
+}
+
+type PropertyType string
+
+const (
+	StringPropertyType  = PropertyType("string")
+	LongPropertyType    = PropertyType("long")
+	BooleanPropertyType = PropertyType("boolean")
+	DatePropertyType    = PropertyType("date")
+	VersionPropertyType = PropertyType("version")
+)
+
+func NewStringProperty(namespace, name, value string) Property {
+	return Property{Namespace: namespace, Name: name, Type: StringPropertyType, Value: value}
+}
+
+func NewLongProperty(namespace, name string, value int64) Property {
+	return Property{Namespace: namespace, Name: name, Type: LongPropertyType, Value: strconv.FormatInt(value, 10)}
+}
+
+func NewBooleanProperty(namespace, name string, value bool) Property {
+	return Property{Namespace: namespace, Name: name, Type: BooleanPropertyType, Value: strconv.FormatBool(value)}
+}
+
+func NewDateProperty(namespace, name string, value time.Time) Property {
+	return Property{Namespace: namespace, Name: name, Type: DatePropertyType, Value: value.UTC().Format(time.RFC3339)}
+}
+
+func (p Property) Long() (int64, error) {
+	return strconv.ParseInt(p.Value, 10, 64)
+}
+
+func (p Property) Boolean() (bool, error) {
+	return strconv.ParseBool(p.Value)
+}
+
+func (p Property) Date() (time.Time, error) {
+	return time.Parse(time.RFC3339, p.Value)
+}
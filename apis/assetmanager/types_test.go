@@ -0,0 +1,46 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assetmanager_test
+
+import (
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+)
+
+func TestPropertyValues(t *testing.T) {
+	p := assetmanager.NewLongProperty("org.example", "views", 42)
+	if p.Type != assetmanager.LongPropertyType || p.Value != "42" {
+		t.Fatalf("got %+v", p)
+	}
+	if v, err := p.Long(); err != nil || v != 42 {
+		t.Errorf("Long() = %d, %v", v, err)
+	}
+	if _, err := assetmanager.NewStringProperty("org.example", "name", "x").Long(); err == nil {
+		t.Error("Long() of a string succeeded")
+	}
+
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	p = assetmanager.NewDateProperty("org.example", "reviewed", at)
+	if p.Value != "2026-03-02T08:00:00Z" {
+		t.Errorf("got date value %q", p.Value)
+	}
+	if d, err := p.Date(); err != nil || !d.Equal(at) {
+		t.Errorf("Date() = %s, %v, want %s", d, err, at)
+	}
+}
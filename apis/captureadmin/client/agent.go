@@ -0,0 +1,190 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/captureadmin"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+type UpdateAgentStateRequestBody struct {
+	State captureadmin.AgentState
+	// Address is the URL of the capture agent.
+	Address string
+}
+
+func (c *client) ListAgent(ctx context.Context, opts ...oc.RequestOpts) ([]captureadmin.Agent, *oc.Response, error) {
+	agents, resp, err := oc.GenericAutoDecodedDo[*captureadmin.AgentsResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListAgentRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return agents.Agents.Agent, resp, nil
+}
+
+func (c *client) ListAgentRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		captureadmin.ServiceType,
+		"/capture-admin/agents.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetAgent(ctx context.Context, name string, opts ...oc.RequestOpts) (*captureadmin.Agent, *oc.Response, error) {
+	agent, resp, err := oc.GenericAutoDecodedDo[*captureadmin.AgentResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetAgentRequest(ctx, name, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &agent.Agent, resp, nil
+}
+
+func (c *client) GetAgentRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		captureadmin.ServiceType,
+		"/capture-admin/agents/"+url.PathEscape(name)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// UpdateAgentState sets the state of the capture agent. Unknown agents are
+// registered.
+func (c *client) UpdateAgentState(ctx context.Context, name string, body *UpdateAgentStateRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.UpdateAgentStateRequest(ctx, name, body, opts...) },
+	)
+}
+
+func (c *client) UpdateAgentStateRequest(ctx context.Context, name string, body *UpdateAgentStateRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("state", string(body.State)))
+	mp.AddPart(multipart.FormFieldString("address", body.Address))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		captureadmin.ServiceType,
+		"/capture-admin/agents/"+url.PathEscape(name),
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func (c *client) DeleteAgent(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.DeleteAgentRequest(ctx, name, opts...) },
+	)
+}
+
+func (c *client) DeleteAgentRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodDelete,
+		captureadmin.ServiceType,
+		"/capture-admin/agents/"+url.PathEscape(name),
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetAgentCapabilities(ctx context.Context, name string, opts ...oc.RequestOpts) (base.Properties, *oc.Response, error) {
+	props, resp, err := oc.GenericAutoDecodedDo[*captureadmin.PropertiesResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetAgentCapabilitiesRequest(ctx, name, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return props.Properties.Properties(), resp, nil
+}
+
+func (c *client) GetAgentCapabilitiesRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		captureadmin.ServiceType,
+		"/capture-admin/agents/"+url.PathEscape(name)+"/capabilities.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetAgentConfiguration(ctx context.Context, name string, opts ...oc.RequestOpts) (base.Properties, *oc.Response, error) {
+	props, resp, err := oc.GenericAutoDecodedDo[*captureadmin.PropertiesResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetAgentConfigurationRequest(ctx, name, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return props.Properties.Properties(), resp, nil
+}
+
+func (c *client) GetAgentConfigurationRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		captureadmin.ServiceType,
+		"/capture-admin/agents/"+url.PathEscape(name)+"/configuration.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// UpdateAgentConfiguration sets the configuration of the capture agent. The
+// capabilities are derived from it, e.g. capture.device.names.
+func (c *client) UpdateAgentConfiguration(ctx context.Context, name string, config base.Properties, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.UpdateAgentConfigurationRequest(ctx, name, config, opts...) },
+	)
+}
+
+func (c *client) UpdateAgentConfigurationRequest(ctx context.Context, name string, config base.Properties, opts ...oc.RequestOpts) (*oc.Request, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	mp := multipart.New()
+	mp.AddPart(multipart.FormField("configuration", b))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		captureadmin.ServiceType,
+		"/capture-admin/agents/"+url.PathEscape(name)+"/configuration",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
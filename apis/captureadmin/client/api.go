@@ -0,0 +1,87 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/captureadmin"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Agents
+
+	ListAgent(ctx context.Context, opts ...oc.RequestOpts) ([]captureadmin.Agent, *oc.Response, error)
+	ListAgentRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetAgent(ctx context.Context, name string, opts ...oc.RequestOpts) (*captureadmin.Agent, *oc.Response, error)
+	GetAgentRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	UpdateAgentState(ctx context.Context, name string, body *UpdateAgentStateRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	UpdateAgentStateRequest(ctx context.Context, name string, body *UpdateAgentStateRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	DeleteAgent(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Response, error)
+	DeleteAgentRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetAgentCapabilities(ctx context.Context, name string, opts ...oc.RequestOpts) (base.Properties, *oc.Response, error)
+	GetAgentCapabilitiesRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetAgentConfiguration(ctx context.Context, name string, opts ...oc.RequestOpts) (base.Properties, *oc.Response, error)
+	GetAgentConfigurationRequest(ctx context.Context, name string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	UpdateAgentConfiguration(ctx context.Context, name string, config base.Properties, opts ...oc.RequestOpts) (*oc.Response, error)
+	UpdateAgentConfigurationRequest(ctx context.Context, name string, config base.Properties, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Recordings
+
+	ListRecordingState(ctx context.Context, opts ...oc.RequestOpts) ([]captureadmin.Recording, *oc.Response, error)
+	ListRecordingStateRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetRecordingState(ctx context.Context, id string, opts ...oc.RequestOpts) (*captureadmin.Recording, *oc.Response, error)
+	GetRecordingStateRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	UpdateRecordingState(ctx context.Context, id string, state captureadmin.RecordingState, opts ...oc.RequestOpts) (*oc.Response, error)
+	UpdateRecordingStateRequest(ctx context.Context, id string, state captureadmin.RecordingState, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	DeleteRecordingState(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Response, error)
+	DeleteRecordingStateRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
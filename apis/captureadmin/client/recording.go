@@ -0,0 +1,109 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/captureadmin"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+func (c *client) ListRecordingState(ctx context.Context, opts ...oc.RequestOpts) ([]captureadmin.Recording, *oc.Response, error) {
+	recordings, resp, err := oc.GenericAutoDecodedDo[*captureadmin.RecordingsResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListRecordingStateRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return recordings.Recordings.Recording, resp, nil
+}
+
+func (c *client) ListRecordingStateRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		captureadmin.ServiceType,
+		"/capture-admin/recordings.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetRecordingState(ctx context.Context, id string, opts ...oc.RequestOpts) (*captureadmin.Recording, *oc.Response, error) {
+	recording, resp, err := oc.GenericAutoDecodedDo[*captureadmin.RecordingResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetRecordingStateRequest(ctx, id, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &recording.Recording, resp, nil
+}
+
+func (c *client) GetRecordingStateRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		captureadmin.ServiceType,
+		"/capture-admin/recordings/"+url.PathEscape(id)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) UpdateRecordingState(ctx context.Context, id string, state captureadmin.RecordingState, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.UpdateRecordingStateRequest(ctx, id, state, opts...) },
+	)
+}
+
+func (c *client) UpdateRecordingStateRequest(ctx context.Context, id string, state captureadmin.RecordingState, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("state", string(state)))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		captureadmin.ServiceType,
+		"/capture-admin/recordings/"+url.PathEscape(id),
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func (c *client) DeleteRecordingState(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.DeleteRecordingStateRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) DeleteRecordingStateRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodDelete,
+		captureadmin.ServiceType,
+		"/capture-admin/recordings/"+url.PathEscape(id),
+		oc.NoBody,
+		opts...,
+	)
+}
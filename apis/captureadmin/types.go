@@ -0,0 +1,119 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package captureadmin
+
+import (
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+const ServiceType = "org.opencastproject.capture.admin"
+
+type AgentsResponse struct {
+	Agents AgentList `json:"agents"`
+}
+
+type AgentList struct {
+	Agent mediapackage.List[Agent] `json:"agent"`
+}
+
+type AgentResponse struct {
+	Agent Agent `json:"agent-state-update"`
+}
+
+type Agent struct {
+	Name  string     `json:"name"`
+	State AgentState `json:"state"`
+	URL   string     `json:"url,omitempty"`
+	// TimeSinceLastUpdate is given in milliseconds.
+	TimeSinceLastUpdate base.Int      `json:"time-since-last-update,omitempty"`
+	Capabilities        *PropertyList `json:"capabilities,omitempty"`
+}
+
+type AgentState string
+
+const (
+	UnknownAgentState      = AgentState("unknown")
+	IdleAgentState         = AgentState("idle")
+	CapturingAgentState    = AgentState("capturing")
+	UploadingAgentState    = AgentState("uploading")
+	ShuttingDownAgentState = AgentState("shutting_down")
+	OfflineAgentState      = AgentState("offline")
+	ErrorAgentState        = AgentState("error")
+)
+
+// PropertyList is the JSON representation of Java properties used for agent
+// capabilities and configuration.
+type PropertyList struct {
+	Item mediapackage.List[PropertyItem] `json:"item"`
+}
+
+type PropertyItem struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (l *PropertyList) Properties() base.Properties {
+	props := make(base.Properties)
+	if l == nil {
+		return props
+	}
+	for _, item := range l.Item {
+		props[item.Key] = item.Value
+	}
+	return props
+}
+
+type RecordingsResponse struct {
+	Recordings RecordingList `json:"recordings"`
+}
+
+type RecordingList struct {
+	Recording mediapackage.List[Recording] `json:"recording"`
+}
+
+type RecordingResponse struct {
+	Recording Recording `json:"recording"`
+}
+
+type Recording struct {
+	ID    string         `json:"id"`
+	State RecordingState `json:"state"`
+	// TimeSinceLastUpdate is given in milliseconds.
+	TimeSinceLastUpdate base.Int `json:"time-since-last-update,omitempty"`
+}
+
+type RecordingState string
+
+const (
+	UnknownRecordingState          = RecordingState("unknown")
+	CapturingRecordingState        = RecordingState("capturing")
+	CaptureFinishedRecordingState  = RecordingState("capture_finished")
+	CaptureErrorRecordingState     = RecordingState("capture_error")
+	ManifestRecordingState         = RecordingState("manifest")
+	ManifestErrorRecordingState    = RecordingState("manifest_error")
+	ManifestFinishedRecordingState = RecordingState("manifest_finished")
+	CompressingRecordingState      = RecordingState("compressing")
+	CompressingErrorRecordingState = RecordingState("compressing_error")
+	UploadingRecordingState        = RecordingState("uploading")
+	UploadFinishedRecordingState   = RecordingState("upload_finished")
+	UploadErrorRecordingState      = RecordingState("upload_error")
+)
+
+type PropertiesResponse struct {
+	Properties PropertyList `json:"properties"`
+}
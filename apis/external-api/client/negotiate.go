@@ -0,0 +1,83 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client creates External API clients for the highest version
+// supported by both Opencast and this library.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	extapiv1_12 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.12"
+	extapiv1_13 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.13"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+var NoCommonVersionErr = errors.New("no common External API version")
+
+// Versions are the External API versions supported by this library, newest
+// first. Versions before v1.11.0 are served by the v1.11 client, so requests
+// of endpoints added later fail on such clusters and fields added later are
+// left empty.
+var Versions = []string{
+	extapiv1_13.Version,
+	extapiv1_12.Version,
+	extapiv1.Version,
+	"v1.10.0",
+	"v1.9.0",
+	"v1.8.0",
+	"v1.7.0",
+	"v1.6.0",
+	"v1.5.0",
+	"v1.4.0",
+	"v1.3.0",
+	"v1.2.0",
+	"v1.1.0",
+	"v1.0.0",
+}
+
+// New asks Opencast for the External API versions it supports and returns a
+// client for the highest version also supported by this library. The
+// request options are applied to the version request only.
+func New(ctx context.Context, opencastClient oc.Client, opts ...oc.RequestOpts) (extapiclientv1.Client, error) {
+	// any version would do, but older clusters reject unknown ones
+	opts = append([]oc.RequestOpts{oc.WithHeader("Accept", "application/json")}, opts...)
+	apiVersion, _, err := extapiclientv1.New(opencastClient).GetAPIVersion(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	version, err := Negotiate(apiVersion.Versions)
+	if err != nil {
+		return nil, err
+	}
+	return extapiclientv1.NewVersion(opencastClient, version), nil
+}
+
+// Negotiate returns the highest of the given versions that is supported by
+// this library.
+func Negotiate(versions []string) (string, error) {
+	for _, v := range Versions {
+		if slices.Contains(versions, v) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("%w: Opencast supports %v, this library %v", NoCommonVersionErr, versions, Versions)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	extapiclient "shio.solutions/tales.media/opencast-client-go/apis/external-api/client"
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	extapiclientv1_12 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.12/client"
+	extapiclientv1_13 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.13/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		versions []string
+		want     string
+	}{
+		{[]string{"v1.11.0", "v1.12.0", "v1.13.0", "v1.14.0"}, "v1.13.0"},
+		{[]string{"v1.11.0", "v1.12.0"}, "v1.12.0"},
+		{[]string{"v1.0.0", "v1.1.0", "v1.10.0"}, "v1.10.0"},
+		{[]string{"v1.0.0"}, "v1.0.0"},
+	}
+	for _, tt := range tests {
+		got, err := extapiclient.Negotiate(tt.versions)
+		if err != nil || got != tt.want {
+			t.Errorf("Negotiate(%v) = %q, %v, want %q", tt.versions, got, err, tt.want)
+		}
+	}
+
+	if _, err := extapiclient.Negotiate([]string{"v2.0.0"}); !errors.Is(err, extapiclient.NoCommonVersionErr) {
+		t.Errorf("Negotiate(v2.0.0) error = %v, want NoCommonVersionErr", err)
+	}
+}
+
+// newServer returns a client of a server supporting the given versions and a
+// func returning the Accept headers of the requests to /api/events and
+// /api/series so far.
+func newServer(t *testing.T, versions ...string) (oc.Client, func() []string) {
+	t.Helper()
+	srv := opencasttest.NewServer(
+		opencasttest.WithAPIVersions(versions...),
+		opencasttest.WithFixtures(&opencasttest.Fixtures{
+			Events: []opencasttest.EventFixture{{Event: extapiv1.Event{Identifier: "e1", Title: "Event"}}},
+			Series: []opencasttest.SeriesFixture{{Series: extapiv1.Series{Identifier: "s1", Title: "Series"}}},
+		}),
+	)
+	t.Cleanup(srv.Close)
+
+	var (
+		mtx     sync.Mutex
+		accepts []string
+	)
+	srv.OnRequest(func(r *http.Request) {
+		if r.URL.Path == "/api/events" || r.URL.Path == "/api/series" || r.URL.Path == "/api/series/s1" {
+			mtx.Lock()
+			defer mtx.Unlock()
+			accepts = append(accepts, r.Header.Get("Accept"))
+		}
+	})
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return occ, func() []string {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return slices.Clone(accepts)
+	}
+}
+
+func TestNewPicksHighestCommonVersion(t *testing.T) {
+	tests := []struct {
+		versions []string
+		want     string
+	}{
+		{[]string{"v1.11.0", "v1.12.0", "v1.13.0"}, "v1.13.0"},
+		{[]string{"v1.11.0", "v1.12.0"}, "v1.12.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			occ, accepts := newServer(t, tt.versions...)
+			ctx := context.Background()
+
+			c, err := extapiclient.New(ctx, occ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := extapiclientv1.Version(c); v != tt.want {
+				t.Errorf("Version = %q, want %q", v, tt.want)
+			}
+
+			series, _, err := c.GetSeries(ctx, "s1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if series.Identifier != "s1" || series.Title != "Series" {
+				t.Errorf("GetSeries = %+v", series)
+			}
+			events, _, err := c.ListEvent(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 || events[0].Identifier != "e1" {
+				t.Errorf("ListEvent = %+v", events)
+			}
+			accept := "application/" + tt.want + "+json"
+			if got, want := accepts(), []string{accept, accept}; !slices.Equal(got, want) {
+				t.Errorf("Accept = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestVersionClients(t *testing.T) {
+	occ, accepts := newServer(t, "v1.11.0", "v1.12.0", "v1.13.0")
+	ctx := context.Background()
+
+	if _, _, err := extapiclientv1_12.New(occ).ListSeries(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := extapiclientv1_13.New(occ).ListSeries(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := accepts(), []string{"application/v1.12.0+json", "application/v1.13.0+json"}; !slices.Equal(got, want) {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestNewFallsBackToOlderVersion(t *testing.T) {
+	occ, accepts := newServer(t, "v1.9.0", "v1.10.0")
+
+	c, err := extapiclient.New(context.Background(), occ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := extapiclientv1.Version(c); v != "v1.10.0" {
+		t.Errorf("Version = %q, want v1.10.0", v)
+	}
+	events, _, err := c.ListEvent(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Errorf("got %d events, want 1", len(events))
+	}
+	if got, want := accepts(), []string{"application/v1.10.0+json"}; !slices.Equal(got, want) {
+		t.Errorf("Accept = %q, want %q", got, want)
+	}
+}
+
+func TestNewNoCommonVersion(t *testing.T) {
+	occ, _ := newServer(t, "v2.0.0")
+
+	if _, err := extapiclient.New(context.Background(), occ); !errors.Is(err, extapiclient.NoCommonVersionErr) {
+		t.Errorf("New error = %v, want NoCommonVersionErr", err)
+	}
+}
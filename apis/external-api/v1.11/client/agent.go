@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -43,6 +44,13 @@ func (c *client) ListAgentRequest(ctx context.Context, opts ...oc.RequestOpts) (
 	)
 }
 
+func (c *client) StreamAgent(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Agent, error] {
+	return oc.GenericStreamedDo[extapiv1.Agent](
+		c,
+		func() (*oc.Request, error) { return c.ListAgentRequest(ctx, opts...) },
+	)
+}
+
 func (c *client) GetAgent(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.Agent, *oc.Response, error) {
 	return oc.GenericAutoDecodedDo[*extapiv1.Agent](
 		c,
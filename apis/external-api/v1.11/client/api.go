@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
@@ -27,6 +28,8 @@ import (
 )
 
 const (
+	// Deprecated: the client sets the Accept header of its version itself,
+	// see NewVersion.
 	AcceptJSONHeader = "application/" + extapiv1.Version + "+json"
 )
 
@@ -93,6 +96,7 @@ type Client interface {
 
 	ListGroup(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Group, *oc.Response, error)
 	ListGroupRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	StreamGroup(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Group, error]
 
 	CreateGroup(ctx context.Context, body *CreateGroupRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
 	CreateGroupRequest(ctx context.Context, body *CreateGroupRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
@@ -132,6 +136,7 @@ type Client interface {
 
 	ListAgent(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Agent, *oc.Response, error)
 	ListAgentRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	StreamAgent(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Agent, error]
 
 	GetAgent(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.Agent, *oc.Response, error)
 	GetAgentRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
@@ -140,6 +145,7 @@ type Client interface {
 
 	ListEvent(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Event, *oc.Response, error)
 	ListEventRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	StreamEvent(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Event, error]
 
 	CreateEvent(ctx context.Context, body *CreateEventRequestBody, opts ...oc.RequestOpts) (objlist.ObjectOrList[extapiv1.Identifier], *oc.Response, error)
 	CreateEventRequest(ctx context.Context, body *CreateEventRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
@@ -209,6 +215,7 @@ type Client interface {
 
 	ListSeries(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Series, *oc.Response, error)
 	ListSeriesRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	StreamSeries(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Series, error]
 
 	SearchSeries(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Series, *oc.Response, error)
 	SearchSeriesRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
@@ -259,6 +266,7 @@ type Client interface {
 
 	ListPlaylist(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Playlist, *oc.Response, error)
 	ListPlaylistRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	StreamPlaylist(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Playlist, error]
 
 	CreatePlaylist(ctx context.Context, body *CreatePlaylistRequestBody, opts ...oc.RequestOpts) (*extapiv1.Playlist, *oc.Response, error)
 	CreatePlaylistRequest(ctx context.Context, body *CreatePlaylistRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
@@ -274,9 +282,8 @@ type Client interface {
 
 	// Workflows
 
-	// TODO: add list workflow to External API
-	// ListWorkflow(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Workflow, *oc.Response, error)
-	// ListWorkflowRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	// The External API does not list workflows, see the workflow service
+	// client in apis/workflow/client instead.
 
 	CreateWorkflow(ctx context.Context, body *CreateWorkflowRequestBody, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error)
 	CreateWorkflowRequest(ctx context.Context, body *CreateWorkflowRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
@@ -294,32 +301,56 @@ type Client interface {
 
 	ListWorkflowDefinition(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.WorkflowDefinition, *oc.Response, error)
 	ListWorkflowDefinitionRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	StreamWorkflowDefinition(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.WorkflowDefinition, error]
 
 	GetWorkflowDefinition(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowDefinition, *oc.Response, error)
 	GetWorkflowDefinitionRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
 }
 
 type client struct {
-	occ oc.Client
+	occ     oc.Client
+	version string
 }
 
 var _ Client = &client{}
 
 func New(opencastClient oc.Client) *client {
+	return NewVersion(opencastClient, extapiv1.Version)
+}
+
+// NewVersion returns a client requesting the given version of the External
+// API. It is used by the packages of newer versions sharing the requests and
+// types of v1.11.
+func NewVersion(opencastClient oc.Client, version string) *client {
 	return &client{
-		occ: opencastClient,
+		occ:     opencastClient,
+		version: version,
 	}
 }
 
+// Do requests the version of the client unless the request sets the Accept
+// header.
 func (c *client) Do(req *oc.Request) (*oc.Response, error) {
-	if err := req.ApplyOptions(
-		oc.WithHeader("Accept", AcceptJSONHeader),
-	); err != nil {
-		return nil, err
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/"+c.version+"+json")
 	}
 	return c.occ.Do(req)
 }
 
+// Version returns the External API version requested by the client.
+func (c *client) Version() string {
+	return c.version
+}
+
+// Version returns the External API version requested by c, or v1.11.0 if c
+// does not report one.
+func Version(c Client) string {
+	if v, ok := c.(interface{ Version() string }); ok {
+		return v.Version()
+	}
+	return extapiv1.Version
+}
+
 func (c *client) OpencastClient() oc.Client {
 	return c.occ
 }
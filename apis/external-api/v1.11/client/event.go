@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -156,6 +157,13 @@ func (c *client) ListEventRequest(ctx context.Context, opts ...oc.RequestOpts) (
 	)
 }
 
+func (c *client) StreamEvent(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Event, error] {
+	return oc.GenericStreamedDo[extapiv1.Event](
+		c,
+		func() (*oc.Request, error) { return c.ListEventRequest(ctx, opts...) },
+	)
+}
+
 func (c *client) CreateEvent(ctx context.Context, body *CreateEventRequestBody, opts ...oc.RequestOpts) (objlist.ObjectOrList[extapiv1.Identifier], *oc.Response, error) {
 	return oc.GenericAutoDecodedDo[objlist.ObjectOrList[extapiv1.Identifier]](
 		c,
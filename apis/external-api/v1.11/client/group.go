@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
@@ -73,6 +74,13 @@ func (c *client) ListGroupRequest(ctx context.Context, opts ...oc.RequestOpts) (
 	)
 }
 
+func (c *client) StreamGroup(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Group, error] {
+	return oc.GenericStreamedDo[extapiv1.Group](
+		c,
+		func() (*oc.Request, error) { return c.ListGroupRequest(ctx, opts...) },
+	)
+}
+
 func (c *client) CreateGroup(ctx context.Context, body *CreateGroupRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
 	return oc.GenericDo(
 		c,
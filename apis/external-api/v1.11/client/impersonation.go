@@ -0,0 +1,180 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+var (
+	// SudoRequiredErr is returned if Opencast rejects the run-as headers
+	// because the authenticated user lacks the sudo role.
+	SudoRequiredErr = errors.New("run-as requires the sudo role")
+	// ImpersonationIgnoredErr is returned if Opencast processed the request
+	// as a different user or without the requested roles, e.g. because a
+	// proxy dropped the run-as headers.
+	ImpersonationIgnoredErr = errors.New("run-as was not applied")
+)
+
+// ImpersonationError is returned by clients created with [As] if
+// impersonation did not take effect.
+type ImpersonationError struct {
+	Username string
+	Roles    []string
+
+	// ActualUsername is the user Opencast processed the request as.
+	ActualUsername string
+	MissingRoles   []string
+
+	Err error
+}
+
+func (e *ImpersonationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("impersonating ")
+	sb.WriteString(e.Username)
+	if len(e.Roles) > 0 {
+		sb.WriteString(" with roles " + strings.Join(e.Roles, ","))
+	}
+	sb.WriteString(" failed: " + e.Err.Error())
+	if e.ActualUsername != "" && e.ActualUsername != e.Username {
+		sb.WriteString(", running as " + e.ActualUsername)
+	}
+	if len(e.MissingRoles) > 0 {
+		sb.WriteString(", missing roles " + strings.Join(e.MissingRoles, ","))
+	}
+	return sb.String()
+}
+
+func (e *ImpersonationError) Unwrap() error {
+	return e.Err
+}
+
+type runAsClient struct {
+	occ      oc.Client
+	version  string
+	username string
+	roles    []string
+
+	mtx      sync.Mutex
+	verified bool  // protected by mtx
+	err      error // protected by mtx
+}
+
+var _ oc.Client = &runAsClient{}
+
+// As returns a client running all requests of c as the user with the given
+// roles. Impersonation is verified on first use with GetInfoMe and
+// GetInfoMeRoles. If it did not take effect, all requests fail with an
+// *ImpersonationError.
+func As(c Client, username string, roles ...string) Client {
+	version := Version(c)
+	return NewVersion(&runAsClient{
+		occ:      c,
+		version:  version,
+		username: username,
+		roles:    slices.Clone(roles),
+	}, version)
+}
+
+func (c *runAsClient) Do(req *oc.Request) (*oc.Response, error) {
+	if err := c.verify(req.Ctx); err != nil {
+		return nil, err
+	}
+	if err := req.ApplyOptions(c.requestOptions()...); err != nil {
+		return nil, err
+	}
+	return c.occ.Do(req)
+}
+
+func (c *runAsClient) requestOptions() []oc.RequestOpts {
+	opts := []oc.RequestOpts{oc.WithRunAsUser(c.username)}
+	if len(c.roles) > 0 {
+		opts = append(opts, oc.WithRunWithRoles(c.roles...))
+	}
+	return opts
+}
+
+// verify checks once that impersonation takes effect. Errors not caused by
+// impersonation, e.g. network errors, are not remembered.
+func (c *runAsClient) verify(ctx context.Context) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.verified {
+		return c.err
+	}
+
+	err := c.check(ctx)
+	var ie *ImpersonationError
+	if err == nil || errors.As(err, &ie) {
+		c.verified = true
+		c.err = err
+	}
+	return err
+}
+
+func (c *runAsClient) check(ctx context.Context) error {
+	api := NewVersion(c.occ, c.version)
+	opts := c.requestOptions()
+
+	me, resp, err := api.GetInfoMe(ctx, opts...)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			return c.newError(SudoRequiredErr, "", nil)
+		}
+		return err
+	}
+	if me.Username != c.username {
+		return c.newError(ImpersonationIgnoredErr, me.Username, nil)
+	}
+	if len(c.roles) == 0 {
+		return nil
+	}
+
+	roles, _, err := api.GetInfoMeRoles(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, role := range c.roles {
+		if !slices.Contains(roles, role) {
+			missing = append(missing, role)
+		}
+	}
+	if len(missing) > 0 {
+		return c.newError(ImpersonationIgnoredErr, me.Username, missing)
+	}
+	return nil
+}
+
+func (c *runAsClient) newError(err error, actualUsername string, missingRoles []string) *ImpersonationError {
+	return &ImpersonationError{
+		Username:       c.username,
+		Roles:          c.roles,
+		ActualUsername: actualUsername,
+		MissingRoles:   missingRoles,
+		Err:            err,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newClient(t *testing.T, srv *opencasttest.Server) extapiclientv1.Client {
+	t.Helper()
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return extapiclientv1.New(c)
+}
+
+func TestAs(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	api := extapiclientv1.As(newClient(t, srv), "jdoe", "ROLE_STUDIO")
+	me, _, err := api.GetInfoMe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.Username != "jdoe" {
+		t.Fatalf("got user %q, want jdoe", me.Username)
+	}
+}
+
+func TestAsWithoutSudo(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(&opencasttest.Fixtures{Roles: []string{"ROLE_ADMIN"}}))
+	defer srv.Close()
+
+	api := extapiclientv1.As(newClient(t, srv), "jdoe")
+	_, _, err := api.ListEvent(context.Background())
+	if !errors.Is(err, extapiclientv1.SudoRequiredErr) {
+		t.Fatalf("got error %v, want %v", err, extapiclientv1.SudoRequiredErr)
+	}
+}
+
+func TestAsIgnored(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithoutRunAs())
+	defer srv.Close()
+
+	api := extapiclientv1.As(newClient(t, srv), "jdoe", "ROLE_STUDIO")
+	ctx := context.Background()
+	_, _, err := api.ListEvent(ctx)
+	var ie *extapiclientv1.ImpersonationError
+	if !errors.As(err, &ie) || !errors.Is(err, extapiclientv1.ImpersonationIgnoredErr) {
+		t.Fatalf("got error %v, want %v", err, extapiclientv1.ImpersonationIgnoredErr)
+	}
+	if ie.ActualUsername != opencasttest.DefaultUsername {
+		t.Fatalf("got actual user %q, want %q", ie.ActualUsername, opencasttest.DefaultUsername)
+	}
+
+	// the result of the verification is kept
+	if _, _, err := api.ListEvent(ctx); !errors.Is(err, extapiclientv1.ImpersonationIgnoredErr) {
+		t.Fatalf("got error %v on second request, want %v", err, extapiclientv1.ImpersonationIgnoredErr)
+	}
+}
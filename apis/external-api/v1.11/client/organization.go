@@ -0,0 +1,244 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+var (
+	OrganizationNotFoundErr = errors.New("organization not found")
+	OrganizationMismatchErr = errors.New("organization mismatch")
+)
+
+// Tenant describes how to reach an organization. Opencast selects the
+// organization by the hostname of the request, so each tenant has its own base
+// URL and usually its own credentials.
+type Tenant struct {
+	OrganizationID string
+	BaseURL        string
+	// Opts are applied after the options shared by all tenants, e.g.
+	// oc.WithAdditionalRequestOptions(oc.WithBasicAuth(username, password)).
+	Opts []oc.ClientOpts
+}
+
+// OrganizationClient routes requests to the organizations of an Opencast
+// cluster.
+type OrganizationClient interface {
+	// Organizations returns the sorted IDs of all configured organizations.
+	Organizations() []string
+	// ForOrganization returns the client scoped to the organization.
+	ForOrganization(id string) (Client, error)
+	// Verify checks for all tenants that the organization reported by
+	// Opencast matches the configured one. Tenants are checked before their
+	// first request anyway, Verify allows to do so upfront.
+	Verify(ctx context.Context) error
+}
+
+type organizationClient struct {
+	ids       []string // sorted
+	clients   map[string]Client
+	verifiers map[string]*organizationVerifier
+}
+
+var _ OrganizationClient = &organizationClient{}
+
+// NewOrganizationClient returns a client for multiple organizations of an
+// Opencast cluster. opts are applied to the clients of all tenants. Before the
+// first request of a tenant, the organization reported by Opencast is checked
+// against the configured one, see [OrganizationClient.Verify].
+func NewOrganizationClient(tenants []Tenant, opts ...oc.ClientOpts) (OrganizationClient, error) {
+	c := &organizationClient{
+		clients:   make(map[string]Client, len(tenants)),
+		verifiers: make(map[string]*organizationVerifier, len(tenants)),
+	}
+	for _, t := range tenants {
+		if _, ok := c.clients[t.OrganizationID]; ok {
+			return nil, fmt.Errorf("duplicate organization %q", t.OrganizationID)
+		}
+		occ, err := oc.New(
+			&oc.StaticServiceMapper{Default: t.BaseURL},
+			append(slices.Clip(opts), t.Opts...)...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("organization %q: %w", t.OrganizationID, err)
+		}
+		v := &organizationVerifier{Client: occ, id: t.OrganizationID}
+		c.verifiers[t.OrganizationID] = v
+		c.clients[t.OrganizationID] = New(v)
+		c.ids = append(c.ids, t.OrganizationID)
+	}
+	slices.Sort(c.ids)
+	return c, nil
+}
+
+func (c *organizationClient) Organizations() []string {
+	return slices.Clone(c.ids)
+}
+
+func (c *organizationClient) ForOrganization(id string) (Client, error) {
+	client, ok := c.clients[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", OrganizationNotFoundErr, id)
+	}
+	return client, nil
+}
+
+func (c *organizationClient) Verify(ctx context.Context) error {
+	errs := make([]error, len(c.ids))
+	var wg sync.WaitGroup
+	for i, id := range c.ids {
+		wg.Go(func() {
+			if err := c.verifiers[id].verify(ctx); err != nil {
+				errs[i] = &OrganizationError{OrganizationID: id, Err: err}
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// organizationVerifier checks the organization of a tenant before passing on
+// the first request. A mismatch fails all further requests, other errors are
+// retried with the next request.
+type organizationVerifier struct {
+	oc.Client
+	id string
+
+	mtx      sync.Mutex
+	verified bool  // protected by mtx
+	err      error // protected by mtx
+}
+
+func (v *organizationVerifier) Do(req *oc.Request) (*oc.Response, error) {
+	if err := v.verify(req.Ctx); err != nil {
+		return nil, err
+	}
+	return v.Client.Do(req)
+}
+
+func (v *organizationVerifier) verify(ctx context.Context) error {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if v.verified {
+		return v.err
+	}
+	org, _, err := New(v.Client).GetInfoOrganization(ctx)
+	if err != nil {
+		return err
+	}
+	v.verified = true
+	if org.ID != v.id {
+		v.err = fmt.Errorf("%w: got %q", OrganizationMismatchErr, org.ID)
+	}
+	return v.err
+}
+
+// OrganizationError is returned for failed calls of a fan-out.
+type OrganizationError struct {
+	OrganizationID string
+	Err            error
+}
+
+func (e *OrganizationError) Error() string {
+	return "organization " + e.OrganizationID + ": " + e.Err.Error()
+}
+
+func (e *OrganizationError) Unwrap() error {
+	return e.Err
+}
+
+// OrganizationResult is the result of a call for one organization.
+type OrganizationResult[T any] struct {
+	OrganizationID string
+	Value          T
+	Response       *oc.Response
+	// Err is an *OrganizationError if the call failed.
+	Err error
+}
+
+// FanOut runs fn concurrently for all organizations. The results are ordered
+// by organization ID.
+func FanOut[T any](
+	ctx context.Context,
+	c OrganizationClient,
+	fn func(ctx context.Context, client Client) (T, *oc.Response, error),
+) []OrganizationResult[T] {
+	ids := c.Organizations()
+	results := make([]OrganizationResult[T], len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Go(func() {
+			var (
+				v    T
+				resp *oc.Response
+			)
+			client, err := c.ForOrganization(id)
+			if err == nil {
+				v, resp, err = fn(ctx, client)
+			}
+			if err != nil {
+				err = &OrganizationError{OrganizationID: id, Err: err}
+			}
+			results[i] = OrganizationResult[T]{
+				OrganizationID: id,
+				Value:          v,
+				Response:       resp,
+				Err:            err,
+			}
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+// OrganizationItem tags an item with the organization it belongs to.
+type OrganizationItem[T any] struct {
+	OrganizationID string
+	Item           T
+}
+
+// FanOutList runs fn concurrently for all organizations and merges the
+// returned lists. Items of successful calls are returned even if some calls
+// failed, the errors of which are joined.
+func FanOutList[T any](
+	ctx context.Context,
+	c OrganizationClient,
+	fn func(ctx context.Context, client Client) ([]T, *oc.Response, error),
+) ([]OrganizationItem[T], error) {
+	var items []OrganizationItem[T]
+	var errs []error
+	for _, r := range FanOut(ctx, c, fn) {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+			continue
+		}
+		for _, item := range r.Value {
+			items = append(items, OrganizationItem[T]{OrganizationID: r.OrganizationID, Item: item})
+		}
+	}
+	return items, errors.Join(errs...)
+}
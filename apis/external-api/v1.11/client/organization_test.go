@@ -0,0 +1,125 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newOrganizationServer(t *testing.T, id string, events ...string) *opencasttest.Server {
+	t.Helper()
+	f := &opencasttest.Fixtures{Organization: &extapiv1.Organization{ID: id, Name: id}}
+	for _, e := range events {
+		f.Events = append(f.Events, opencasttest.EventFixture{Event: extapiv1.Event{Identifier: e, Title: e}})
+	}
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(f))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func tenant(srv *opencasttest.Server, id string) extapiclientv1.Tenant {
+	return extapiclientv1.Tenant{
+		OrganizationID: id,
+		BaseURL:        srv.URL,
+		Opts: []oc.ClientOpts{
+			oc.WithAdditionalRequestOptions(oc.WithBasicAuth(opencasttest.DefaultUsername, opencasttest.DefaultPassword)),
+		},
+	}
+}
+
+func TestOrganizationClientFanOut(t *testing.T) {
+	org1 := newOrganizationServer(t, "org1", "e1", "e2")
+	org2 := newOrganizationServer(t, "org2", "e3")
+
+	var userAgents []string
+	org1.OnRequest(func(r *http.Request) { userAgents = append(userAgents, r.UserAgent()) })
+
+	c, err := extapiclientv1.NewOrganizationClient([]extapiclientv1.Tenant{tenant(org2, "org2"), tenant(org1, "org1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := c.Organizations(); len(ids) != 2 || ids[0] != "org1" || ids[1] != "org2" {
+		t.Fatalf("got organizations %v", ids)
+	}
+
+	items, err := extapiclientv1.FanOutList(context.Background(), c,
+		func(ctx context.Context, c extapiclientv1.Client) ([]extapiv1.Event, *oc.Response, error) {
+			return c.ListEvent(ctx)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[0].OrganizationID != "org1" || items[2].OrganizationID != "org2" {
+		t.Fatalf("got items %v", items)
+	}
+
+	// the organization is verified before the first request
+	if len(userAgents) != 2 {
+		t.Fatalf("got %d requests, want 2", len(userAgents))
+	}
+	for _, ua := range userAgents {
+		if ua != oc.UserAgent {
+			t.Fatalf("got User-Agent %q, want %q", ua, oc.UserAgent)
+		}
+	}
+}
+
+func TestOrganizationClientMismatch(t *testing.T) {
+	good := newOrganizationServer(t, "good")
+	wrong := newOrganizationServer(t, "other")
+
+	c, err := extapiclientv1.NewOrganizationClient([]extapiclientv1.Tenant{tenant(good, "good"), tenant(wrong, "wrong")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	client, err := c.ForOrganization("wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.ListEvent(ctx); !errors.Is(err, extapiclientv1.OrganizationMismatchErr) {
+		t.Fatalf("got error %v, want %v", err, extapiclientv1.OrganizationMismatchErr)
+	}
+
+	client, err = c.ForOrganization("good")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.ListEvent(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.Verify(ctx)
+	var orgErr *extapiclientv1.OrganizationError
+	if !errors.As(err, &orgErr) || orgErr.OrganizationID != "wrong" || !errors.Is(err, extapiclientv1.OrganizationMismatchErr) {
+		t.Fatalf("got error %v, want mismatch of organization wrong", err)
+	}
+
+	if _, err := c.ForOrganization("unknown"); !errors.Is(err, extapiclientv1.OrganizationNotFoundErr) {
+		t.Fatalf("got error %v, want %v", err, extapiclientv1.OrganizationNotFoundErr)
+	}
+}
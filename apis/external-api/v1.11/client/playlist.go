@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -57,6 +58,13 @@ func (c *client) ListPlaylistRequest(ctx context.Context, opts ...oc.RequestOpts
 	)
 }
 
+func (c *client) StreamPlaylist(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Playlist, error] {
+	return oc.GenericStreamedDo[extapiv1.Playlist](
+		c,
+		func() (*oc.Request, error) { return c.ListPlaylistRequest(ctx, opts...) },
+	)
+}
+
 func (c *client) CreatePlaylist(ctx context.Context, body *CreatePlaylistRequestBody, opts ...oc.RequestOpts) (*extapiv1.Playlist, *oc.Response, error) {
 	return oc.GenericAutoDecodedDo[*extapiv1.Playlist](
 		c,
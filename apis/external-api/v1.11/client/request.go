@@ -17,9 +17,7 @@ limitations under the License.
 package client
 
 import (
-	"strconv"
-	"strings"
-
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
 	oc "shio.solutions/tales.media/opencast-client-go/client"
 )
 
@@ -27,108 +25,18 @@ func WithSignedURLs() oc.RequestOpts {
 	return oc.WithQuery("sign", "true")
 }
 
-type WithPagination struct {
-	Limit  int
-	Offset int
-}
-
-var _ oc.RequestOpts = WithPagination{}
-
-func (opt WithPagination) Apply(r *oc.Request) error {
-	return r.ApplyOptions(
-		oc.WithQuery("limit", strconv.Itoa(opt.Limit)),
-		oc.WithQuery("offset", strconv.Itoa(opt.Offset)),
-	)
-}
-
-type WithFilter map[FilterKey]string
-
-var _ oc.RequestOpts = WithFilter{}
-
-type FilterKey string
-
-func (opt WithFilter) Apply(r *oc.Request) error {
-	if len(opt) == 0 {
-		return nil
-	}
-
-	sb := strings.Builder{}
-
-	// grow string builder once
-	n := len(opt) - 1 // comma
-	for k, v := range opt {
-		n = n +
-			len(k) + // key
-			1 + // colon
-			len(v) // value
-	}
-	sb.Grow(n)
-
-	// build filter
-	for k, v := range opt {
-		if sb.Len() > 0 {
-			sb.WriteString(",")
-		}
-		sb.WriteString(string(k))
-		sb.WriteString(":")
-		sb.WriteString(v)
-	}
-
-	return r.ApplyOptions(oc.WithQuery("filter", sb.String()))
-}
-
-type WithSort []Sort
-
-func (opt WithSort) Apply(r *oc.Request) error {
-	if len(opt) == 0 {
-		return nil
-	}
-
-	sb := strings.Builder{}
-
-	// grow string builder once
-	n := len(opt) - 1 // comma
-	for _, s := range opt {
-		n = n +
-			len(s.By) + // by
-			1 // colon
-		// direction
-		if s.Direction == "" {
-			n = n + len(Ascending)
-		} else {
-			n = n + len(s.Direction)
-		}
-	}
-
-	// build filter
-	for i, s := range opt {
-		if i > 0 {
-			sb.WriteString(",")
-		}
-		sb.WriteString(string(s.By))
-		sb.WriteString(":")
-		if s.Direction == "" {
-			sb.WriteString(string(Ascending))
-		} else {
-			sb.WriteString(string(s.Direction))
-		}
-	}
-
-	return r.ApplyOptions(oc.WithQuery("sort", sb.String()))
-}
-
-var _ oc.RequestOpts = WithSort{}
-
-type Sort struct {
-	By        SortKey
-	Direction SortDirection
-}
-
-type SortKey string
-
-type SortDirection string
+// The list options are shared with the admin services, see package listopts.
+type (
+	WithPagination = listopts.WithPagination
+	WithFilter     = listopts.WithFilter
+	FilterKey      = listopts.FilterKey
+	WithSort       = listopts.WithSort
+	Sort           = listopts.Sort
+	SortKey        = listopts.SortKey
+	SortDirection  = listopts.SortDirection
+)
 
 const (
-	Ascending  = SortDirection("ASC")
-	Descending = SortDirection("DESC")
+	Ascending  = listopts.Ascending
+	Descending = listopts.Descending
 )
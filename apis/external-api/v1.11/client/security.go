@@ -54,6 +54,7 @@ func (c *client) SignURLRequest(ctx context.Context, body *SignURLRequestBody, o
 		SecurityServiceType,
 		"/api/security/sign",
 		oc.NewMultipartBody(mp),
-		opts...,
+		// signing has no side effects and can be hedged
+		append([]oc.RequestOpts{oc.WithIdempotent()}, opts...)...,
 	)
 }
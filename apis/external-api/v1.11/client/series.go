@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -33,7 +34,8 @@ import (
 type CreateSeriesRequestBody struct {
 	ACL      extapiv1.ACL
 	Metadata []extapiv1.Catalog
-	Theme    string
+	// Theme is the ID of the theme, see the themes client to find it by name.
+	Theme string
 }
 
 type UpdateSeriesRequestBody struct {
@@ -200,6 +202,13 @@ func (c *client) ListSeriesRequest(ctx context.Context, opts ...oc.RequestOpts)
 	)
 }
 
+func (c *client) StreamSeries(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.Series, error] {
+	return oc.GenericStreamedDo[extapiv1.Series](
+		c,
+		func() (*oc.Request, error) { return c.ListSeriesRequest(ctx, opts...) },
+	)
+}
+
 func (c *client) SearchSeries(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.Series, *oc.Response, error) {
 	return oc.GenericAutoDecodedDo[[]extapiv1.Series](
 		c,
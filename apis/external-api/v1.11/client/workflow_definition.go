@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -70,6 +71,13 @@ func (c *client) ListWorkflowDefinitionRequest(ctx context.Context, opts ...oc.R
 	)
 }
 
+func (c *client) StreamWorkflowDefinition(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[extapiv1.WorkflowDefinition, error] {
+	return oc.GenericStreamedDo[extapiv1.WorkflowDefinition](
+		c,
+		func() (*oc.Request, error) { return c.ListWorkflowDefinitionRequest(ctx, opts...) },
+	)
+}
+
 func (c *client) GetWorkflowDefinition(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowDefinition, *oc.Response, error) {
 	return oc.GenericAutoDecodedDo[*extapiv1.WorkflowDefinition](
 		c,
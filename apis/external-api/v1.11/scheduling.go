@@ -0,0 +1,55 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_11
+
+import (
+	"errors"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/pkg/rrule"
+)
+
+var IncompleteSchedulingErr = errors.New("incomplete scheduling")
+
+func (r RRule) Parse() (*rrule.Rule, error) {
+	return rrule.Parse(string(r))
+}
+
+// Periods returns the recordings the scheduling request creates. Recurring
+// requests need End and Duration and are expanded in loc, which should be the
+// time zone of the Opencast server.
+func (r *SchedulingRequest) Periods(loc *time.Location) ([]rrule.Period, error) {
+	if r.RRule == nil {
+		switch {
+		case r.End != nil:
+			return []rrule.Period{{Start: r.Start.Time, End: r.End.Time}}, nil
+		case r.Duration != nil:
+			return []rrule.Period{{Start: r.Start.Time, End: r.Start.Time.Add(time.Duration(*r.Duration) * time.Millisecond)}}, nil
+		default:
+			return nil, IncompleteSchedulingErr
+		}
+	}
+
+	if r.End == nil || r.Duration == nil {
+		return nil, IncompleteSchedulingErr
+	}
+	rule, err := r.RRule.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return rule.Periods(r.Start.Time, r.End.Time, time.Duration(*r.Duration)*time.Millisecond, loc)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1_12 contains the types of version 1.12 of the Opencast External
+// API. No schema change since v1.11 is modelled, so all types are aliases of
+// the v1.11 types and values can be passed between the versions as is. A type
+// whose schema changes gets its own definition here, together with
+// conversion funcs from and to the v1.11 type. Constants are used from the
+// package of the version they were introduced in.
+package v1_12
+
+import (
+	v1_11 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+)
+
+const Version = "v1.12.0"
+
+type (
+	ACL                                = v1_11.ACL
+	ACE                                = v1_11.ACE
+	Catalog                            = v1_11.Catalog
+	Value                              = v1_11.Value
+	Field                              = v1_11.Field
+	FieldValue                         = v1_11.FieldValue
+	FieldType                          = v1_11.FieldType
+	API                                = v1_11.API
+	APIVersion                         = v1_11.APIVersion
+	Me                                 = v1_11.Me
+	Organization                       = v1_11.Organization
+	SignedURL                          = v1_11.SignedURL
+	Group                              = v1_11.Group
+	StatisticProvider                  = v1_11.StatisticProvider
+	StatisticProviderType              = v1_11.StatisticProviderType
+	StatisticResourceType              = v1_11.StatisticResourceType
+	StatisticProviderParameter         = v1_11.StatisticProviderParameter
+	StatisticProviderParameterType     = v1_11.StatisticProviderParameterType
+	StatisticQuery                     = v1_11.StatisticQuery
+	StatisticQueryResult               = v1_11.StatisticQueryResult
+	StatisticQueryResultTimeSeriesData = v1_11.StatisticQueryResultTimeSeriesData
+	Agent                              = v1_11.Agent
+	AgentStatus                        = v1_11.AgentStatus
+	Identifier                         = v1_11.Identifier
+	Event                              = v1_11.Event
+	EventStatus                        = v1_11.EventStatus
+	ProcessingState                    = v1_11.ProcessingState
+	Processing                         = v1_11.Processing
+	Scheduling                         = v1_11.Scheduling
+	SchedulingRequest                  = v1_11.SchedulingRequest
+	RRule                              = v1_11.RRule
+	Publication                        = v1_11.Publication
+	TrackElement                       = v1_11.TrackElement
+	MediaTrackElement                  = v1_11.MediaTrackElement
+	MediaTrackElementStream            = v1_11.MediaTrackElementStream
+	ScanOrder                          = v1_11.ScanOrder
+	ScanType                           = v1_11.ScanType
+	AttachmentElement                  = v1_11.AttachmentElement
+	CatalogElement                     = v1_11.CatalogElement
+	Series                             = v1_11.Series
+	Playlist                           = v1_11.Playlist
+	PlaylistEntry                      = v1_11.PlaylistEntry
+	PlaylistEntryType                  = v1_11.PlaylistEntryType
+	PlaylistACE                        = v1_11.PlaylistACE
+	WorkflowInstance                   = v1_11.WorkflowInstance
+	WorkflowState                      = v1_11.WorkflowState
+	OperationInstance                  = v1_11.OperationInstance
+	WorkflowOperationState             = v1_11.WorkflowOperationState
+	WorkflowRetryStrategy              = v1_11.WorkflowRetryStrategy
+	WorkflowDefinition                 = v1_11.WorkflowDefinition
+	OperationDefinition                = v1_11.OperationDefinition
+)
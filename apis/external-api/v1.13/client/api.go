@@ -0,0 +1,32 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client is the client of version 1.13 of the External API. It sends
+// the requests of the v1.11 client, which are unchanged, with the version
+// 1.13 Accept header.
+package client
+
+import (
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	v1_13 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.13"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client = extapiclientv1.Client
+
+func New(opencastClient oc.Client) Client {
+	return extapiclientv1.NewVersion(opencastClient, v1_13.Version)
+}
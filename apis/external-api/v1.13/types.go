@@ -0,0 +1,83 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1_13 contains the types of version 1.13 of the Opencast External
+// API. No schema change since v1.12 is modelled, so all types are aliases of
+// the v1.12 types and values can be passed between the versions as is. A type
+// whose schema changes gets its own definition here, together with
+// conversion funcs from and to the v1.12 type. Constants are used from the
+// package of the version they were introduced in.
+package v1_13
+
+import (
+	v1_12 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.12"
+)
+
+const Version = "v1.13.0"
+
+type (
+	ACL                                = v1_12.ACL
+	ACE                                = v1_12.ACE
+	Catalog                            = v1_12.Catalog
+	Value                              = v1_12.Value
+	Field                              = v1_12.Field
+	FieldValue                         = v1_12.FieldValue
+	FieldType                          = v1_12.FieldType
+	API                                = v1_12.API
+	APIVersion                         = v1_12.APIVersion
+	Me                                 = v1_12.Me
+	Organization                       = v1_12.Organization
+	SignedURL                          = v1_12.SignedURL
+	Group                              = v1_12.Group
+	StatisticProvider                  = v1_12.StatisticProvider
+	StatisticProviderType              = v1_12.StatisticProviderType
+	StatisticResourceType              = v1_12.StatisticResourceType
+	StatisticProviderParameter         = v1_12.StatisticProviderParameter
+	StatisticProviderParameterType     = v1_12.StatisticProviderParameterType
+	StatisticQuery                     = v1_12.StatisticQuery
+	StatisticQueryResult               = v1_12.StatisticQueryResult
+	StatisticQueryResultTimeSeriesData = v1_12.StatisticQueryResultTimeSeriesData
+	Agent                              = v1_12.Agent
+	AgentStatus                        = v1_12.AgentStatus
+	Identifier                         = v1_12.Identifier
+	Event                              = v1_12.Event
+	EventStatus                        = v1_12.EventStatus
+	ProcessingState                    = v1_12.ProcessingState
+	Processing                         = v1_12.Processing
+	Scheduling                         = v1_12.Scheduling
+	SchedulingRequest                  = v1_12.SchedulingRequest
+	RRule                              = v1_12.RRule
+	Publication                        = v1_12.Publication
+	TrackElement                       = v1_12.TrackElement
+	MediaTrackElement                  = v1_12.MediaTrackElement
+	MediaTrackElementStream            = v1_12.MediaTrackElementStream
+	ScanOrder                          = v1_12.ScanOrder
+	ScanType                           = v1_12.ScanType
+	AttachmentElement                  = v1_12.AttachmentElement
+	CatalogElement                     = v1_12.CatalogElement
+	Series                             = v1_12.Series
+	Playlist                           = v1_12.Playlist
+	PlaylistEntry                      = v1_12.PlaylistEntry
+	PlaylistEntryType                  = v1_12.PlaylistEntryType
+	PlaylistACE                        = v1_12.PlaylistACE
+	WorkflowInstance                   = v1_12.WorkflowInstance
+	WorkflowState                      = v1_12.WorkflowState
+	OperationInstance                  = v1_12.OperationInstance
+	WorkflowOperationState             = v1_12.WorkflowOperationState
+	WorkflowRetryStrategy              = v1_12.WorkflowRetryStrategy
+	WorkflowDefinition                 = v1_12.WorkflowDefinition
+	OperationDefinition                = v1_12.OperationDefinition
+)
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/ingest"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+// Client adds elements to a media package step by step and starts processing
+// it. Each step returns the updated media package.
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	CreateMediaPackage(ctx context.Context, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error)
+	CreateMediaPackageRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// CreateMediaPackageWithID creates a media package with the identifier of
+	// a scheduled event, e.g. to upload a recording.
+	CreateMediaPackageWithID(ctx context.Context, id string, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error)
+	CreateMediaPackageWithIDRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	AddDCCatalog(ctx context.Context, body *AddDCCatalogRequestBody, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error)
+	AddDCCatalogRequest(ctx context.Context, body *AddDCCatalogRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	AddTrack(ctx context.Context, body *AddTrackRequestBody, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error)
+	AddTrackRequest(ctx context.Context, body *AddTrackRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	Ingest(ctx context.Context, body *IngestRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	IngestRequest(ctx context.Context, body *IngestRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
+
+type AddDCCatalogRequestBody struct {
+	MediaPackage *mediapackage.MediaPackage
+	// DublinCore is the XML catalog.
+	DublinCore []byte
+	// Flavor defaults to dublincore/episode.
+	Flavor base.Flavor
+}
+
+type AddTrackRequestBody struct {
+	MediaPackage *mediapackage.MediaPackage
+	Flavor       base.Flavor
+	// File is the path of the media file.
+	File string
+	Tags []string
+}
+
+type IngestRequestBody struct {
+	MediaPackage         *mediapackage.MediaPackage
+	WorkflowDefinitionID string
+	// WorkflowConfiguration is passed to the workflow.
+	WorkflowConfiguration base.Properties
+}
+
+func (c *client) CreateMediaPackage(ctx context.Context, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*mediapackage.MediaPackage](
+		c,
+		func() (*oc.Request, error) { return c.CreateMediaPackageRequest(ctx, opts...) },
+	)
+}
+
+func (c *client) CreateMediaPackageRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		ingest.ServiceType,
+		"/ingest/createMediaPackage",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) CreateMediaPackageWithID(ctx context.Context, id string, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*mediapackage.MediaPackage](
+		c,
+		func() (*oc.Request, error) { return c.CreateMediaPackageWithIDRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) CreateMediaPackageWithIDRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodPut,
+		ingest.ServiceType,
+		"/ingest/createMediaPackageWithID/"+url.PathEscape(id),
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) AddDCCatalog(ctx context.Context, body *AddDCCatalogRequestBody, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*mediapackage.MediaPackage](
+		c,
+		func() (*oc.Request, error) { return c.AddDCCatalogRequest(ctx, body, opts...) },
+	)
+}
+
+func (c *client) AddDCCatalogRequest(ctx context.Context, body *AddDCCatalogRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mpXML, err := xml.Marshal(body.MediaPackage)
+	if err != nil {
+		return nil, err
+	}
+	flavor := body.Flavor
+	if flavor == "" {
+		flavor = base.DublinCoreEpisodeFlavor
+	}
+
+	mp := multipart.New()
+	mp.AddPart(multipart.FormField("mediaPackage", mpXML))
+	mp.AddPart(multipart.FormField("dublinCore", body.DublinCore))
+	mp.AddPart(multipart.FormFieldString("flavor", string(flavor)))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		ingest.ServiceType,
+		"/ingest/addDCCatalog",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func (c *client) AddTrack(ctx context.Context, body *AddTrackRequestBody, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*mediapackage.MediaPackage](
+		c,
+		func() (*oc.Request, error) { return c.AddTrackRequest(ctx, body, opts...) },
+	)
+}
+
+func (c *client) AddTrackRequest(ctx context.Context, body *AddTrackRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mpXML, err := xml.Marshal(body.MediaPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	mp := multipart.New()
+	mp.AddPart(multipart.FormField("mediaPackage", mpXML))
+	mp.AddPart(multipart.FormFieldString("flavor", string(body.Flavor)))
+	for _, tag := range body.Tags {
+		mp.AddPart(multipart.FormFieldString("tags", tag))
+	}
+	// the file must be the last part
+	mp.AddPart(multipart.File("BODY", body.File))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		ingest.ServiceType,
+		"/ingest/addTrack",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func (c *client) Ingest(ctx context.Context, body *IngestRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.IngestRequest(ctx, body, opts...) },
+	)
+}
+
+func (c *client) IngestRequest(ctx context.Context, body *IngestRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mpXML, err := xml.Marshal(body.MediaPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	mp := multipart.New()
+	mp.AddPart(multipart.FormField("mediaPackage", mpXML))
+	for _, k := range slices.Sorted(maps.Keys(body.WorkflowConfiguration)) {
+		mp.AddPart(multipart.FormFieldString(k, body.WorkflowConfiguration[k]))
+	}
+	path := "/ingest/ingest"
+	if body.WorkflowDefinitionID != "" {
+		path += "/" + url.PathEscape(body.WorkflowDefinitionID)
+	}
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		ingest.ServiceType,
+		path,
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mediapackage contains the JSON and XML representation of Opencast
+// media packages as used by the internal services, e.g. search and scheduler.
+package mediapackage
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"slices"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+const Namespace = "http://mediapackage.opencastproject.org"
+
+// Envelope wraps a media package as in the JSON responses of Opencast.
+type Envelope struct {
+	MediaPackage MediaPackage `json:"mediapackage"`
+}
+
+type MediaPackage struct {
+	XMLName xml.Name `json:"-" xml:"http://mediapackage.opencastproject.org mediapackage"`
+
+	ID          string        `json:"id" xml:"id,attr"`
+	Start       base.DateTime `json:"start,omitzero" xml:"start,attr,omitempty"`
+	Duration    *base.Int     `json:"duration,omitempty" xml:"duration,attr,omitempty"` // milliseconds
+	Title       string        `json:"title,omitempty" xml:"title,omitempty"`
+	Series      string        `json:"series,omitempty" xml:"series,omitempty"`
+	SeriesTitle string        `json:"seriestitle,omitempty" xml:"seriestitle,omitempty"`
+	License     string        `json:"license,omitempty" xml:"license,omitempty"`
+	Language    string        `json:"language,omitempty" xml:"language,omitempty"`
+	Source      string        `json:"source,omitempty" xml:"source,omitempty"`
+
+	Creators     *Creators     `json:"creators,omitempty" xml:"creators,omitempty"`
+	Contributors *Contributors `json:"contributors,omitempty" xml:"contributors,omitempty"`
+	Subjects     *Subjects     `json:"subjects,omitempty" xml:"subjects,omitempty"`
+
+	Media        *Media        `json:"media,omitempty" xml:"media,omitempty"`
+	Metadata     *Metadata     `json:"metadata,omitempty" xml:"metadata,omitempty"`
+	Attachments  *Attachments  `json:"attachments,omitempty" xml:"attachments,omitempty"`
+	Publications *Publications `json:"publications,omitempty" xml:"publications,omitempty"`
+}
+
+type Creators struct {
+	Creator List[string] `json:"creator,omitempty" xml:"creator,omitempty"`
+}
+
+type Contributors struct {
+	Contributor List[string] `json:"contributor,omitempty" xml:"contributor,omitempty"`
+}
+
+type Subjects struct {
+	Subject List[string] `json:"subject,omitempty" xml:"subject,omitempty"`
+}
+
+type Media struct {
+	Track List[Track] `json:"track,omitempty" xml:"track,omitempty"`
+}
+
+type Metadata struct {
+	Catalog List[Catalog] `json:"catalog,omitempty" xml:"catalog,omitempty"`
+}
+
+type Attachments struct {
+	Attachment List[Attachment] `json:"attachment,omitempty" xml:"attachment,omitempty"`
+}
+
+type Publications struct {
+	Publication List[Publication] `json:"publication,omitempty" xml:"publication,omitempty"`
+}
+
+type Tags struct {
+	Tag List[string] `json:"tag,omitempty" xml:"tag,omitempty"`
+}
+
+// Element contains the fields common to tracks, catalogs and attachments.
+type Element struct {
+	ID       string      `json:"id,omitempty" xml:"id,attr,omitempty"`
+	Flavor   base.Flavor `json:"type,omitempty" xml:"type,attr,omitempty"`
+	Ref      string      `json:"ref,omitempty" xml:"ref,attr,omitempty"`
+	MimeType string      `json:"mimetype,omitempty" xml:"mimetype,omitempty"`
+	Tags     *Tags       `json:"tags,omitempty" xml:"tags,omitempty"`
+	URL      string      `json:"url,omitempty" xml:"url,omitempty"`
+	Checksum *Checksum   `json:"checksum,omitempty" xml:"checksum,omitempty"`
+	Size     *base.Int   `json:"size,omitempty" xml:"size,omitempty"`
+}
+
+type Checksum struct {
+	Type  string `json:"type" xml:"type,attr,omitempty"`
+	Value string `json:"$" xml:",chardata"`
+}
+
+type Track struct {
+	Element
+
+	Duration  *base.Int    `json:"duration,omitempty" xml:"duration,omitempty"` // milliseconds
+	Audio     *AudioStream `json:"audio,omitempty" xml:"audio,omitempty"`
+	Video     *VideoStream `json:"video,omitempty" xml:"video,omitempty"`
+	Live      bool         `json:"live,omitempty" xml:"live,omitempty"`
+	Master    bool         `json:"master,omitempty" xml:"master,omitempty"`
+	Transport string       `json:"transport,omitempty" xml:"transport,omitempty"`
+}
+
+type Encoder struct {
+	Type string `json:"type,omitempty" xml:"type,attr,omitempty"`
+}
+
+type AudioStream struct {
+	ID           string      `json:"id,omitempty" xml:"id,attr,omitempty"`
+	Encoder      *Encoder    `json:"encoder,omitempty" xml:"encoder,omitempty"`
+	Channels     *base.Int   `json:"channels,omitempty" xml:"channels,omitempty"`
+	BitDepth     *base.Int   `json:"bitdepth,omitempty" xml:"bitdepth,omitempty"`
+	BitRate      *base.Float `json:"bitrate,omitempty" xml:"bitrate,omitempty"`
+	SamplingRate *base.Int   `json:"samplingrate,omitempty" xml:"samplingrate,omitempty"`
+}
+
+type VideoStream struct {
+	ID         string      `json:"id,omitempty" xml:"id,attr,omitempty"`
+	Encoder    *Encoder    `json:"encoder,omitempty" xml:"encoder,omitempty"`
+	FrameCount *base.Int   `json:"framecount,omitempty" xml:"framecount,omitempty"`
+	BitRate    *base.Float `json:"bitrate,omitempty" xml:"bitrate,omitempty"`
+	FrameRate  *base.Float `json:"framerate,omitempty" xml:"framerate,omitempty"`
+	Resolution string      `json:"resolution,omitempty" xml:"resolution,omitempty"` // e.g. 1920x1080
+}
+
+type Catalog struct {
+	Element
+}
+
+type Attachment struct {
+	Element
+}
+
+type Publication struct {
+	ID       string `json:"id,omitempty" xml:"id,attr,omitempty"`
+	Channel  string `json:"channel,omitempty" xml:"channel,attr,omitempty"`
+	MimeType string `json:"mimetype,omitempty" xml:"mimetype,omitempty"`
+	URL      string `json:"url,omitempty" xml:"url,omitempty"`
+
+	Media       *Media       `json:"media,omitempty" xml:"media,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty" xml:"metadata,omitempty"`
+	Attachments *Attachments `json:"attachments,omitempty" xml:"attachments,omitempty"`
+}
+
+// List is a list of elements. Opencast represents lists with a single element
+// as object, which List accepts as well.
+type List[T any] []T
+
+func (l *List[T]) UnmarshalJSON(value []byte) error {
+	value = bytes.TrimSpace(value)
+	switch {
+	case bytes.Equal(value, []byte("null")), bytes.Equal(value, []byte(`""`)):
+		*l = nil
+		return nil
+	case len(value) > 0 && value[0] == '[':
+		return json.Unmarshal(value, (*[]T)(l))
+	default:
+		var v T
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		*l = List[T]{v}
+		return nil
+	}
+}
+
+func (mp *MediaPackage) Tracks() []Track {
+	if mp.Media == nil {
+		return nil
+	}
+	return mp.Media.Track
+}
+
+func (mp *MediaPackage) Catalogs() []Catalog {
+	if mp.Metadata == nil {
+		return nil
+	}
+	return mp.Metadata.Catalog
+}
+
+func (mp *MediaPackage) AttachmentList() []Attachment {
+	if mp.Attachments == nil {
+		return nil
+	}
+	return mp.Attachments.Attachment
+}
+
+func (mp *MediaPackage) PublicationList() []Publication {
+	if mp.Publications == nil {
+		return nil
+	}
+	return mp.Publications.Publication
+}
+
+// Publication returns the publication of the media package to channel.
+func (mp *MediaPackage) Publication(channel string) (*Publication, bool) {
+	for i, p := range mp.PublicationList() {
+		if p.Channel == channel {
+			return &mp.Publications.Publication[i], true
+		}
+	}
+	return nil, false
+}
+
+func (mp *MediaPackage) CreatorList() []string {
+	if mp.Creators == nil {
+		return nil
+	}
+	return mp.Creators.Creator
+}
+
+func (mp *MediaPackage) ContributorList() []string {
+	if mp.Contributors == nil {
+		return nil
+	}
+	return mp.Contributors.Contributor
+}
+
+func (mp *MediaPackage) SubjectList() []string {
+	if mp.Subjects == nil {
+		return nil
+	}
+	return mp.Subjects.Subject
+}
+
+func (p *Publication) Tracks() []Track {
+	if p.Media == nil {
+		return nil
+	}
+	return p.Media.Track
+}
+
+func (p *Publication) Catalogs() []Catalog {
+	if p.Metadata == nil {
+		return nil
+	}
+	return p.Metadata.Catalog
+}
+
+func (p *Publication) AttachmentList() []Attachment {
+	if p.Attachments == nil {
+		return nil
+	}
+	return p.Attachments.Attachment
+}
+
+func (e *Element) TagList() []string {
+	if e.Tags == nil {
+		return nil
+	}
+	return e.Tags.Tag
+}
+
+func (e *Element) HasTag(tag string) bool {
+	return slices.Contains(e.TagList(), tag)
+}
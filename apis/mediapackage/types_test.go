@@ -0,0 +1,108 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mediapackage_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func TestUnmarshalSingleElementLists(t *testing.T) {
+	// Opencast encodes lists with a single element as object
+	const body = `{"mediapackage":{
+		"id":"mp1",
+		"duration":3600000,
+		"creators":{"creator":"Jane"},
+		"media":{"track":{"id":"t1","type":"presenter/source","tags":{"tag":"archive"},"duration":3600000}},
+		"publications":{"publication":[{"id":"p1","channel":"engage-player"},{"id":"p2","channel":"api"}]},
+		"metadata":{"catalog":""}
+	}}`
+
+	var env mediapackage.Envelope
+	if err := json.Unmarshal([]byte(body), &env); err != nil {
+		t.Fatal(err)
+	}
+	mp := env.MediaPackage
+	if mp.ID != "mp1" || mp.Duration == nil || *mp.Duration != 3600000 {
+		t.Fatalf("got media package %+v", mp)
+	}
+	if c := mp.CreatorList(); len(c) != 1 || c[0] != "Jane" {
+		t.Fatalf("got creators %v, want [Jane]", c)
+	}
+	tracks := mp.Tracks()
+	if len(tracks) != 1 || tracks[0].Flavor != "presenter/source" || !tracks[0].HasTag("archive") {
+		t.Fatalf("got tracks %+v", tracks)
+	}
+	if len(mp.PublicationList()) != 2 {
+		t.Fatalf("got publications %+v, want 2", mp.PublicationList())
+	}
+	if p, ok := mp.Publication("api"); !ok || p.ID != "p2" {
+		t.Fatalf("got publication %+v, want p2", p)
+	}
+	if len(mp.Catalogs()) != 0 {
+		t.Fatalf("got catalogs %+v, want none", mp.Catalogs())
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	size := base.Int(1024)
+	in := mediapackage.MediaPackage{
+		ID:    "mp1",
+		Start: base.DateTime{Time: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)},
+		Title: "Lecture",
+		Media: &mediapackage.Media{Track: mediapackage.List[mediapackage.Track]{{
+			Element: mediapackage.Element{
+				ID:       "t1",
+				Flavor:   "presenter/source",
+				URL:      "https://example.org/t1.mp4",
+				Tags:     &mediapackage.Tags{Tag: []string{"archive", "engage"}},
+				Checksum: &mediapackage.Checksum{Type: "md5", Value: "abc"},
+				Size:     &size,
+			},
+			Video: &mediapackage.VideoStream{Resolution: "1920x1080"},
+		}}},
+	}
+
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`xmlns="http://mediapackage.opencastproject.org"`, `id="mp1"`, `type="presenter/source"`, `<checksum type="md5">abc</checksum>`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s does not contain %s", b, want)
+		}
+	}
+
+	var out mediapackage.MediaPackage
+	if err := xml.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != in.ID || !out.Start.Time.Equal(in.Start.Time) || out.Title != in.Title {
+		t.Fatalf("got %+v, want %+v", out, in)
+	}
+	tracks := out.Tracks()
+	if len(tracks) != 1 || tracks[0].URL != "https://example.org/t1.mp4" || len(tracks[0].TagList()) != 2 ||
+		tracks[0].Size == nil || *tracks[0].Size != size || tracks[0].Video == nil || tracks[0].Video.Resolution != "1920x1080" {
+		t.Fatalf("got tracks %+v", tracks)
+	}
+}
@@ -18,7 +18,11 @@ package base
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -26,6 +30,33 @@ const HourMinuteOnly = "15:04"
 
 type Properties map[string]string
 
+// ParseProperties parses the key=value lines of the Java properties format as
+// used by Opencast services. Escapes and continuation lines are not supported.
+func ParseProperties(s string) Properties {
+	props := Properties{}
+	for line := range strings.Lines(s) {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		k, v, _ := strings.Cut(line, "=")
+		props[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return props
+}
+
+// Format formats the properties sorted by key in the Java properties format.
+func (p Properties) Format() string {
+	var sb strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(p)) {
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(p[k])
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
 type DateTime struct {
 	Time time.Time
 
@@ -58,6 +89,15 @@ func (dt DateTime) MarshalText() ([]byte, error) {
 	return buf, nil
 }
 
+// MarshalXMLAttr omits the attribute for the zero time.
+func (dt DateTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if dt.IsZero() {
+		return xml.Attr{}, nil
+	}
+	b, err := dt.MarshalText()
+	return xml.Attr{Name: name, Value: string(b)}, err
+}
+
 func (dt DateTime) MarshalJSON() ([]byte, error) {
 	if dt.IsZero() {
 		return []byte{'"', '"'}, nil
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base_test
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func TestParseProperties(t *testing.T) {
+	props := base.ParseProperties("# comment\n! comment\n\ncapture.device.names = presenter,slides\norg.opencastproject.workflow.definition=fast\nflag\n")
+	want := base.Properties{
+		"capture.device.names":                    "presenter,slides",
+		"org.opencastproject.workflow.definition": "fast",
+		"flag": "",
+	}
+	if !maps.Equal(props, want) {
+		t.Errorf("got %v, want %v", props, want)
+	}
+}
+
+func TestPropertiesFormat(t *testing.T) {
+	props := base.Properties{"b": "2", "a": "1"}
+	if got, want := props.Format(), "a=1\nb=2\n"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+	if got := base.ParseProperties(props.Format()); !maps.Equal(got, props) {
+		t.Errorf("round trip = %v, want %v", got, props)
+	}
+}
+
+func TestIntRejectsQuotedNumbers(t *testing.T) {
+	var i base.Int
+	if err := json.Unmarshal([]byte(`""`), &i); err != nil || i != 0 {
+		t.Errorf("Unmarshal(\"\") = %d, %v", i, err)
+	}
+	if err := json.Unmarshal([]byte(`"42"`), &i); err == nil {
+		t.Errorf("Unmarshal(\"42\") = %d, want error", i)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package listopts_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+func query(t *testing.T, opts ...oc.RequestOpts) map[string]string {
+	t.Helper()
+	req, err := oc.NewRequest(context.Background(), http.MethodGet, "org.opencastproject.test", "/list.json", oc.NoBody, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := map[string]string{}
+	for k := range req.Query {
+		q[k] = req.Query.Get(k)
+	}
+	return q
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []oc.RequestOpts
+		want map[string]string
+	}{
+		{"pagination", []oc.RequestOpts{listopts.WithPagination{Limit: 10, Offset: 20}}, map[string]string{"limit": "10", "offset": "20"}},
+		{"filter", []oc.RequestOpts{listopts.WithFilter{"textFilter": "doe"}}, map[string]string{"filter": "textFilter:doe"}},
+		{"empty filter", []oc.RequestOpts{listopts.WithFilter{}}, map[string]string{}},
+		{"sort", []oc.RequestOpts{listopts.WithSort{{By: "name"}, {By: "email", Direction: listopts.Descending}}}, map[string]string{"sort": "name:ASC,email:DESC"}},
+		{"empty sort", []oc.RequestOpts{listopts.WithSort{}}, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := query(t, tt.opts...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got query %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("got %s=%q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterWithSeveralKeys(t *testing.T) {
+	got := query(t, listopts.WithFilter{"role": "ROLE_ADMIN", "provider": "opencast"})["filter"]
+	if got != "role:ROLE_ADMIN,provider:opencast" && got != "provider:opencast,role:ROLE_ADMIN" {
+		t.Errorf("got filter %q", got)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+// Filenames of the attachments of calendar events.
+const (
+	EpisodeAttachment         = "episode.xml"
+	SeriesAttachment          = "series.xml"
+	AgentPropertiesAttachment = "org.opencastproject.capture.agent.properties"
+)
+
+const (
+	DeviceNamesAgentProperty        = "capture.device.names"
+	WorkflowDefinitionAgentProperty = "org.opencastproject.workflow.definition"
+	// WorkflowConfigAgentPropertyPrefix prefixes the workflow configuration
+	// in the agent properties.
+	WorkflowConfigAgentPropertyPrefix = "org.opencastproject.workflow.config."
+)
+
+// Calendar is the calendar of capture agents.
+type Calendar struct {
+	Events []CalendarEvent
+	// Skipped are the events that could not be decoded.
+	Skipped []CalendarEventError
+}
+
+// CalendarEventError reports a VEVENT of a calendar that could not be
+// decoded.
+type CalendarEventError struct {
+	// Index is the position of the VEVENT in the calendar.
+	Index   int
+	EventID string // empty if the UID is missing
+	Err     error
+}
+
+func (e *CalendarEventError) Error() string {
+	if e.EventID == "" {
+		return fmt.Sprintf("calendar event %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("calendar event %d (%s): %v", e.Index, e.EventID, e.Err)
+}
+
+func (e *CalendarEventError) Unwrap() error {
+	return e.Err
+}
+
+var _ ical.Unmarshaler = &Calendar{}
+
+// CalendarEvent is a scheduled recording as VEVENT in the calendar.
+type CalendarEvent struct {
+	EventID  string // UID
+	Title    string // SUMMARY
+	SeriesID string // RELATED-TO
+	AgentID  string // LOCATION
+	Start    time.Time
+	End      time.Time
+	// TimeZone is the time zone of DTSTART, UTC if not given.
+	TimeZone *time.Location
+
+	Attachments []CalendarAttachment
+}
+
+type CalendarAttachment struct {
+	Filename string
+	MimeType string
+	Data     []byte
+}
+
+// UnmarshalICal decodes the VEVENTs of the calendar. Events that cannot be
+// decoded are skipped and reported in Skipped.
+func (c *Calendar) UnmarshalICal(cal *ical.Component) error {
+	c.Events, c.Skipped = nil, nil
+	tzs := cal.Timezones()
+	for i, vevent := range cal.ComponentList(ical.EventComponent) {
+		e, err := calendarEventFromICal(&vevent, tzs)
+		if err != nil {
+			c.Skipped = append(c.Skipped, CalendarEventError{Index: i, EventID: e.EventID, Err: err})
+			continue
+		}
+		c.Events = append(c.Events, e)
+	}
+	return nil
+}
+
+func calendarEventFromICal(vevent *ical.Component, tzs ical.Timezones) (CalendarEvent, error) {
+	e := CalendarEvent{TimeZone: time.UTC}
+	uid, ok := vevent.Property("UID")
+	if !ok {
+		return e, fmt.Errorf("%w: event without UID", ical.MalformedCalendarErr)
+	}
+	e.EventID = uid.Text()
+	for name, v := range map[string]*string{"SUMMARY": &e.Title, "RELATED-TO": &e.SeriesID, "LOCATION": &e.AgentID} {
+		if p, ok := vevent.Property(name); ok {
+			*v = p.Text()
+		}
+	}
+
+	start, ok := vevent.Property("DTSTART")
+	if !ok {
+		return e, fmt.Errorf("%w: event %s without DTSTART", ical.MalformedCalendarErr, e.EventID)
+	}
+	var err error
+	if e.Start, err = start.Time(time.UTC, tzs); err != nil {
+		return e, err
+	}
+	if start.Params["TZID"] != "" {
+		e.TimeZone = e.Start.Location()
+	}
+	if end, ok := vevent.Property("DTEND"); ok {
+		if e.End, err = end.Time(e.TimeZone, tzs); err != nil {
+			return e, err
+		}
+	} else {
+		e.End = e.Start
+	}
+
+	for _, p := range vevent.PropertyList("ATTACH") {
+		if !strings.EqualFold(p.Params["VALUE"], "BINARY") {
+			continue // reference by URI
+		}
+		a := CalendarAttachment{
+			Filename: p.Params["X-APPLE-FILENAME"],
+			MimeType: p.Params["FMTTYPE"],
+		}
+		if a.Data, err = p.Binary(); err != nil {
+			return e, fmt.Errorf("attachment %s of event %s: %w", a.Filename, e.EventID, err)
+		}
+		e.Attachments = append(e.Attachments, a)
+	}
+	return e, nil
+}
+
+// ICal returns the event as VEVENT. Times are given in UTC.
+func (e *CalendarEvent) ICal() ical.Component {
+	vevent := ical.Component{
+		Name: ical.EventComponent,
+		Properties: []ical.Property{
+			ical.NewTextProperty("UID", e.EventID),
+			ical.NewTimeProperty("DTSTAMP", time.Now()),
+			ical.NewTimeProperty("DTSTART", e.Start),
+			ical.NewTimeProperty("DTEND", e.End),
+			ical.NewTextProperty("SUMMARY", e.Title),
+			ical.NewTextProperty("LOCATION", e.AgentID),
+		},
+	}
+	if e.SeriesID != "" {
+		vevent.Properties = append(vevent.Properties, ical.NewTextProperty("RELATED-TO", e.SeriesID))
+	}
+	for _, a := range e.Attachments {
+		vevent.Properties = append(vevent.Properties, ical.NewBinaryProperty("ATTACH", a.Data, map[string]string{
+			"FMTTYPE":          a.MimeType,
+			"X-APPLE-FILENAME": a.Filename,
+		}))
+	}
+	return vevent
+}
+
+// Attachment returns the data of the attachment with the given filename.
+func (e *CalendarEvent) Attachment(filename string) ([]byte, bool) {
+	for _, a := range e.Attachments {
+		if a.Filename == filename {
+			return a.Data, true
+		}
+	}
+	return nil, false
+}
+
+// AgentProperties returns the capture agent properties attached to the event,
+// e.g. the workflow to start after ingesting the recording.
+func (e *CalendarEvent) AgentProperties() base.Properties {
+	b, _ := e.Attachment(AgentPropertiesAttachment)
+	return base.ParseProperties(string(b))
+}
+
+// WorkflowConfiguration returns the workflow configuration of the agent
+// properties without prefix.
+func (e *CalendarEvent) WorkflowConfiguration() base.Properties {
+	config := base.Properties{}
+	for k, v := range e.AgentProperties() {
+		if key, ok := strings.CutPrefix(k, WorkflowConfigAgentPropertyPrefix); ok {
+			config[key] = v
+		}
+	}
+	return config
+}
+
+func (e *CalendarEvent) Scheduling() extapiv1.Scheduling {
+	s := extapiv1.Scheduling{
+		Start:   base.DateTime{Time: e.Start.UTC()},
+		End:     base.DateTime{Time: e.End.UTC()},
+		AgentID: e.AgentID,
+	}
+	if names := e.AgentProperties()[DeviceNamesAgentProperty]; names != "" {
+		s.Inputs = strings.Split(names, ",")
+	}
+	return s
+}
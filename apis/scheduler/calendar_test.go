@@ -0,0 +1,74 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+// MalformedCalendar contains a valid event between an event without DTSTART
+// and one in an unknown time zone.
+const MalformedCalendar = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\nUID:broken\r\nSUMMARY:No start\r\nEND:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nUID:ok\r\nSUMMARY:Lecture\r\nLOCATION:room-1\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20260302T090000\r\nDTEND;TZID=Europe/Berlin:20260302T103000\r\n" +
+	"ATTACH;VALUE=BINARY;ENCODING=BASE64;FMTTYPE=application/text;X-APPLE-FILENAME=org.opencastproject.capture.agent.properties:" +
+	"Y2FwdHVyZS5kZXZpY2UubmFtZXM9cHJlc2VudGVyLHNjcmVlbg==\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nUID:nowhere\r\nDTSTART;TZID=Nowhere:20260302T090000\r\nEND:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestCalendarSkipsMalformedEvents(t *testing.T) {
+	vcal, err := ical.Parse(strings.NewReader(MalformedCalendar))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cal scheduler.Calendar
+	if err := cal.UnmarshalICal(vcal); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cal.Events) != 1 {
+		t.Fatalf("got %d events, want 1", len(cal.Events))
+	}
+	e := cal.Events[0]
+	if e.EventID != "ok" || e.AgentID != "room-1" || e.TimeZone.String() != "Europe/Berlin" {
+		t.Fatalf("got event %+v", e)
+	}
+	if want := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC); !e.Start.Equal(want) || e.End.Sub(e.Start) != 90*time.Minute {
+		t.Fatalf("got %s to %s, want 90 minutes from %s", e.Start, e.End, want)
+	}
+	if inputs := e.Scheduling().Inputs; len(inputs) != 2 || inputs[1] != "screen" {
+		t.Fatalf("got inputs %v, want [presenter screen]", inputs)
+	}
+
+	if len(cal.Skipped) != 2 {
+		t.Fatalf("got skipped %v, want 2", cal.Skipped)
+	}
+	if s := cal.Skipped[0]; s.Index != 0 || s.EventID != "broken" || !errors.Is(&s, ical.MalformedCalendarErr) {
+		t.Errorf("got skipped %v, want event broken", &s)
+	}
+	if s := cal.Skipped[1]; s.Index != 2 || s.EventID != "nowhere" {
+		t.Errorf("got skipped %v, want event nowhere", &s)
+	}
+}
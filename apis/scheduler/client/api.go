@@ -0,0 +1,88 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Recordings
+
+	ListRecording(ctx context.Context, opts ...oc.RequestOpts) ([]mediapackage.MediaPackage, *oc.Response, error)
+	ListRecordingRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetRecording(ctx context.Context, id string, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error)
+	GetRecordingRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	CreateRecording(ctx context.Context, body *CreateRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	CreateRecordingRequest(ctx context.Context, body *CreateRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	CreateMultipleRecording(ctx context.Context, body *CreateMultipleRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	CreateMultipleRecordingRequest(ctx context.Context, body *CreateMultipleRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	DeleteRecording(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Response, error)
+	DeleteRecordingRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Conflicts
+
+	ListConflict(ctx context.Context, body *ConflictCheckRequestBody, opts ...oc.RequestOpts) ([]scheduler.Conflict, *oc.Response, error)
+	ListConflictRequest(ctx context.Context, body *ConflictCheckRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Calendar
+
+	GetCalendar(ctx context.Context, opts ...oc.RequestOpts) ([]byte, *oc.Response, error)
+	GetCalendarRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	ListCalendarEvent(ctx context.Context, opts ...oc.RequestOpts) ([]scheduler.CalendarEvent, *oc.Response, error)
+	ListCalendarEventRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
+
+// millis formats t as milliseconds since the epoch as expected by the
+// scheduler service.
+func millis(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+const CalendarMediaType = "text/calendar"
+
+// GetCalendar returns the iCalendar of the scheduled recordings.
+func (c *client) GetCalendar(ctx context.Context, opts ...oc.RequestOpts) ([]byte, *oc.Response, error) {
+	resp, err := oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.GetCalendarRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	return b, resp, err
+}
+
+func (c *client) GetCalendarRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		scheduler.ServiceType,
+		"/recordings/calendar",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// ListCalendarEvent returns the scheduled recordings of the calendar. Events
+// that cannot be decoded are skipped and reported in ResponseMeta.Diagnostics.
+func (c *client) ListCalendarEvent(ctx context.Context, opts ...oc.RequestOpts) ([]scheduler.CalendarEvent, *oc.Response, error) {
+	cal, resp, err := oc.GenericAutoDecodedDo[*scheduler.Calendar](
+		c,
+		func() (*oc.Request, error) { return c.ListCalendarEventRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, skipped := range cal.Skipped {
+		resp.Meta.Diagnostics = append(resp.Meta.Diagnostics, oc.DecodeDiagnostic{
+			Kind: oc.DecodeErrorDiagnosticKind,
+			Path: "VEVENT[" + strconv.Itoa(skipped.Index) + "]",
+			Err:  &skipped,
+		})
+	}
+	return cal.Events, resp, nil
+}
+
+// ListCalendarEventRequest is GetCalendarRequest with the iCalendar decoder.
+func (c *client) ListCalendarEventRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return c.GetCalendarRequest(ctx, slices.Concat([]oc.RequestOpts{oc.WithDecoder(CalendarMediaType, ICalDecoder)}, opts)...)
+}
+
+// ICalDecoder decodes an iCalendar into an *ical.Component or an
+// ical.Unmarshaler.
+func ICalDecoder(v any, resp *oc.Response) error {
+	cal, err := ical.Parse(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// allocate pointers as done by the JSON and XML decoders
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Pointer {
+		if rv.Elem().IsNil() {
+			rv.Elem().Set(reflect.New(rv.Elem().Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	switch v := rv.Interface().(type) {
+	case ical.Unmarshaler:
+		return v.UnmarshalICal(cal)
+	case *ical.Component:
+		*v = *cal
+		return nil
+	default:
+		return fmt.Errorf("ICalDecoder: cannot decode into %T", v)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+var ConflictErr = errors.New("scheduling conflict")
+
+// ConflictError is returned if a recording could not be scheduled because it
+// clashes with other events.
+type ConflictError struct {
+	Conflicts []scheduler.Conflict
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: clashes with %d events", ConflictErr, len(e.Conflicts))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ConflictErr
+}
+
+type ConflictCheckRequestBody struct {
+	Agent string
+	Start time.Time
+	End   time.Time
+	// RRule, Duration and TimeZone check a recurring recording from Start to
+	// End instead of a single one.
+	RRule    string
+	Duration time.Duration
+	TimeZone *time.Location
+}
+
+func (c *client) ListConflict(ctx context.Context, body *ConflictCheckRequestBody, opts ...oc.RequestOpts) ([]scheduler.Conflict, *oc.Response, error) {
+	resp, err := oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.ListConflictRequest(ctx, body, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, resp, nil
+	}
+	conflicts, err := decodeConflicts(resp)
+	return conflicts, resp, err
+}
+
+func (c *client) ListConflictRequest(ctx context.Context, body *ConflictCheckRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	req, err := oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		scheduler.ServiceType,
+		"/recordings/conflicts.json",
+		oc.NoBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Query.Set("agent", body.Agent)
+	req.Query.Set("start", millis(body.Start))
+	req.Query.Set("end", millis(body.End))
+	if body.RRule != "" {
+		tz := body.TimeZone
+		if tz == nil {
+			tz = time.UTC
+		}
+		req.Query.Set("rrule", body.RRule)
+		req.Query.Set("duration", strconv.FormatInt(body.Duration.Milliseconds(), 10))
+		req.Query.Set("timezone", tz.String())
+	}
+	if err := req.ApplyOptions(opts...); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func decodeConflicts(resp *oc.Response) ([]scheduler.Conflict, error) {
+	var list mediapackage.List[mediapackage.Envelope]
+	if err := resp.Decode(&list, oc.AutoDecoder); err != nil {
+		return nil, err
+	}
+	conflicts := make([]scheduler.Conflict, 0, len(list))
+	for _, e := range list {
+		conflicts = append(conflicts, scheduler.ConflictFromMediaPackage(e.MediaPackage))
+	}
+	return conflicts, nil
+}
+
+// doScheduling sends a scheduling request and turns 409 Conflict responses
+// into a [ConflictError].
+func doScheduling(do oc.Doer, reqFunc func() (*oc.Request, error)) (*oc.Response, error) {
+	resp, err := oc.GenericDo(do, reqFunc)
+	if err != nil && resp != nil && resp.StatusCode == http.StatusConflict {
+		conflicts, _ := decodeConflicts(resp)
+		return resp, &ConflictError{Conflicts: conflicts}
+	}
+	return resp, err
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+type CreateRecordingRequestBody struct {
+	Start time.Time
+	End   time.Time
+	Agent string
+	// Users are the usernames of the users which can access the event.
+	Users []string
+	// MediaPackage must contain the episode Dublin Core catalog.
+	MediaPackage       *mediapackage.MediaPackage
+	WorkflowProperties base.Properties
+	AgentParameters    base.Properties
+	Source             string
+}
+
+type CreateMultipleRecordingRequestBody struct {
+	// RRule is the recurrence rule, e.g. FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=8;BYMINUTE=15.
+	RRule    string
+	Start    time.Time
+	End      time.Time
+	Duration time.Duration
+	TimeZone *time.Location
+	Agent    string
+	Users    []string
+	// Template is the media package every recording is created from.
+	Template           *mediapackage.MediaPackage
+	WorkflowProperties base.Properties
+	AgentParameters    base.Properties
+	Source             string
+}
+
+func (c *client) ListRecording(ctx context.Context, opts ...oc.RequestOpts) ([]mediapackage.MediaPackage, *oc.Response, error) {
+	list, resp, err := oc.GenericAutoDecodedDo[mediapackage.List[mediapackage.Envelope]](
+		c,
+		func() (*oc.Request, error) { return c.ListRecordingRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	mps := make([]mediapackage.MediaPackage, 0, len(list))
+	for _, e := range list {
+		mps = append(mps, e.MediaPackage)
+	}
+	return mps, resp, nil
+}
+
+func (c *client) ListRecordingRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		scheduler.ServiceType,
+		"/recordings/search.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetRecording(ctx context.Context, id string, opts ...oc.RequestOpts) (*mediapackage.MediaPackage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*mediapackage.MediaPackage](
+		c,
+		func() (*oc.Request, error) { return c.GetRecordingRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) GetRecordingRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		scheduler.ServiceType,
+		"/recordings/"+url.PathEscape(id)+"/mediapackage.xml",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) CreateRecording(ctx context.Context, body *CreateRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return doScheduling(c, func() (*oc.Request, error) { return c.CreateRecordingRequest(ctx, body, opts...) })
+}
+
+func (c *client) CreateRecordingRequest(ctx context.Context, body *CreateRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mpXML, err := xml.Marshal(body.MediaPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("start", millis(body.Start)))
+	mp.AddPart(multipart.FormFieldString("end", millis(body.End)))
+	mp.AddPart(multipart.FormFieldString("agent", body.Agent))
+	mp.AddPart(multipart.FormField("mediaPackage", mpXML))
+	addSchedulingFields(mp, body.Users, body.WorkflowProperties, body.AgentParameters, body.Source)
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		scheduler.ServiceType,
+		"/recordings",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func (c *client) CreateMultipleRecording(ctx context.Context, body *CreateMultipleRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return doScheduling(c, func() (*oc.Request, error) { return c.CreateMultipleRecordingRequest(ctx, body, opts...) })
+}
+
+func (c *client) CreateMultipleRecordingRequest(ctx context.Context, body *CreateMultipleRecordingRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mpXML, err := xml.Marshal(body.Template)
+	if err != nil {
+		return nil, err
+	}
+	tz := body.TimeZone
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("rrule", body.RRule))
+	mp.AddPart(multipart.FormFieldString("start", millis(body.Start)))
+	mp.AddPart(multipart.FormFieldString("end", millis(body.End)))
+	mp.AddPart(multipart.FormFieldString("duration", strconv.FormatInt(body.Duration.Milliseconds(), 10)))
+	mp.AddPart(multipart.FormFieldString("tz", tz.String()))
+	mp.AddPart(multipart.FormFieldString("agent", body.Agent))
+	mp.AddPart(multipart.FormField("templateMp", mpXML))
+	addSchedulingFields(mp, body.Users, body.WorkflowProperties, body.AgentParameters, body.Source)
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		scheduler.ServiceType,
+		"/recordings/multiple",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func addSchedulingFields(mp *multipart.Multipart, users []string, wfProps, agentParams base.Properties, source string) {
+	if len(users) > 0 {
+		mp.AddPart(multipart.FormFieldString("users", strings.Join(users, ",")))
+	}
+	if len(wfProps) > 0 {
+		mp.AddPart(multipart.FormFieldString("wfproperties", wfProps.Format()))
+	}
+	if len(agentParams) > 0 {
+		mp.AddPart(multipart.FormFieldString("agentparameters", agentParams.Format()))
+	}
+	if source != "" {
+		mp.AddPart(multipart.FormFieldString("source", source))
+	}
+}
+
+func (c *client) DeleteRecording(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.DeleteRecordingRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) DeleteRecordingRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodDelete,
+		scheduler.ServiceType,
+		"/recordings/"+url.PathEscape(id),
+		oc.NoBody,
+		opts...,
+	)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+// WithAgent limits recordings to those of the capture agent.
+func WithAgent(agentID string) oc.RequestOpts {
+	return oc.WithQuery("agent", agentID)
+}
+
+// WithStartsBetween limits recordings to those starting in the given range.
+func WithStartsBetween(from, to time.Time) oc.RequestOpts {
+	return oc.RequestOptsFunc(func(r *oc.Request) error {
+		return r.ApplyOptions(
+			oc.WithQuery("startsfrom", millis(from)),
+			oc.WithQuery("startsto", millis(to)),
+		)
+	})
+}
+
+// WithEndsBetween limits recordings to those ending in the given range.
+func WithEndsBetween(from, to time.Time) oc.RequestOpts {
+	return oc.RequestOptsFunc(func(r *oc.Request) error {
+		return r.ApplyOptions(
+			oc.WithQuery("endsfrom", millis(from)),
+			oc.WithQuery("endsto", millis(to)),
+		)
+	})
+}
+
+// WithCalendarAgent limits the calendar to the recordings of the capture
+// agent.
+func WithCalendarAgent(agentID string) oc.RequestOpts {
+	return oc.WithQuery("agentid", agentID)
+}
+
+// WithCalendarSeries limits the calendar to the recordings of the series.
+func WithCalendarSeries(seriesID string) oc.RequestOpts {
+	return oc.WithQuery("seriesid", seriesID)
+}
+
+// WithCalendarCutoff excludes recordings starting after cutoff from the
+// calendar.
+func WithCalendarCutoff(cutoff time.Time) oc.RequestOpts {
+	return oc.WithQuery("cutoff", millis(cutoff))
+}
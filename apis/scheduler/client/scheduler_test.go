@@ -0,0 +1,236 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	schedulerclient "shio.solutions/tales.media/opencast-client-go/apis/scheduler/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+const testAgent = "room-1"
+
+var testStart = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+func newClient(t *testing.T) schedulerclient.Client {
+	t.Helper()
+	srv := opencasttest.NewServer()
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedulerclient.New(occ)
+}
+
+func schedule(t *testing.T, c schedulerclient.Client, id string, start time.Time, d time.Duration) error {
+	t.Helper()
+	_, err := c.CreateRecording(context.Background(), &schedulerclient.CreateRecordingRequestBody{
+		Start: start,
+		End:   start.Add(d),
+		Agent: testAgent,
+		MediaPackage: &mediapackage.MediaPackage{
+			ID:       id,
+			Title:    "Lecture " + id,
+			Series:   "s1",
+			Creators: &mediapackage.Creators{Creator: []string{"Jane"}},
+		},
+	})
+	return err
+}
+
+func TestCreateAndGetRecording(t *testing.T) {
+	c := newClient(t)
+	if err := schedule(t, c, "r1", testStart, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	mp, _, err := c.GetRecording(context.Background(), "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mp.ID != "r1" || mp.Title != "Lecture r1" || !mp.Start.Time.Equal(testStart) {
+		t.Fatalf("got media package %+v", mp)
+	}
+	if c := mp.CreatorList(); len(c) != 1 || c[0] != "Jane" {
+		t.Fatalf("got creators %v, want [Jane]", c)
+	}
+
+	list, _, err := c.ListRecording(context.Background(), schedulerclient.WithAgent(testAgent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != "r1" {
+		t.Fatalf("got recordings %+v, want r1", list)
+	}
+	list, _, err = c.ListRecording(context.Background(), schedulerclient.WithAgent("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Fatalf("got recordings %+v of another agent", list)
+	}
+}
+
+func TestCreateRecordingConflict(t *testing.T) {
+	c := newClient(t)
+	if err := schedule(t, c, "r1", testStart, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	err := schedule(t, c, "r2", testStart.Add(30*time.Minute), time.Hour)
+	var conflictErr *schedulerclient.ConflictError
+	if !errors.As(err, &conflictErr) || !errors.Is(err, schedulerclient.ConflictErr) {
+		t.Fatalf("got error %v, want %v", err, schedulerclient.ConflictErr)
+	}
+	if len(conflictErr.Conflicts) != 1 {
+		t.Fatalf("got conflicts %+v, want 1", conflictErr.Conflicts)
+	}
+	conflict := conflictErr.Conflicts[0]
+	if conflict.EventID != "r1" || conflict.SeriesID != "s1" || !conflict.End.Equal(testStart.Add(time.Hour)) {
+		t.Fatalf("got conflict %+v, want r1", conflict)
+	}
+
+	if err := schedule(t, c, "r3", testStart.Add(time.Hour), time.Hour); err != nil {
+		t.Fatalf("adjacent recording: %v", err)
+	}
+}
+
+func TestListConflictRecurring(t *testing.T) {
+	c := newClient(t)
+	// Wednesday of the following week
+	if err := schedule(t, c, "r1", testStart.AddDate(0, 0, 9), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(rule string) []string {
+		t.Helper()
+		conflicts, _, err := c.ListConflict(context.Background(), &schedulerclient.ConflictCheckRequestBody{
+			Agent:    testAgent,
+			Start:    testStart,
+			End:      testStart.AddDate(0, 0, 28),
+			RRule:    rule,
+			Duration: 90 * time.Minute,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, c := range conflicts {
+			ids = append(ids, c.EventID)
+		}
+		return ids
+	}
+
+	if ids := check("FREQ=WEEKLY;BYDAY=WE;BYHOUR=7;BYMINUTE=30"); len(ids) != 1 || ids[0] != "r1" {
+		t.Fatalf("got conflicts %v, want [r1]", ids)
+	}
+	if ids := check("FREQ=WEEKLY;BYDAY=MO;BYHOUR=8;BYMINUTE=0"); len(ids) != 0 {
+		t.Fatalf("got conflicts %v, want none", ids)
+	}
+}
+
+func TestListCalendarEventReportsSkippedEvents(t *testing.T) {
+	srv := opencasttest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.InjectFault(opencasttest.Fault{
+		Method:     http.MethodGet,
+		Path:       "/recordings/calendar",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/calendar"}},
+		Body: "BEGIN:VCALENDAR\r\n" +
+			"BEGIN:VEVENT\r\nUID:broken\r\nEND:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\nUID:ok\r\nDTSTART:20260302T080000Z\r\nDTEND:20260302T090000Z\r\nEND:VEVENT\r\n" +
+			"END:VCALENDAR\r\n",
+	})
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := schedulerclient.New(occ)
+
+	events, resp, err := c.ListCalendarEvent(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].EventID != "ok" {
+		t.Fatalf("got events %+v, want ok", events)
+	}
+	if len(resp.Meta.Diagnostics) != 1 || resp.Meta.Diagnostics[0].Path != "VEVENT[0]" {
+		t.Fatalf("got diagnostics %v, want the broken event", resp.Meta.Diagnostics)
+	}
+}
+
+func TestListCalendarEvent(t *testing.T) {
+	c := newClient(t)
+	start := testStart.AddDate(1, 0, 0)
+	if err := schedule(t, c, "r1", start, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := c.ListCalendarEventRequest(context.Background(), schedulerclient.WithCalendarAgent(testAgent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Decoders[schedulerclient.CalendarMediaType] == nil {
+		t.Fatal("request without iCalendar decoder")
+	}
+
+	events, _, err := c.ListCalendarEvent(context.Background(), schedulerclient.WithCalendarAgent(testAgent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	e := events[0]
+	if e.EventID != "r1" || e.SeriesID != "s1" || !e.Start.Equal(start) || !e.End.Equal(start.Add(time.Hour)) {
+		t.Fatalf("got event %+v", e)
+	}
+	if _, ok := e.Attachment(scheduler.EpisodeAttachment); !ok {
+		t.Fatal("episode catalog missing")
+	}
+	if wf := e.AgentProperties()[scheduler.WorkflowDefinitionAgentProperty]; wf != opencasttest.DefaultScheduleWorkflow {
+		t.Fatalf("got workflow %q, want %q", wf, opencasttest.DefaultScheduleWorkflow)
+	}
+}
+
+func TestICalDecoderIntoComponent(t *testing.T) {
+	c := newClient(t)
+	if err := schedule(t, c, "r1", testStart.AddDate(1, 0, 0), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	cal, _, err := oc.GenericAutoDecodedDo[*ical.Component](c, func() (*oc.Request, error) {
+		return c.ListCalendarEventRequest(context.Background())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cal.Name != ical.CalendarComponent || len(cal.ComponentList(ical.EventComponent)) != 1 {
+		t.Fatalf("got calendar %+v", cal)
+	}
+}
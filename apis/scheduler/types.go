@@ -0,0 +1,57 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+)
+
+const ServiceType = "org.opencastproject.scheduler"
+
+// Conflict is a scheduled event clashing with a proposed recording.
+type Conflict struct {
+	EventID  string
+	Title    string
+	SeriesID string
+	Start    time.Time
+	End      time.Time
+
+	MediaPackage mediapackage.MediaPackage
+}
+
+func ConflictFromMediaPackage(mp mediapackage.MediaPackage) Conflict {
+	c := Conflict{
+		EventID:      mp.ID,
+		Title:        mp.Title,
+		SeriesID:     mp.Series,
+		Start:        mp.Start.Time,
+		End:          mp.Start.Time,
+		MediaPackage: mp,
+	}
+	if mp.Duration != nil {
+		c.End = c.Start.Add(time.Duration(*mp.Duration) * time.Millisecond)
+	}
+	return c
+}
+
+// Overlaps reports whether the conflicting event overlaps the range from
+// start to end.
+func (c Conflict) Overlaps(start, end time.Time) bool {
+	return c.Start.Before(end) && start.Before(c.End)
+}
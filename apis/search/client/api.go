@@ -0,0 +1,71 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"iter"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/search"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+// DefaultPageLimit is the page size used to walk all results if no limit is
+// given.
+const DefaultPageLimit = 100
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Episodes
+
+	ListEpisode(ctx context.Context, opts ...oc.RequestOpts) (*search.Results, *oc.Response, error)
+	ListEpisodeRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	WalkEpisode(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[search.Result, error]
+
+	// Series
+
+	ListSeries(ctx context.Context, opts ...oc.RequestOpts) (*search.Results, *oc.Response, error)
+	ListSeriesRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+	WalkSeries(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[search.Result, error]
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	if err := req.ApplyOptions(
+		oc.WithHeader("Accept", "application/json"),
+	); err != nil {
+		return nil, err
+	}
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
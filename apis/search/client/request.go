@@ -0,0 +1,82 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+// The pagination is shared with the other services, see package listopts.
+// Sorting differs from listopts in syntax.
+type WithPagination = listopts.WithPagination
+
+// WithText searches the full text of episodes or series.
+func WithText(q string) oc.RequestOpts {
+	return oc.WithQuery("q", q)
+}
+
+func WithID(id string) oc.RequestOpts {
+	return oc.WithQuery("id", id)
+}
+
+// WithSeriesID limits episodes to those of the series.
+func WithSeriesID(id string) oc.RequestOpts {
+	return oc.WithQuery("sid", id)
+}
+
+// WithSeriesName limits episodes to those of series with the given title.
+func WithSeriesName(name string) oc.RequestOpts {
+	return oc.WithQuery("sname", name)
+}
+
+// WithSignedURLs signs the URLs of published elements.
+func WithSignedURLs() oc.RequestOpts {
+	return oc.WithQuery("sign", "true")
+}
+
+type WithSort struct {
+	By        SortKey
+	Direction SortDirection
+}
+
+var _ oc.RequestOpts = WithSort{}
+
+func (opt WithSort) Apply(r *oc.Request) error {
+	dir := opt.Direction
+	if dir == "" {
+		dir = Ascending
+	}
+	return r.ApplyOptions(oc.WithQuery("sort", string(opt.By)+" "+string(dir)))
+}
+
+type SortKey string
+
+const (
+	IdentifierSortKey  = SortKey("identifier")
+	TitleSortKey       = SortKey("title")
+	ContributorSortKey = SortKey("contributor")
+	CreatorSortKey     = SortKey("creator")
+	ModifiedSortKey    = SortKey("modified")
+)
+
+type SortDirection string
+
+const (
+	Ascending  = SortDirection("asc")
+	Descending = SortDirection("desc")
+)
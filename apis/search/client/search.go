@@ -0,0 +1,110 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/search"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+func (c *client) ListEpisode(ctx context.Context, opts ...oc.RequestOpts) (*search.Results, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*search.Results](
+		c,
+		func() (*oc.Request, error) { return c.ListEpisodeRequest(ctx, opts...) },
+	)
+}
+
+func (c *client) ListEpisodeRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		search.ServiceType,
+		"/search/episode.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) WalkEpisode(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[search.Result, error] {
+	return walk(c, func(opts ...oc.RequestOpts) (*oc.Request, error) { return c.ListEpisodeRequest(ctx, opts...) }, opts)
+}
+
+func (c *client) ListSeries(ctx context.Context, opts ...oc.RequestOpts) (*search.Results, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*search.Results](
+		c,
+		func() (*oc.Request, error) { return c.ListSeriesRequest(ctx, opts...) },
+	)
+}
+
+func (c *client) ListSeriesRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		search.ServiceType,
+		"/search/series.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) WalkSeries(ctx context.Context, opts ...oc.RequestOpts) iter.Seq2[search.Result, error] {
+	return walk(c, func(opts ...oc.RequestOpts) (*oc.Request, error) { return c.ListSeriesRequest(ctx, opts...) }, opts)
+}
+
+// walk requests page after page starting at the offset given in opts until
+// all results were yielded. Errors are yielded as the last element.
+func walk(do oc.Doer, reqFunc func(opts ...oc.RequestOpts) (*oc.Request, error), opts []oc.RequestOpts) iter.Seq2[search.Result, error] {
+	return func(yield func(search.Result, error) bool) {
+		first, err := reqFunc(opts...)
+		if err != nil {
+			yield(search.Result{}, err)
+			return
+		}
+		limit, _ := strconv.Atoi(first.Query.Get("limit"))
+		if limit <= 0 {
+			limit = DefaultPageLimit
+		}
+		offset, _ := strconv.Atoi(first.Query.Get("offset"))
+
+		for {
+			page, _, err := oc.GenericAutoDecodedDo[*search.Results](
+				do,
+				func() (*oc.Request, error) {
+					return reqFunc(append(opts, WithPagination{Limit: limit, Offset: offset})...)
+				},
+			)
+			if err != nil {
+				yield(search.Result{}, err)
+				return
+			}
+			for _, r := range page.Result {
+				if !yield(r, nil) {
+					return
+				}
+			}
+			offset += len(page.Result)
+			if len(page.Result) == 0 || offset >= int(page.Total) {
+				return
+			}
+		}
+	}
+}
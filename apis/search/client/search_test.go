@@ -0,0 +1,118 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/search"
+	searchclient "shio.solutions/tales.media/opencast-client-go/apis/search/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func publishedEvent(id, title, series string) opencasttest.EventFixture {
+	return opencasttest.EventFixture{Event: extapiv1.Event{
+		Identifier: id,
+		Title:      title,
+		IsPartOf:   series,
+		Publications: []extapiv1.Publication{{
+			ID:      id + "-engage",
+			Channel: extapiv1.EngagePlayerChannel,
+			Media: []extapiv1.TrackElement{{
+				ID:        id + "-track",
+				MediaType: "video/mp4",
+				URL:       "https://example.org/" + id + ".mp4",
+				Tags:      []string{"engage-download"},
+				HasVideo:  true,
+			}},
+		}},
+	}}
+}
+
+func newSearchClient(t *testing.T) searchclient.Client {
+	t.Helper()
+	unpublished := opencasttest.EventFixture{Event: extapiv1.Event{Identifier: "e0", Title: "Draft"}}
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(&opencasttest.Fixtures{
+		Events: []opencasttest.EventFixture{
+			unpublished,
+			publishedEvent("e1", "Charlie", "s1"),
+			publishedEvent("e2", "Alpha", "s1"),
+			publishedEvent("e3", "Bravo", "s2"),
+		},
+		Series: []opencasttest.SeriesFixture{
+			{Series: extapiv1.Series{Identifier: "s1", Title: "Lectures"}},
+		},
+	}))
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return searchclient.New(occ)
+}
+
+func TestWalkEpisodeVisitsAllPages(t *testing.T) {
+	c := newSearchClient(t)
+
+	var titles []string
+	for r, err := range c.WalkEpisode(context.Background(),
+		searchclient.WithPagination{Limit: 1},
+		searchclient.WithSort{By: searchclient.TitleSortKey},
+	) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		titles = append(titles, r.DublinCore.Get(search.TitleDublinCoreTerm))
+	}
+	want := []string{"Alpha", "Bravo", "Charlie"}
+	if len(titles) != len(want) {
+		t.Fatalf("got %v, want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("got %v, want %v", titles, want)
+		}
+	}
+}
+
+func TestListEpisodeBySeries(t *testing.T) {
+	c := newSearchClient(t)
+
+	results, _, err := c.ListEpisode(context.Background(), searchclient.WithSeriesID("s1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 2 || len(results.Result) != 2 {
+		t.Fatalf("got %d results, want 2", len(results.Result))
+	}
+	for _, r := range results.Result {
+		mp := r.MediaPackage
+		if mp == nil || mp.Series != "s1" || mp.SeriesTitle != "Lectures" {
+			t.Fatalf("got media package %+v, want one of series s1", mp)
+		}
+		pub, ok := mp.Publication(extapiv1.EngagePlayerChannel)
+		if !ok {
+			t.Fatal("engage publication missing")
+		}
+		tracks := pub.Tracks()
+		if len(tracks) != 1 || !tracks[0].HasTag("engage-download") || tracks[0].Video == nil {
+			t.Fatalf("got tracks %+v, want the published video track", tracks)
+		}
+	}
+}
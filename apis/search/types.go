@@ -0,0 +1,83 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+const ServiceType = "org.opencastproject.search"
+
+// Results is a page of search results as returned by Opencast 14 and later.
+type Results struct {
+	Total  base.Int `json:"total"`
+	Offset base.Int `json:"offset"`
+	Limit  base.Int `json:"limit"`
+	Result []Result `json:"result"`
+}
+
+type Result struct {
+	Type         ResultType                 `json:"type,omitempty"`
+	Org          string                     `json:"org,omitempty"`
+	Modified     base.DateTime              `json:"modified,omitzero"`
+	Deleted      base.DateTime              `json:"deleted,omitzero"`
+	MediaPackage *mediapackage.MediaPackage `json:"mediapackage,omitempty"` // episodes only
+	DublinCore   DublinCore                 `json:"dc,omitempty"`
+}
+
+type ResultType string
+
+const (
+	EpisodeResultType = ResultType("Episode")
+	SeriesResultType  = ResultType("Series")
+)
+
+// DublinCore holds the values of the Dublin Core catalog of an episode or
+// series by term.
+type DublinCore map[DublinCoreTerm][]string
+
+type DublinCoreTerm string
+
+const (
+	IdentifierDublinCoreTerm   = DublinCoreTerm("identifier")
+	TitleDublinCoreTerm        = DublinCoreTerm("title")
+	DescriptionDublinCoreTerm  = DublinCoreTerm("description")
+	CreatorDublinCoreTerm      = DublinCoreTerm("creator")
+	ContributorDublinCoreTerm  = DublinCoreTerm("contributor")
+	PublisherDublinCoreTerm    = DublinCoreTerm("publisher")
+	SubjectDublinCoreTerm      = DublinCoreTerm("subject")
+	LanguageDublinCoreTerm     = DublinCoreTerm("language")
+	LicenseDublinCoreTerm      = DublinCoreTerm("license")
+	RightsHolderDublinCoreTerm = DublinCoreTerm("rightsHolder")
+	CreatedDublinCoreTerm      = DublinCoreTerm("created")
+	ModifiedDublinCoreTerm     = DublinCoreTerm("modified")
+	TemporalDublinCoreTerm     = DublinCoreTerm("temporal")
+	ExtentDublinCoreTerm       = DublinCoreTerm("extent")
+	IsPartOfDublinCoreTerm     = DublinCoreTerm("isPartOf")
+	SpatialDublinCoreTerm      = DublinCoreTerm("spatial")
+	SourceDublinCoreTerm       = DublinCoreTerm("source")
+	TypeDublinCoreTerm         = DublinCoreTerm("type")
+)
+
+// Get returns the first value of term.
+func (dc DublinCore) Get(term DublinCoreTerm) string {
+	if v := dc[term]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
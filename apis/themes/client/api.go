@@ -0,0 +1,83 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Themes
+
+	ListTheme(ctx context.Context, opts ...oc.RequestOpts) ([]themes.Theme, *oc.Response, error)
+	ListThemeRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetTheme(ctx context.Context, id int64, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error)
+	GetThemeRequest(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	FindTheme(ctx context.Context, name string, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error)
+
+	CreateTheme(ctx context.Context, body *CreateThemeRequestBody, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error)
+	CreateThemeRequest(ctx context.Context, body *CreateThemeRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	UpdateTheme(ctx context.Context, id int64, body *UpdateThemeRequestBody, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error)
+	UpdateThemeRequest(ctx context.Context, id int64, body *UpdateThemeRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	DeleteTheme(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Response, error)
+	DeleteThemeRequest(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetThemeUsage(ctx context.Context, id int64, opts ...oc.RequestOpts) (*themes.Usage, *oc.Response, error)
+	GetThemeUsageRequest(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Series
+
+	AssignSeriesTheme(ctx context.Context, seriesID, themeName string, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error)
+
+	UpdateSeriesTheme(ctx context.Context, seriesID string, themeID int64, opts ...oc.RequestOpts) (*oc.Response, error)
+	UpdateSeriesThemeRequest(ctx context.Context, seriesID string, themeID int64, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Static files
+
+	CreateStaticFile(ctx context.Context, file string, opts ...oc.RequestOpts) (string, *oc.Response, error)
+	CreateStaticFileRequest(ctx context.Context, file string, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
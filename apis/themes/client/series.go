@@ -0,0 +1,70 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+// AssignSeriesTheme sets the theme with the given name on the series. If
+// there is no such theme, ThemeNotFoundErr is returned and the series is not
+// changed. The options apply to the update of the series, the theme is looked
+// up as the same user only, see [oc.WithIdentityOf]. New series get a theme by
+// setting CreateSeriesRequestBody.Theme of the External API to
+// [themes.Theme.SeriesTheme] of the theme found by FindTheme.
+func (c *client) AssignSeriesTheme(ctx context.Context, seriesID, themeName string, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error) {
+	update, err := c.UpdateSeriesThemeRequest(ctx, seriesID, 0, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	theme, resp, err := c.FindTheme(ctx, themeName, oc.WithIdentityOf(update))
+	if err != nil {
+		return nil, resp, err
+	}
+	resp, err = c.UpdateSeriesTheme(ctx, seriesID, int64(theme.ID), opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	return theme, resp, nil
+}
+
+func (c *client) UpdateSeriesTheme(ctx context.Context, seriesID string, themeID int64, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.UpdateSeriesThemeRequest(ctx, seriesID, themeID, opts...) },
+	)
+}
+
+func (c *client) UpdateSeriesThemeRequest(ctx context.Context, seriesID string, themeID int64, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("themeId", strconv.FormatInt(themeID, 10)))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPut,
+		themes.SeriesServiceType,
+		"/admin-ng/series/"+url.PathEscape(seriesID)+"/theme",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
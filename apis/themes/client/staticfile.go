@@ -0,0 +1,56 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+// CreateStaticFile uploads the file and returns its static file ID. Files not
+// referenced by a theme are removed by Opencast after a while.
+func (c *client) CreateStaticFile(ctx context.Context, file string, opts ...oc.RequestOpts) (string, *oc.Response, error) {
+	resp, err := oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.CreateStaticFileRequest(ctx, file, opts...) },
+	)
+	if err != nil {
+		return "", resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	return strings.TrimSpace(string(b)), resp, err
+}
+
+func (c *client) CreateStaticFileRequest(ctx context.Context, file string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.File("BODY", file))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		themes.StaticFilesServiceType,
+		"/staticfiles",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
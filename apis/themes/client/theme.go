@@ -0,0 +1,304 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+var (
+	ThemeNotFoundErr = errors.New("theme not found")
+	// FileNotUploadedErr is returned by the theme requests if a file has a
+	// path but no static file ID.
+	FileNotUploadedErr = errors.New("theme file not uploaded")
+)
+
+// File is a bumper, trailer, watermark or slide background of a theme. Path
+// is uploaded as static file by CreateTheme and UpdateTheme. Otherwise ID
+// refers to a static file uploaded before, e.g. the file of an existing theme.
+type File struct {
+	Path string
+	ID   string
+}
+
+// CreateThemeRequestBody activates the bumper, trailer, watermark and slides
+// that are set.
+type CreateThemeRequestBody struct {
+	Name        string
+	Description string
+	Default     bool
+
+	Bumper  *File
+	Trailer *File
+
+	// TitleSlideMetadata is the JSON configuration of the title slide.
+	TitleSlideMetadata   string
+	TitleSlideBackground *File
+
+	LicenseSlideDescription string
+	LicenseSlideBackground  *File
+
+	Watermark         *File
+	WatermarkPosition themes.WatermarkPosition
+}
+
+// UpdateThemeRequestBody replaces all settings of the theme.
+type UpdateThemeRequestBody CreateThemeRequestBody
+
+// findThemePageLimit is the number of themes FindTheme requests at once.
+const findThemePageLimit = 100
+
+// The theme listing takes the pagination, filter and sort options of package
+// listopts.
+const (
+	// ThemeTextFilterKey searches the name, description and creator.
+	ThemeTextFilterKey    = listopts.FilterKey("textFilter")
+	ThemeCreatorFilterKey = listopts.FilterKey("creator")
+)
+
+const (
+	ThemeNameSortKey         = listopts.SortKey("name")
+	ThemeDescriptionSortKey  = listopts.SortKey("description")
+	ThemeCreatorSortKey      = listopts.SortKey("creator")
+	ThemeDefaultSortKey      = listopts.SortKey("default")
+	ThemeCreationDateSortKey = listopts.SortKey("creationDate")
+)
+
+func (c *client) ListTheme(ctx context.Context, opts ...oc.RequestOpts) ([]themes.Theme, *oc.Response, error) {
+	list, resp, err := oc.GenericAutoDecodedDo[*themes.ThemesResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListThemeRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list.Results, resp, nil
+}
+
+func (c *client) ListThemeRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		themes.ServiceType,
+		"/admin-ng/themes/themes.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetTheme(ctx context.Context, id int64, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*themes.Theme](
+		c,
+		func() (*oc.Request, error) { return c.GetThemeRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) GetThemeRequest(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		themes.ServiceType,
+		"/admin-ng/themes/"+strconv.FormatInt(id, 10)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// FindTheme returns the theme with the given name, searching all pages of the
+// theme listing. If there is none, ThemeNotFoundErr is returned.
+func (c *client) FindTheme(ctx context.Context, name string, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error) {
+	var resp *oc.Response
+	for offset := 0; ; offset += findThemePageLimit {
+		var list []themes.Theme
+		var err error
+		list, resp, err = c.ListTheme(ctx, slices.Concat(opts, []oc.RequestOpts{
+			listopts.WithFilter{ThemeTextFilterKey: name},
+			listopts.WithPagination{Limit: findThemePageLimit, Offset: offset},
+		})...)
+		if err != nil {
+			return nil, resp, err
+		}
+		if i := slices.IndexFunc(list, func(t themes.Theme) bool { return t.Name == name }); i >= 0 {
+			return &list[i], resp, nil
+		}
+		if len(list) < findThemePageLimit {
+			return nil, resp, fmt.Errorf("%w: %q", ThemeNotFoundErr, name)
+		}
+	}
+}
+
+// CreateTheme uploads the files of the theme and creates it.
+func (c *client) CreateTheme(ctx context.Context, body *CreateThemeRequestBody, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error) {
+	uploaded, resp, err := c.uploadFiles(ctx, body)
+	if err != nil {
+		return nil, resp, err
+	}
+	return oc.GenericAutoDecodedDo[*themes.Theme](
+		c,
+		func() (*oc.Request, error) { return c.CreateThemeRequest(ctx, uploaded, opts...) },
+	)
+}
+
+// CreateThemeRequest expects the files of the theme to be uploaded.
+func (c *client) CreateThemeRequest(ctx context.Context, body *CreateThemeRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp, err := themeForm(body)
+	if err != nil {
+		return nil, err
+	}
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		themes.ServiceType,
+		"/admin-ng/themes",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+// UpdateTheme uploads the files of the theme and updates it.
+func (c *client) UpdateTheme(ctx context.Context, id int64, body *UpdateThemeRequestBody, opts ...oc.RequestOpts) (*themes.Theme, *oc.Response, error) {
+	uploaded, resp, err := c.uploadFiles(ctx, (*CreateThemeRequestBody)(body))
+	if err != nil {
+		return nil, resp, err
+	}
+	return oc.GenericAutoDecodedDo[*themes.Theme](
+		c,
+		func() (*oc.Request, error) {
+			return c.UpdateThemeRequest(ctx, id, (*UpdateThemeRequestBody)(uploaded), opts...)
+		},
+	)
+}
+
+// UpdateThemeRequest expects the files of the theme to be uploaded.
+func (c *client) UpdateThemeRequest(ctx context.Context, id int64, body *UpdateThemeRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp, err := themeForm((*CreateThemeRequestBody)(body))
+	if err != nil {
+		return nil, err
+	}
+	return oc.NewRequest(
+		ctx,
+		http.MethodPut,
+		themes.ServiceType,
+		"/admin-ng/themes/"+strconv.FormatInt(id, 10),
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+// uploadFiles returns a copy of the body with all files uploaded.
+func (c *client) uploadFiles(ctx context.Context, body *CreateThemeRequestBody) (*CreateThemeRequestBody, *oc.Response, error) {
+	uploaded := *body
+	for _, f := range []**File{
+		&uploaded.Bumper,
+		&uploaded.Trailer,
+		&uploaded.TitleSlideBackground,
+		&uploaded.LicenseSlideBackground,
+		&uploaded.Watermark,
+	} {
+		if *f == nil || (*f).ID != "" {
+			continue
+		}
+		id, resp, err := c.CreateStaticFile(ctx, (*f).Path)
+		if err != nil {
+			return nil, resp, err
+		}
+		*f = &File{Path: (*f).Path, ID: id}
+	}
+	return &uploaded, nil, nil
+}
+
+func themeForm(body *CreateThemeRequestBody) (*multipart.Multipart, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("name", body.Name))
+	mp.AddPart(multipart.FormFieldString("description", body.Description))
+	mp.AddPart(multipart.FormFieldString("default", strconv.FormatBool(body.Default)))
+	mp.AddPart(multipart.FormFieldString("titleSlideActive", strconv.FormatBool(body.TitleSlideMetadata != "")))
+	mp.AddPart(multipart.FormFieldString("titleSlideMetadata", body.TitleSlideMetadata))
+	mp.AddPart(multipart.FormFieldString("licenseSlideActive", strconv.FormatBool(body.LicenseSlideDescription != "")))
+	mp.AddPart(multipart.FormFieldString("licenseSlideDescription", body.LicenseSlideDescription))
+	mp.AddPart(multipart.FormFieldString("watermarkPosition", string(body.WatermarkPosition)))
+	for _, f := range []struct {
+		active string
+		field  string
+		file   *File
+	}{
+		{"bumperActive", "bumperFile", body.Bumper},
+		{"trailerActive", "trailerFile", body.Trailer},
+		{"", "titleSlideBackground", body.TitleSlideBackground},
+		{"", "licenseSlideBackground", body.LicenseSlideBackground},
+		{"watermarkActive", "watermarkFile", body.Watermark},
+	} {
+		if f.active != "" {
+			mp.AddPart(multipart.FormFieldString(f.active, strconv.FormatBool(f.file != nil)))
+		}
+		if f.file == nil {
+			continue
+		}
+		if f.file.ID == "" {
+			return nil, fmt.Errorf("%w: %s", FileNotUploadedErr, f.field)
+		}
+		mp.AddPart(multipart.FormFieldString(f.field, f.file.ID))
+	}
+	return mp, nil
+}
+
+// DeleteTheme deletes the theme and removes it from all series.
+func (c *client) DeleteTheme(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.DeleteThemeRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) DeleteThemeRequest(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodDelete,
+		themes.ServiceType,
+		"/admin-ng/themes/"+strconv.FormatInt(id, 10),
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetThemeUsage(ctx context.Context, id int64, opts ...oc.RequestOpts) (*themes.Usage, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*themes.Usage](
+		c,
+		func() (*oc.Request, error) { return c.GetThemeUsageRequest(ctx, id, opts...) },
+	)
+}
+
+func (c *client) GetThemeUsageRequest(ctx context.Context, id int64, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		themes.ServiceType,
+		"/admin-ng/themes/"+strconv.FormatInt(id, 10)+"/usage.json",
+		oc.NoBody,
+		opts...,
+	)
+}
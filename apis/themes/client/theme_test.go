@@ -0,0 +1,210 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+	themesclient "shio.solutions/tales.media/opencast-client-go/apis/themes/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+// newClient returns a client of a server with the series s1 and themes named
+// "Lecture 1" to "Lecture 150" followed by "Lecture".
+func newClient(t *testing.T) (themesclient.Client, *opencasttest.Server) {
+	t.Helper()
+	f := &opencasttest.Fixtures{
+		Series: []opencasttest.SeriesFixture{{Series: extapiv1.Series{Identifier: "s1", Title: "Physics"}}},
+	}
+	for i := 1; i <= 150; i++ {
+		f.Themes = append(f.Themes, themes.Theme{ID: base.Int(i), Name: fmt.Sprintf("Lecture %d", i)})
+	}
+	f.Themes = append(f.Themes, themes.Theme{ID: 151, Name: "Lecture"})
+
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(f))
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return themesclient.New(occ), srv
+}
+
+func TestCreateTheme(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, name := range []string{"bumper.mp4", "logo.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	theme, _, err := c.CreateTheme(ctx, &themesclient.CreateThemeRequestBody{
+		Name:              "University",
+		Bumper:            &themesclient.File{Path: filepath.Join(dir, "bumper.mp4")},
+		Watermark:         &themesclient.File{Path: filepath.Join(dir, "logo.png")},
+		WatermarkPosition: themes.TopRightWatermarkPosition,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !theme.BumperActive || theme.BumperFile == "" || !theme.WatermarkActive || theme.WatermarkFile == "" || theme.TrailerActive {
+		t.Fatalf("got theme %+v", theme)
+	}
+
+	theme, _, err = c.UpdateTheme(ctx, int64(theme.ID), &themesclient.UpdateThemeRequestBody{
+		Name:   "University",
+		Bumper: &themesclient.File{ID: theme.BumperFile},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !theme.BumperActive || theme.WatermarkActive {
+		t.Fatalf("got theme %+v after update", theme)
+	}
+
+	_, err = c.CreateThemeRequest(ctx, &themesclient.CreateThemeRequestBody{
+		Name:    "University",
+		Trailer: &themesclient.File{Path: filepath.Join(dir, "bumper.mp4")},
+	})
+	if !errors.Is(err, themesclient.FileNotUploadedErr) {
+		t.Errorf("CreateThemeRequest() = %v, want %v", err, themesclient.FileNotUploadedErr)
+	}
+}
+
+func TestFindThemeSearchesAllPages(t *testing.T) {
+	c, srv := newClient(t)
+	var mtx sync.Mutex
+	var lists int
+	srv.OnRequest(func(r *http.Request) {
+		if r.URL.Path == "/admin-ng/themes/themes.json" {
+			mtx.Lock()
+			lists++
+			mtx.Unlock()
+		}
+	})
+
+	theme, _, err := c.FindTheme(context.Background(), "Lecture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.ID != 151 {
+		t.Errorf("got theme %+v, want ID 151", theme)
+	}
+	mtx.Lock()
+	if lists != 2 {
+		t.Errorf("got %d list requests, want 2", lists)
+	}
+	mtx.Unlock()
+
+	if _, _, err := c.FindTheme(context.Background(), "Seminar"); !errors.Is(err, themesclient.ThemeNotFoundErr) {
+		t.Errorf("FindTheme() = %v, want %v", err, themesclient.ThemeNotFoundErr)
+	}
+}
+
+func TestFindThemeKeepsCallerOptions(t *testing.T) {
+	c, _ := newClient(t)
+	opts := make([]oc.RequestOpts, 1, 4)
+	opts[0] = listopts.WithSort{{By: themesclient.ThemeNameSortKey}}
+
+	if _, _, err := c.FindTheme(context.Background(), "Lecture 7", opts...); err != nil {
+		t.Fatal(err)
+	}
+	if opts[:2][1] != nil {
+		t.Error("FindTheme wrote into the options of the caller")
+	}
+}
+
+func TestAssignSeriesTheme(t *testing.T) {
+	c, srv := newClient(t)
+	ctx := context.Background()
+	var mtx sync.Mutex
+	queries := map[string]string{}
+	runAs := map[string]string{}
+	srv.OnRequest(func(r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		queries[r.Method+" "+r.URL.Path] = r.URL.Query().Get("marker")
+		runAs[r.Method+" "+r.URL.Path] = r.Header.Get(oc.RunAsUserHeader)
+	})
+
+	theme, _, err := c.AssignSeriesTheme(ctx, "s1", "Lecture", oc.WithQuery("marker", "update"), oc.WithRunAsUser(opencasttest.DefaultUsername))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.ID != 151 {
+		t.Fatalf("got theme %+v, want ID 151", theme)
+	}
+	mtx.Lock()
+	if q := queries["GET /admin-ng/themes/themes.json"]; q != "" {
+		t.Errorf("options of the update were passed to the theme listing")
+	}
+	if q := queries["PUT /admin-ng/series/s1/theme"]; q != "update" {
+		t.Errorf("options were not passed to the update")
+	}
+	if len(runAs) != 2 {
+		t.Errorf("got requests %v, want the listing and the update", runAs)
+	}
+	for k, u := range runAs {
+		if u != opencasttest.DefaultUsername {
+			t.Errorf("%s was not run as %s", k, opencasttest.DefaultUsername)
+		}
+	}
+	mtx.Unlock()
+
+	usage, _, err := c.GetThemeUsage(ctx, 151)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage.Series) != 1 || usage.Series[0].ID != "s1" {
+		t.Errorf("got usage %+v", usage)
+	}
+
+	if _, _, err := c.AssignSeriesTheme(ctx, "s1", "Seminar"); !errors.Is(err, themesclient.ThemeNotFoundErr) {
+		t.Errorf("AssignSeriesTheme() = %v, want %v", err, themesclient.ThemeNotFoundErr)
+	}
+}
+
+func TestDeleteThemeRemovesSeriesTheme(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+	if _, err := c.UpdateSeriesTheme(ctx, "s1", 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteTheme(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.GetTheme(ctx, 3); err == nil {
+		t.Error("GetTheme after delete succeeded")
+	}
+	if _, err := c.UpdateSeriesTheme(ctx, "s1", 3); err == nil {
+		t.Error("assigning a deleted theme succeeded")
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package themes contains the types of the Opencast themes, which brand the
+// videos of a series with bumpers, trailers, title slides and watermarks.
+package themes
+
+import (
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+const (
+	ServiceType = "org.opencastproject.adminui.endpoint.ThemesEndpoint"
+	// SeriesServiceType assigns themes to series.
+	SeriesServiceType      = "org.opencastproject.adminui.endpoint.SeriesEndpoint"
+	StaticFilesServiceType = "org.opencastproject.staticfiles"
+)
+
+type ThemesResponse struct {
+	Total   int     `json:"total"`
+	Offset  int     `json:"offset"`
+	Count   int     `json:"count"`
+	Limit   int     `json:"limit"`
+	Results []Theme `json:"results"`
+}
+
+// Theme references its files by static file ID.
+type Theme struct {
+	ID           base.Int      `json:"id"`
+	CreationDate base.DateTime `json:"creationDate,omitzero"`
+	Default      bool          `json:"default"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	Creator      string        `json:"creator,omitempty"`
+
+	BumperActive bool   `json:"bumperActive"`
+	BumperFile   string `json:"bumperFile,omitempty"`
+
+	TrailerActive bool   `json:"trailerActive"`
+	TrailerFile   string `json:"trailerFile,omitempty"`
+
+	TitleSlideActive     bool   `json:"titleSlideActive"`
+	TitleSlideMetadata   string `json:"titleSlideMetadata,omitempty"`
+	TitleSlideBackground string `json:"titleSlideBackground,omitempty"`
+
+	LicenseSlideActive      bool   `json:"licenseSlideActive"`
+	LicenseSlideDescription string `json:"licenseSlideDescription,omitempty"`
+	LicenseSlideBackground  string `json:"licenseSlideBackground,omitempty"`
+
+	WatermarkActive   bool              `json:"watermarkActive"`
+	WatermarkFile     string            `json:"watermarkFile,omitempty"`
+	WatermarkPosition WatermarkPosition `json:"watermarkPosition,omitempty"`
+}
+
+// SeriesTheme returns the theme ID as expected by the theme of a series.
+func (t *Theme) SeriesTheme() string {
+	return strconv.FormatInt(int64(t.ID), 10)
+}
+
+type WatermarkPosition string
+
+const (
+	TopLeftWatermarkPosition     = WatermarkPosition("topLeft")
+	TopRightWatermarkPosition    = WatermarkPosition("topRight")
+	BottomLeftWatermarkPosition  = WatermarkPosition("bottomLeft")
+	BottomRightWatermarkPosition = WatermarkPosition("bottomRight")
+)
+
+// Usage lists the series using a theme.
+type Usage struct {
+	Series []UsageSeries `json:"series"`
+}
+
+type UsageSeries struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
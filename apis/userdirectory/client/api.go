@@ -0,0 +1,74 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/userdirectory"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Users
+
+	ListUser(ctx context.Context, opts ...oc.RequestOpts) ([]userdirectory.User, *oc.Response, error)
+	ListUserRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetUser(ctx context.Context, username string, opts ...oc.RequestOpts) (*userdirectory.User, *oc.Response, error)
+	GetUserRequest(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	CreateUser(ctx context.Context, body *CreateUserRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	CreateUserRequest(ctx context.Context, body *CreateUserRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	UpdateUser(ctx context.Context, username string, body *UpdateUserRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	UpdateUserRequest(ctx context.Context, username string, body *UpdateUserRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	DeleteUser(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Response, error)
+	DeleteUserRequest(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	ListUserRole(ctx context.Context, username string, opts ...oc.RequestOpts) ([]userdirectory.Role, *oc.Response, error)
+	ListUserRoleRequest(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Roles
+
+	ListRole(ctx context.Context, opts ...oc.RequestOpts) ([]userdirectory.Role, *oc.Response, error)
+	ListRoleRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
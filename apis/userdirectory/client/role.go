@@ -0,0 +1,55 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	"shio.solutions/tales.media/opencast-client-go/apis/userdirectory"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+const (
+	// RoleTextFilterKey searches the name and description.
+	RoleTextFilterKey = listopts.FilterKey("textFilter")
+	RoleTypeFilterKey = listopts.FilterKey("type")
+)
+
+const (
+	RoleNameSortKey = listopts.SortKey("name")
+	RoleTypeSortKey = listopts.SortKey("type")
+)
+
+func (c *client) ListRole(ctx context.Context, opts ...oc.RequestOpts) ([]userdirectory.Role, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[[]userdirectory.Role](
+		c,
+		func() (*oc.Request, error) { return c.ListRoleRequest(ctx, opts...) },
+	)
+}
+
+func (c *client) ListRoleRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		userdirectory.RolesServiceType,
+		"/roles/roles.json",
+		oc.NoBody,
+		opts...,
+	)
+}
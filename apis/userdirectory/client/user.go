@@ -0,0 +1,215 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	"shio.solutions/tales.media/opencast-client-go/apis/userdirectory"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+type CreateUserRequestBody struct {
+	Username string
+	Password string
+	Name     string
+	Email    string
+	Roles    []string
+}
+
+// UpdateUserRequestBody replaces the name, email and roles of the user. The
+// password is kept if empty.
+type UpdateUserRequestBody struct {
+	Password string
+	Name     string
+	Email    string
+	Roles    []string
+}
+
+// The user and role listings take the pagination, filter and sort options of
+// package listopts.
+const (
+	// UserTextFilterKey searches the username, name and email.
+	UserTextFilterKey     = listopts.FilterKey("textFilter")
+	UserRoleFilterKey     = listopts.FilterKey("role")
+	UserProviderFilterKey = listopts.FilterKey("provider")
+)
+
+const (
+	UserNameSortKey     = listopts.SortKey("name")
+	UserUsernameSortKey = listopts.SortKey("username")
+	UserEmailSortKey    = listopts.SortKey("email")
+	UserProviderSortKey = listopts.SortKey("provider")
+)
+
+// ListUser lists the users of all providers.
+func (c *client) ListUser(ctx context.Context, opts ...oc.RequestOpts) ([]userdirectory.User, *oc.Response, error) {
+	users, resp, err := oc.GenericAutoDecodedDo[*userdirectory.UsersResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListUserRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return users.Results, resp, nil
+}
+
+func (c *client) ListUserRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		userdirectory.UsersServiceType,
+		"/admin-ng/users/users.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// GetUser returns the user with the roles assigned directly.
+func (c *client) GetUser(ctx context.Context, username string, opts ...oc.RequestOpts) (*userdirectory.User, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[*userdirectory.User](
+		c,
+		func() (*oc.Request, error) { return c.GetUserRequest(ctx, username, opts...) },
+	)
+}
+
+func (c *client) GetUserRequest(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		userdirectory.UsersServiceType,
+		"/admin-ng/users/"+url.PathEscape(username)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// CreateUser creates an internal user.
+func (c *client) CreateUser(ctx context.Context, body *CreateUserRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.CreateUserRequest(ctx, body, opts...) },
+	)
+}
+
+func (c *client) CreateUserRequest(ctx context.Context, body *CreateUserRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	roles, err := internalRoles(body.Roles)
+	if err != nil {
+		return nil, err
+	}
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("username", body.Username))
+	mp.AddPart(multipart.FormFieldString("password", body.Password))
+	mp.AddPart(multipart.FormFieldString("name", body.Name))
+	mp.AddPart(multipart.FormFieldString("email", body.Email))
+	mp.AddPart(multipart.FormField("roles", roles))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		userdirectory.UsersServiceType,
+		"/admin-ng/users",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+// UpdateUser updates an internal user.
+func (c *client) UpdateUser(ctx context.Context, username string, body *UpdateUserRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.UpdateUserRequest(ctx, username, body, opts...) },
+	)
+}
+
+func (c *client) UpdateUserRequest(ctx context.Context, username string, body *UpdateUserRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	roles, err := internalRoles(body.Roles)
+	if err != nil {
+		return nil, err
+	}
+	mp := multipart.New()
+	if body.Password != "" {
+		mp.AddPart(multipart.FormFieldString("password", body.Password))
+	}
+	mp.AddPart(multipart.FormFieldString("name", body.Name))
+	mp.AddPart(multipart.FormFieldString("email", body.Email))
+	mp.AddPart(multipart.FormField("roles", roles))
+	return oc.NewRequest(
+		ctx,
+		http.MethodPut,
+		userdirectory.UsersServiceType,
+		"/admin-ng/users/"+url.PathEscape(username)+".json",
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+// internalRoles encodes the role names as expected by the user endpoints.
+func internalRoles(names []string) ([]byte, error) {
+	roles := make([]userdirectory.Role, 0, len(names))
+	for _, name := range names {
+		roles = append(roles, userdirectory.Role{Name: name, Type: userdirectory.InternalRoleType})
+	}
+	return json.Marshal(roles)
+}
+
+// DeleteUser deletes an internal user.
+func (c *client) DeleteUser(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(
+		c,
+		func() (*oc.Request, error) { return c.DeleteUserRequest(ctx, username, opts...) },
+	)
+}
+
+func (c *client) DeleteUserRequest(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodDelete,
+		userdirectory.UsersServiceType,
+		"/admin-ng/users/"+url.PathEscape(username)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// ListUserRole returns the effective roles of the user, including the roles
+// granted by providers and through group membership.
+func (c *client) ListUserRole(ctx context.Context, username string, opts ...oc.RequestOpts) ([]userdirectory.Role, *oc.Response, error) {
+	user, resp, err := oc.GenericAutoDecodedDo[*userdirectory.User](
+		c,
+		func() (*oc.Request, error) { return c.ListUserRoleRequest(ctx, username, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return user.Roles, resp, nil
+}
+
+func (c *client) ListUserRoleRequest(ctx context.Context, username string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		userdirectory.UserDirectoryServiceType,
+		"/users/"+url.PathEscape(username)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/listopts"
+	"shio.solutions/tales.media/opencast-client-go/apis/userdirectory"
+	userdirectoryclient "shio.solutions/tales.media/opencast-client-go/apis/userdirectory/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newClient(t *testing.T) userdirectoryclient.Client {
+	t.Helper()
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(&opencasttest.Fixtures{
+		Groups: []extapiv1.Group{{
+			Identifier: "studio",
+			Role:       "ROLE_GROUP_STUDIO",
+			Name:       "Studio",
+			Roles:      "ROLE_STUDIO",
+			Members:    "jdoe",
+		}},
+	}))
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := userdirectoryclient.New(occ)
+	for _, u := range []userdirectoryclient.CreateUserRequestBody{
+		{Username: "jdoe", Password: "secret", Name: "Jane Doe", Email: "jdoe@example.org", Roles: []string{"ROLE_EDITOR"}},
+		{Username: "bdoe", Password: "secret", Name: "Bob Doe", Email: "bdoe@example.org"},
+		{Username: "asmith", Password: "secret", Name: "Alice Smith", Email: "asmith@example.org"},
+	} {
+		if _, err := c.CreateUser(context.Background(), &u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c
+}
+
+func usernames(t *testing.T, c userdirectoryclient.Client, opts ...oc.RequestOpts) []string {
+	t.Helper()
+	users, _, err := c.ListUser(context.Background(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	return names
+}
+
+func TestListUser(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name string
+		opts []oc.RequestOpts
+		want []string
+	}{
+		{"text filter", []oc.RequestOpts{listopts.WithFilter{userdirectoryclient.UserTextFilterKey: "doe"}}, []string{"jdoe", "bdoe"}},
+		{"role filter", []oc.RequestOpts{listopts.WithFilter{userdirectoryclient.UserRoleFilterKey: "ROLE_EDITOR"}}, []string{"jdoe"}},
+		{"sort by name", []oc.RequestOpts{
+			listopts.WithFilter{userdirectoryclient.UserProviderFilterKey: userdirectory.InternalProvider},
+			listopts.WithSort{{By: userdirectoryclient.UserNameSortKey}},
+		}, []string{"asmith", "bdoe", "jdoe"}},
+		{"sort descending", []oc.RequestOpts{
+			listopts.WithFilter{userdirectoryclient.UserTextFilterKey: "doe"},
+			listopts.WithSort{{By: userdirectoryclient.UserUsernameSortKey, Direction: listopts.Descending}},
+		}, []string{"jdoe", "bdoe"}},
+		{"pagination", []oc.RequestOpts{
+			listopts.WithSort{{By: userdirectoryclient.UserUsernameSortKey}},
+			listopts.WithPagination{Limit: 2, Offset: 1},
+		}, []string{"asmith", "bdoe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usernames(t, c, tt.opts...); !slices.Equal(got, tt.want) {
+				t.Errorf("got users %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateAndDeleteUser(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	if _, err := c.UpdateUser(ctx, "bdoe", &userdirectoryclient.UpdateUserRequestBody{Name: "Robert Doe", Roles: []string{"ROLE_EDITOR"}}); err != nil {
+		t.Fatal(err)
+	}
+	u, _, err := c.GetUser(ctx, "bdoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "Robert Doe" || u.Email != "" || !u.Manageable || !slices.Equal(u.RoleNames(), []string{"ROLE_EDITOR"}) {
+		t.Fatalf("got user %+v", u)
+	}
+
+	if _, err := c.DeleteUser(ctx, "bdoe"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.GetUser(ctx, "bdoe"); err == nil {
+		t.Error("GetUser after delete succeeded")
+	}
+	if _, err := c.DeleteUser(ctx, opencasttest.DefaultUsername); err == nil {
+		t.Error("deleting a system user succeeded")
+	}
+}
+
+func TestListUserRole(t *testing.T) {
+	c := newClient(t)
+	roles, _, err := c.ListUserRole(context.Background(), "jdoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := map[string]userdirectory.RoleType{}
+	for _, r := range roles {
+		types[r.Name] = r.Type
+	}
+	want := map[string]userdirectory.RoleType{
+		"ROLE_EDITOR":       userdirectory.InternalRoleType,
+		"ROLE_GROUP_STUDIO": userdirectory.GroupRoleType,
+		"ROLE_STUDIO":       userdirectory.DerivedRoleType,
+	}
+	for name, typ := range want {
+		if types[name] != typ {
+			t.Errorf("got role %s of type %q, want %q", name, types[name], typ)
+		}
+	}
+}
+
+func TestListRole(t *testing.T) {
+	c := newClient(t)
+	roles, _, err := c.ListRole(context.Background(),
+		listopts.WithFilter{userdirectoryclient.RoleTypeFilterKey: string(userdirectory.GroupRoleType)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0].Name != "ROLE_GROUP_STUDIO" {
+		t.Fatalf("got roles %+v", roles)
+	}
+
+	roles, _, err = c.ListRole(context.Background(),
+		listopts.WithFilter{userdirectoryclient.RoleTextFilterKey: "user"},
+		listopts.WithSort{{By: userdirectoryclient.RoleNameSortKey, Direction: listopts.Descending}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	if want := []string{"ROLE_USER_ADMIN", "ROLE_USER"}; !slices.Equal(names, want) {
+		t.Errorf("got roles %v, want %v", names, want)
+	}
+}
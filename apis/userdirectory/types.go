@@ -0,0 +1,74 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package userdirectory contains the types of the Opencast user and role
+// providers.
+package userdirectory
+
+const (
+	UsersServiceType = "org.opencastproject.adminui.endpoint.UsersEndpoint"
+	// UserDirectoryServiceType serves the users as merged from all providers.
+	UserDirectoryServiceType = "org.opencastproject.userdirectory.users"
+	RolesServiceType         = "org.opencastproject.userdirectory.roles"
+)
+
+// InternalProvider is the provider of users stored in the Opencast database.
+const InternalProvider = "opencast"
+
+type UsersResponse struct {
+	Total   int    `json:"total"`
+	Offset  int    `json:"offset"`
+	Count   int    `json:"count"`
+	Limit   int    `json:"limit"`
+	Results []User `json:"results"`
+}
+
+type User struct {
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	// Manageable users are stored by Opencast and can be updated and deleted.
+	Manageable bool   `json:"manageable"`
+	Roles      []Role `json:"roles,omitempty"`
+}
+
+// RoleNames returns the names of the roles of the user.
+func (u *User) RoleNames() []string {
+	names := make([]string, 0, len(u.Roles))
+	for _, r := range u.Roles {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+type Role struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Organization string   `json:"organization,omitempty"`
+	Type         RoleType `json:"type,omitempty"`
+}
+
+type RoleType string
+
+const (
+	InternalRoleType      = RoleType("INTERNAL")
+	SystemRoleType        = RoleType("SYSTEM")
+	GroupRoleType         = RoleType("GROUP")
+	ExternalRoleType      = RoleType("EXTERNAL")
+	ExternalGroupRoleType = RoleType("EXTERNAL_GROUP")
+	DerivedRoleType       = RoleType("DERIVED")
+)
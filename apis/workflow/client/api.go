@@ -0,0 +1,81 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/workflow"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type Client interface {
+	Do(*oc.Request) (*oc.Response, error)
+	OpencastClient() oc.Client
+
+	// Instances
+
+	ListInstance(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.WorkflowInstance, *oc.Response, error)
+	ListInstanceRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	GetInstance(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error)
+	GetInstanceRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	StopInstance(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error)
+	StopInstanceRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	SuspendInstance(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error)
+	SuspendInstanceRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	ResumeInstance(ctx context.Context, id string, config base.Properties, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error)
+	ResumeInstanceRequest(ctx context.Context, id string, config base.Properties, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	CleanupInstance(ctx context.Context, body *CleanupInstanceRequestBody, opts ...oc.RequestOpts) (*oc.Response, error)
+	CleanupInstanceRequest(ctx context.Context, body *CleanupInstanceRequestBody, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Statistics
+
+	GetStatistics(ctx context.Context, opts ...oc.RequestOpts) (*workflow.Statistics, *oc.Response, error)
+	GetStatisticsRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+
+	// Operation Handlers
+
+	ListOperationHandler(ctx context.Context, opts ...oc.RequestOpts) ([]workflow.OperationHandler, *oc.Response, error)
+	ListOperationHandlerRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error)
+}
+
+type client struct {
+	occ oc.Client
+}
+
+var _ Client = &client{}
+
+func New(opencastClient oc.Client) *client {
+	return &client{
+		occ: opencastClient,
+	}
+}
+
+func (c *client) Do(req *oc.Request) (*oc.Response, error) {
+	return c.occ.Do(req)
+}
+
+func (c *client) OpencastClient() oc.Client {
+	return c.occ
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strconv"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/workflow"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type WithPagination struct {
+	Limit  int
+	Offset int
+}
+
+var _ oc.RequestOpts = WithPagination{}
+
+// Apply converts the offset to the page of the workflow service, so it
+// should be a multiple of the limit.
+func (opt WithPagination) Apply(r *oc.Request) error {
+	page := 0
+	if opt.Limit > 0 {
+		page = opt.Offset / opt.Limit
+	}
+	return r.ApplyOptions(
+		oc.WithQuery("count", strconv.Itoa(opt.Limit)),
+		oc.WithQuery("startPage", strconv.Itoa(page)),
+	)
+}
+
+// WithState limits instances to those in any of the states.
+func WithState(states ...workflow.State) oc.RequestOpts {
+	return oc.RequestOptsFunc(func(r *oc.Request) error {
+		for _, s := range states {
+			r.Query.Add("state", string(s))
+		}
+		return nil
+	})
+}
+
+// WithoutState excludes instances in any of the states.
+func WithoutState(states ...workflow.State) oc.RequestOpts {
+	return oc.RequestOptsFunc(func(r *oc.Request) error {
+		for _, s := range states {
+			r.Query.Add("state", "-"+string(s))
+		}
+		return nil
+	})
+}
+
+// WithEventID limits instances to those processing the event.
+func WithEventID(id string) oc.RequestOpts {
+	return oc.WithQuery("mp", id)
+}
+
+func WithSeriesID(id string) oc.RequestOpts {
+	return oc.WithQuery("seriesId", id)
+}
+
+func WithDefinitionID(id string) oc.RequestOpts {
+	return oc.WithQuery("workflowdefinition", id)
+}
+
+// WithCurrentOperation limits instances to those currently running the
+// operation.
+func WithCurrentOperation(operation string) oc.RequestOpts {
+	return oc.WithQuery("op", operation)
+}
+
+func WithText(q string) oc.RequestOpts {
+	return oc.WithQuery("q", q)
+}
+
+// WithCompact leaves out the elements of the media packages and the
+// configurations and operations of the instances.
+func WithCompact() oc.RequestOpts {
+	return oc.WithQuery("compact", "true")
+}
+
+type WithSort struct {
+	By        SortKey
+	Direction SortDirection
+}
+
+var _ oc.RequestOpts = WithSort{}
+
+func (opt WithSort) Apply(r *oc.Request) error {
+	sort := string(opt.By)
+	if opt.Direction == Descending {
+		sort += "_DESC"
+	}
+	return r.ApplyOptions(oc.WithQuery("sort", sort))
+}
+
+type SortKey string
+
+const (
+	CreatedSortKey     = SortKey("DATE_CREATED")
+	TitleSortKey       = SortKey("TITLE")
+	SeriesTitleSortKey = SortKey("SERIES_TITLE")
+	CreatorSortKey     = SortKey("CREATOR")
+	WorkflowSortKey    = SortKey("WORKFLOW_DEFINITION_ID")
+)
+
+type SortDirection string
+
+const (
+	Ascending  = SortDirection("asc")
+	Descending = SortDirection("desc")
+)
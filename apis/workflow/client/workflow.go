@@ -0,0 +1,199 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/workflow"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/multipart"
+)
+
+type CleanupInstanceRequestBody struct {
+	// State must be a final state, e.g. SucceededState.
+	State workflow.State
+	// OlderThan is rounded up to full days.
+	OlderThan time.Duration
+}
+
+func (c *client) ListInstance(ctx context.Context, opts ...oc.RequestOpts) ([]extapiv1.WorkflowInstance, *oc.Response, error) {
+	instances, resp, err := oc.GenericAutoDecodedDo[*workflow.InstancesResponse](
+		c,
+		func() (*oc.Request, error) { return c.ListInstanceRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	list := make([]extapiv1.WorkflowInstance, 0, len(instances.Workflows.Workflow))
+	for _, wf := range instances.Workflows.Workflow {
+		list = append(list, wf.WorkflowInstance())
+	}
+	return list, resp, nil
+}
+
+func (c *client) ListInstanceRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		workflow.ServiceType,
+		"/workflow/instances.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) GetInstance(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error) {
+	instance, resp, err := oc.GenericAutoDecodedDo[*workflow.InstanceResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetInstanceRequest(ctx, id, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	wf := instance.Workflow.WorkflowInstance()
+	return &wf, resp, nil
+}
+
+func (c *client) GetInstanceRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		workflow.ServiceType,
+		"/workflow/instance/"+url.PathEscape(id)+".json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+// doAction sends a request changing the state of an instance. The workflow
+// service responds with the changed instance as XML.
+func (c *client) doAction(reqFunc func() (*oc.Request, error)) (*extapiv1.WorkflowInstance, *oc.Response, error) {
+	instance, resp, err := oc.GenericAutoDecodedDo[*workflow.Instance](c, reqFunc)
+	if err != nil {
+		return nil, resp, err
+	}
+	wf := instance.WorkflowInstance()
+	return &wf, resp, nil
+}
+
+func (c *client) actionRequest(ctx context.Context, action string, mp *multipart.Multipart, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodPost,
+		workflow.ServiceType,
+		"/workflow/"+action,
+		oc.NewMultipartBody(mp),
+		opts...,
+	)
+}
+
+func (c *client) StopInstance(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error) {
+	return c.doAction(func() (*oc.Request, error) { return c.StopInstanceRequest(ctx, id, opts...) })
+}
+
+func (c *client) StopInstanceRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("id", id))
+	return c.actionRequest(ctx, "stop", mp, opts...)
+}
+
+func (c *client) SuspendInstance(ctx context.Context, id string, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error) {
+	return c.doAction(func() (*oc.Request, error) { return c.SuspendInstanceRequest(ctx, id, opts...) })
+}
+
+func (c *client) SuspendInstanceRequest(ctx context.Context, id string, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("id", id))
+	return c.actionRequest(ctx, "suspend", mp, opts...)
+}
+
+// ResumeInstance resumes a paused instance. The configuration is added to the
+// configuration of the instance.
+func (c *client) ResumeInstance(ctx context.Context, id string, config base.Properties, opts ...oc.RequestOpts) (*extapiv1.WorkflowInstance, *oc.Response, error) {
+	return c.doAction(func() (*oc.Request, error) { return c.ResumeInstanceRequest(ctx, id, config, opts...) })
+}
+
+func (c *client) ResumeInstanceRequest(ctx context.Context, id string, config base.Properties, opts ...oc.RequestOpts) (*oc.Request, error) {
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("id", id))
+	if len(config) > 0 {
+		mp.AddPart(multipart.FormFieldString("properties", config.Format()))
+	}
+	return c.actionRequest(ctx, "resume", mp, opts...)
+}
+
+// CleanupInstance removes the instances in a final state which were created
+// before the given duration.
+func (c *client) CleanupInstance(ctx context.Context, body *CleanupInstanceRequestBody, opts ...oc.RequestOpts) (*oc.Response, error) {
+	return oc.GenericDo(c, func() (*oc.Request, error) { return c.CleanupInstanceRequest(ctx, body, opts...) })
+}
+
+func (c *client) CleanupInstanceRequest(ctx context.Context, body *CleanupInstanceRequestBody, opts ...oc.RequestOpts) (*oc.Request, error) {
+	days := (body.OlderThan + 24*time.Hour - 1) / (24 * time.Hour)
+	mp := multipart.New()
+	mp.AddPart(multipart.FormFieldString("state", string(body.State)))
+	mp.AddPart(multipart.FormFieldString("buffer", strconv.FormatInt(int64(days), 10)))
+	return c.actionRequest(ctx, "cleanup", mp, opts...)
+}
+
+func (c *client) GetStatistics(ctx context.Context, opts ...oc.RequestOpts) (*workflow.Statistics, *oc.Response, error) {
+	stats, resp, err := oc.GenericAutoDecodedDo[*workflow.StatisticsResponse](
+		c,
+		func() (*oc.Request, error) { return c.GetStatisticsRequest(ctx, opts...) },
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &stats.Statistics, resp, nil
+}
+
+func (c *client) GetStatisticsRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		workflow.ServiceType,
+		"/workflow/statistics.json",
+		oc.NoBody,
+		opts...,
+	)
+}
+
+func (c *client) ListOperationHandler(ctx context.Context, opts ...oc.RequestOpts) ([]workflow.OperationHandler, *oc.Response, error) {
+	return oc.GenericAutoDecodedDo[mediapackage.List[workflow.OperationHandler]](
+		c,
+		func() (*oc.Request, error) { return c.ListOperationHandlerRequest(ctx, opts...) },
+	)
+}
+
+func (c *client) ListOperationHandlerRequest(ctx context.Context, opts ...oc.RequestOpts) (*oc.Request, error) {
+	return oc.NewRequest(
+		ctx,
+		http.MethodGet,
+		workflow.ServiceType,
+		"/workflow/handlers.json",
+		oc.NoBody,
+		opts...,
+	)
+}
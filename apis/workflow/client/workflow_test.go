@@ -0,0 +1,202 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/workflow"
+	workflowclient "shio.solutions/tales.media/opencast-client-go/apis/workflow/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newClient(t *testing.T) workflowclient.Client {
+	t.Helper()
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(&opencasttest.Fixtures{
+		Workflows: []extapiv1.WorkflowInstance{
+			{Identifier: 1, State: extapiv1.RunningWorkflowState, WorkflowDefinitionIdentifier: "fast", EventIdentifier: "e1"},
+			{Identifier: 2, State: extapiv1.PausedWorkflowState, WorkflowDefinitionIdentifier: "fast", EventIdentifier: "e2",
+				Configuration: base.Properties{"publish": "false"}},
+			{Identifier: 3, State: extapiv1.SucceededWorkflowState, WorkflowDefinitionIdentifier: "schedule", EventIdentifier: "e1",
+				Operations: []extapiv1.OperationInstance{
+					{Operation: "inspect", State: extapiv1.SucceededWorkflowOperationState, MaxAttempts: 2, TimeInQueue: 300},
+				}},
+		},
+		WorkflowDefinitions: []extapiv1.WorkflowDefinition{
+			{Identifier: "fast", Operations: []extapiv1.OperationDefinition{{Operation: "inspect"}, {Operation: "publish-engage"}}},
+			{Identifier: "schedule", Operations: []extapiv1.OperationDefinition{{Operation: "inspect"}, {Operation: "schedule"}}},
+		},
+	}))
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return workflowclient.New(occ)
+}
+
+func instanceIDs(t *testing.T, c workflowclient.Client, opts ...oc.RequestOpts) []base.Int {
+	t.Helper()
+	list, _, err := c.ListInstance(context.Background(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []base.Int
+	for _, wf := range list {
+		ids = append(ids, wf.Identifier)
+	}
+	return ids
+}
+
+func TestListInstance(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name string
+		opts []oc.RequestOpts
+		want []base.Int
+	}{
+		{"all", nil, []base.Int{1, 2, 3}},
+		{"state", []oc.RequestOpts{workflowclient.WithState(workflow.RunningState, workflow.PausedState)}, []base.Int{1, 2}},
+		{"without state", []oc.RequestOpts{workflowclient.WithoutState(workflow.SucceededState)}, []base.Int{1, 2}},
+		{"event", []oc.RequestOpts{workflowclient.WithEventID("e1")}, []base.Int{1, 3}},
+		{"definition", []oc.RequestOpts{workflowclient.WithDefinitionID("schedule")}, []base.Int{3}},
+		{"second page", []oc.RequestOpts{workflowclient.WithPagination{Limit: 2, Offset: 2}}, []base.Int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceIDs(t, c, tt.opts...); !slices.Equal(got, tt.want) {
+				t.Errorf("got instances %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	c := newClient(t)
+	wf, _, err := c.GetInstance(context.Background(), "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wf.Identifier != 3 || wf.State != extapiv1.SucceededWorkflowState || wf.EventIdentifier != "e1" {
+		t.Fatalf("got %+v", wf)
+	}
+	if len(wf.Operations) != 1 || wf.Operations[0].MaxAttempts != 2 || wf.Operations[0].TimeInQueue != 300 {
+		t.Fatalf("got operations %+v", wf.Operations)
+	}
+
+	if _, _, err := c.GetInstance(context.Background(), "42"); err == nil {
+		t.Error("GetInstance of a missing instance succeeded")
+	}
+}
+
+func TestInstanceActions(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	wf, _, err := c.SuspendInstance(ctx, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wf.State != extapiv1.PausedWorkflowState {
+		t.Fatalf("got state %s after suspend, want paused", wf.State)
+	}
+
+	wf, _, err = c.ResumeInstance(ctx, "2", base.Properties{"publish": "true", "comment": "ok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wf.State != extapiv1.RunningWorkflowState {
+		t.Fatalf("got state %s after resume, want running", wf.State)
+	}
+	if wf.Configuration["publish"] != "true" || wf.Configuration["comment"] != "ok" {
+		t.Errorf("got configuration %v after resume", wf.Configuration)
+	}
+
+	wf, _, err = c.StopInstance(ctx, "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wf.State != extapiv1.StoppedWorkflowState {
+		t.Fatalf("got state %s after stop, want stopped", wf.State)
+	}
+
+	if _, _, err := c.SuspendInstance(ctx, "2"); err == nil {
+		t.Error("suspending a stopped instance succeeded")
+	}
+	if _, _, err := c.StopInstance(ctx, "3"); err == nil {
+		t.Error("stopping a succeeded instance succeeded")
+	}
+}
+
+func TestCleanupInstance(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	if _, err := c.CleanupInstance(ctx, &workflowclient.CleanupInstanceRequestBody{State: workflow.SucceededState, OlderThan: 24 * time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if got := instanceIDs(t, c); len(got) != 3 {
+		t.Fatalf("got instances %v after cleanup with buffer, want all", got)
+	}
+
+	if _, err := c.CleanupInstance(ctx, &workflowclient.CleanupInstanceRequestBody{State: workflow.SucceededState}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := instanceIDs(t, c), []base.Int{1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("got instances %v after cleanup, want %v", got, want)
+	}
+
+	if _, err := c.CleanupInstance(ctx, &workflowclient.CleanupInstanceRequestBody{State: workflow.RunningState}); err == nil {
+		t.Error("cleanup of running instances succeeded")
+	}
+}
+
+func TestGetStatistics(t *testing.T) {
+	c := newClient(t)
+	stats, _, err := c.GetStatistics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 3 || stats.Running != 1 || stats.Paused != 1 || stats.Finished != 1 {
+		t.Errorf("got counts %+v", stats.Counts)
+	}
+	defs := stats.DefinitionList()
+	if len(defs) != 2 || defs[0].ID != "fast" || defs[0].Total != 2 || defs[1].ID != "schedule" || defs[1].Finished != 1 {
+		t.Errorf("got definitions %+v", defs)
+	}
+}
+
+func TestListOperationHandler(t *testing.T) {
+	c := newClient(t)
+	handlers, _, err := c.ListOperationHandler(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, h := range handlers {
+		ids = append(ids, h.ID)
+	}
+	if want := []string{"inspect", "publish-engage", "schedule"}; !slices.Equal(ids, want) {
+		t.Errorf("got handlers %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workflow contains the types of the Opencast workflow service, which
+// unlike the External API allows to list and manage all workflow instances.
+package workflow
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+const ServiceType = "org.opencastproject.workflow"
+
+// State is the state of a workflow instance as used by the workflow service.
+type State string
+
+const (
+	InstantiatedState = State("INSTANTIATED")
+	RunningState      = State("RUNNING")
+	StoppedState      = State("STOPPED")
+	PausedState       = State("PAUSED")
+	SucceededState    = State("SUCCEEDED")
+	FailedState       = State("FAILED")
+	FailingState      = State("FAILING")
+)
+
+// StateOf returns the workflow service state of an External API state.
+func StateOf(s extapiv1.WorkflowState) State {
+	return State(strings.ToUpper(string(s)))
+}
+
+// Int is an integer the workflow service may encode as a JSON string.
+type Int int64
+
+func (i *Int) UnmarshalJSON(data []byte) error {
+	if len(data) >= 2 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return err
+		}
+		if s == "" {
+			return nil
+		}
+		data = []byte(s)
+	}
+	var i2 int64
+	if err := json.Unmarshal(data, &i2); err != nil {
+		return err
+	}
+	*i = Int(i2)
+	return nil
+}
+
+type InstancesResponse struct {
+	Workflows Instances `json:"workflows"`
+}
+
+type Instances struct {
+	TotalCount Int                         `json:"totalCount"`
+	StartPage  Int                         `json:"startPage"`
+	Count      Int                         `json:"count"`
+	Workflow   mediapackage.List[Instance] `json:"workflow"`
+}
+
+type InstanceResponse struct {
+	Workflow Instance `json:"workflow"`
+}
+
+type Instance struct {
+	XMLName xml.Name `json:"-" xml:"http://workflow.opencastproject.org workflow"`
+
+	ID             Int                        `json:"id" xml:"id,attr"`
+	State          State                      `json:"state" xml:"state,attr"`
+	Template       string                     `json:"template,omitempty" xml:"template,omitempty"`
+	Title          string                     `json:"title,omitempty" xml:"title,omitempty"`
+	Description    string                     `json:"description,omitempty" xml:"description,omitempty"`
+	CreatorID      string                     `json:"creator-id,omitempty" xml:"creator-id,omitempty"`
+	OrganizationID string                     `json:"organization-id,omitempty" xml:"organization-id,omitempty"`
+	MediaPackage   *mediapackage.MediaPackage `json:"mediapackage,omitempty" xml:"mediapackage,omitempty"`
+	Operations     *Operations                `json:"operations,omitempty" xml:"operations,omitempty"`
+	Configurations *Configurations            `json:"configurations,omitempty" xml:"configurations,omitempty"`
+}
+
+type Operations struct {
+	Operation mediapackage.List[Operation] `json:"operation,omitempty" xml:"operation,omitempty"`
+}
+
+type Operation struct {
+	ID                       string          `json:"id" xml:"id,attr"`
+	State                    string          `json:"state" xml:"state,attr"`
+	Description              string          `json:"description,omitempty" xml:"description,attr,omitempty"`
+	If                       string          `json:"if,omitempty" xml:"if,attr,omitempty"`
+	FailOnError              bool            `json:"fail-on-error,omitempty" xml:"fail-on-error,attr,omitempty"`
+	ExceptionHandlerWorkflow string          `json:"exception-handler-workflow,omitempty" xml:"exception-handler-workflow,attr,omitempty"`
+	RetryStrategy            string          `json:"retry-strategy,omitempty" xml:"retry-strategy,attr,omitempty"`
+	MaxAttempts              Int             `json:"max-attempts,omitempty" xml:"max-attempts,attr,omitempty"`
+	FailedAttempts           Int             `json:"failed-attempts,omitempty" xml:"failed-attempts,attr,omitempty"`
+	ExecutionHost            string          `json:"execution-host,omitempty" xml:"execution-host,attr,omitempty"`
+	Started                  base.DateTime   `json:"started,omitzero" xml:"started,omitempty"`
+	Completed                base.DateTime   `json:"completed,omitzero" xml:"completed,omitempty"`
+	TimeInQueue              Int             `json:"time-in-queue,omitempty" xml:"time-in-queue,omitempty"` // milliseconds
+	Configurations           *Configurations `json:"configurations,omitempty" xml:"configurations,omitempty"`
+}
+
+type Configurations struct {
+	Configuration mediapackage.List[Configuration] `json:"configuration,omitempty" xml:"configuration,omitempty"`
+}
+
+type Configuration struct {
+	Key   string `json:"key" xml:"key,attr"`
+	Value string `json:"$" xml:",chardata"`
+}
+
+func (c *Configurations) Properties() base.Properties {
+	if c == nil {
+		return nil
+	}
+	props := make(base.Properties, len(c.Configuration))
+	for _, cfg := range c.Configuration {
+		props[cfg.Key] = cfg.Value
+	}
+	return props
+}
+
+func (i *Instance) OperationList() []Operation {
+	if i.Operations == nil {
+		return nil
+	}
+	return i.Operations.Operation
+}
+
+// WorkflowInstance returns the instance as represented by the External API.
+func (i *Instance) WorkflowInstance() extapiv1.WorkflowInstance {
+	wf := extapiv1.WorkflowInstance{
+		Identifier:                   base.Int(i.ID),
+		Title:                        i.Title,
+		Description:                  i.Description,
+		WorkflowDefinitionIdentifier: i.Template,
+		Creator:                      i.CreatorID,
+		State:                        extapiv1.WorkflowState(strings.ToLower(string(i.State))),
+		Configuration:                i.Configurations.Properties(),
+	}
+	if i.MediaPackage != nil {
+		wf.EventIdentifier = i.MediaPackage.ID
+	}
+	for _, op := range i.OperationList() {
+		wf.Operations = append(wf.Operations, extapiv1.OperationInstance{
+			Operation:            op.ID,
+			Description:          op.Description,
+			State:                extapiv1.WorkflowOperationState(strings.ToLower(op.State)),
+			TimeInQueue:          base.Int(op.TimeInQueue),
+			Host:                 op.ExecutionHost,
+			If:                   op.If,
+			FailWorkflowOnError:  op.FailOnError,
+			ErrorHandlerWorkflow: op.ExceptionHandlerWorkflow,
+			RetryStrategy:        extapiv1.WorkflowRetryStrategy(op.RetryStrategy),
+			MaxAttempts:          base.Int(op.MaxAttempts),
+			FailedAttempts:       base.Int(op.FailedAttempts),
+			Configuration:        op.Configurations.Properties(),
+			Start:                op.Started,
+			Completion:           op.Completed,
+		})
+	}
+	return wf
+}
+
+type StatisticsResponse struct {
+	Statistics Statistics `json:"statistics"`
+}
+
+// Counts are the numbers of workflow instances by state.
+type Counts struct {
+	Total        Int `json:"total"`
+	Instantiated Int `json:"instantiated"`
+	Running      Int `json:"running"`
+	Paused       Int `json:"paused"`
+	Stopped      Int `json:"stopped"`
+	Finished     Int `json:"finished"`
+	Failing      Int `json:"failing"`
+	Failed       Int `json:"failed"`
+}
+
+type Statistics struct {
+	Counts
+	Definitions *DefinitionStatisticsList `json:"definitions,omitempty"`
+}
+
+type DefinitionStatisticsList struct {
+	Definition mediapackage.List[DefinitionStatistics] `json:"definition,omitempty"`
+}
+
+type DefinitionStatistics struct {
+	ID string `json:"id"`
+	Counts
+	Operations *OperationStatisticsList `json:"operations,omitempty"`
+}
+
+type OperationStatisticsList struct {
+	Operation mediapackage.List[OperationStatistics] `json:"operation,omitempty"`
+}
+
+type OperationStatistics struct {
+	ID string `json:"id"`
+	Counts
+}
+
+func (s *Statistics) DefinitionList() []DefinitionStatistics {
+	if s.Definitions == nil {
+		return nil
+	}
+	return s.Definitions.Definition
+}
+
+func (s *DefinitionStatistics) OperationList() []OperationStatistics {
+	if s.Operations == nil {
+		return nil
+	}
+	return s.Operations.Operation
+}
+
+// OperationHandler is an operation available to workflow definitions.
+type OperationHandler struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/workflow"
+)
+
+func TestIntDecodesQuotedNumbers(t *testing.T) {
+	tests := []struct {
+		data string
+		want workflow.Int
+	}{
+		{`42`, 42},
+		{`"42"`, 42},
+		{`""`, 0},
+		{`null`, 0},
+	}
+	for _, tt := range tests {
+		var i workflow.Int
+		if err := json.Unmarshal([]byte(tt.data), &i); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.data, err)
+			continue
+		}
+		if i != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.data, i, tt.want)
+		}
+	}
+
+	var i workflow.Int
+	if err := json.Unmarshal([]byte(`"4x"`), &i); err == nil {
+		t.Error("Unmarshal(\"4x\") succeeded")
+	}
+}
+
+func TestInstanceWorkflowInstance(t *testing.T) {
+	data := `{"workflow":{"id":"7","state":"RUNNING","template":"fast","mediapackage":{"id":"e1"},
+		"operations":{"operation":{"id":"inspect","state":"SUCCEEDED","max-attempts":"2","failed-attempts":"1","time-in-queue":"300"}},
+		"configurations":{"configuration":{"key":"publish","$":"true"}}}}`
+	var resp workflow.InstanceResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	wf := resp.Workflow.WorkflowInstance()
+	if wf.Identifier != 7 || wf.State != "running" || wf.WorkflowDefinitionIdentifier != "fast" || wf.EventIdentifier != "e1" {
+		t.Fatalf("got %+v", wf)
+	}
+	if wf.Configuration["publish"] != "true" {
+		t.Errorf("got configuration %v", wf.Configuration)
+	}
+	if len(wf.Operations) != 1 {
+		t.Fatalf("got %d operations, want 1", len(wf.Operations))
+	}
+	op := wf.Operations[0]
+	if op.Operation != "inspect" || op.State != "succeeded" || op.MaxAttempts != 2 || op.FailedAttempts != 1 || op.TimeInQueue != 300 {
+		t.Errorf("got operation %+v", op)
+	}
+}
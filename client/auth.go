@@ -0,0 +1,201 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	SessionCookie      = "JSESSIONID"
+	SessionLoginPath   = "/j_spring_security_check"
+	SessionLoginPage   = "/admin-ng/login.html"
+	sessionUsernameKey = "j_username"
+	sessionPasswordKey = "j_password"
+)
+
+var LoginFailedErr = errors.New("login failed")
+
+// defaultSender is used by authenticators outside of a client.
+var defaultSender Sender = httpSender{}
+
+type httpSender struct{}
+
+func (httpSender) Send(httpReq *http.Request) (*http.Response, error) {
+	h := http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return h.Do(httpReq)
+}
+
+// Authenticator authenticates requests that cannot be authenticated by static
+// headers or query parameters.
+type Authenticator interface {
+	// Authenticate adds credentials to the request.
+	Authenticate(req *http.Request) error
+	// Reauthenticate renews the credentials if the response indicates that
+	// they expired and reports whether the request should be retried.
+	Reauthenticate(resp *http.Response) (bool, error)
+}
+
+func WithAuthenticator(a Authenticator) RequestOpts {
+	return RequestOptsFunc(func(req *Request) error {
+		req.Authenticator = a
+		return nil
+	})
+}
+
+type sessionAuthenticator struct {
+	username string
+	password string
+
+	jar *cookiejar.Jar
+
+	mtx sync.Mutex // serializes logins
+}
+
+var _ Authenticator = &sessionAuthenticator{}
+
+// NewSessionAuthenticator returns an authenticator using the form login of
+// Opencast. The login happens lazily on the first request to a host and is
+// repeated once the session expired. Concurrent requests noticing the expired
+// session trigger a single login only. Logins are sent through the HTTP client
+// and the recorder of the client sending the request, see [SenderFromContext].
+func NewSessionAuthenticator(username, password string) Authenticator {
+	jar, _ := cookiejar.New(nil)
+	return &sessionAuthenticator{
+		username: username,
+		password: password,
+		jar:      jar,
+	}
+}
+
+func (a *sessionAuthenticator) Authenticate(req *http.Request) error {
+	sessionID := a.sessionID(req.URL)
+	if sessionID == "" {
+		var err error
+		sessionID, err = a.login(req.Context(), req.URL, "")
+		if err != nil {
+			return err
+		}
+	}
+	req.AddCookie(&http.Cookie{Name: SessionCookie, Value: sessionID})
+	return nil
+}
+
+func (a *sessionAuthenticator) Reauthenticate(resp *http.Response) (bool, error) {
+	if resp.Request == nil {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusUnauthorized && !isLoginPage(resp.Request.URL) && !redirectsToLoginPage(resp) {
+		return false, nil
+	}
+
+	// find the session used by the initial request before any redirect
+	req := resp.Request
+	for req.Response != nil && req.Response.Request != nil {
+		req = req.Response.Request
+	}
+	var staleID string
+	if c, err := req.Cookie(SessionCookie); err == nil {
+		staleID = c.Value
+	}
+
+	if _, err := a.login(req.Context(), req.URL, staleID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (a *sessionAuthenticator) sessionID(u *url.URL) string {
+	for _, c := range a.jar.Cookies(u) {
+		if c.Name == SessionCookie {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// login logs in at the host of u unless the current session differs from
+// staleID, i.e. another caller already renewed the session.
+func (a *sessionAuthenticator) login(ctx context.Context, u *url.URL, staleID string) (string, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if sessionID := a.sessionID(u); sessionID != "" && sessionID != staleID {
+		return sessionID, nil
+	}
+
+	loginURL := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: SessionLoginPath}
+	form := url.Values{}
+	form.Set(sessionUsernameKey, a.username)
+	form.Set(sessionPasswordKey, a.password)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginURL.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", UserAgent)
+
+	sender, ok := SenderFromContext(ctx)
+	if !ok {
+		sender = defaultSender
+	}
+	resp, err := sender.Send(req)
+	if err != nil {
+		return "", err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	// Opencast redirects to the login page again if the login failed
+	if resp.StatusCode >= 400 || redirectsToLoginPage(resp) {
+		return "", LoginFailedErr
+	}
+	if loc, err := resp.Location(); err == nil && loc.Query().Has("error") {
+		return "", LoginFailedErr
+	}
+	a.jar.SetCookies(loginURL, resp.Cookies())
+
+	sessionID := a.sessionID(u)
+	if sessionID == "" {
+		return "", LoginFailedErr
+	}
+	return sessionID, nil
+}
+
+// redirectsToLoginPage reports whether resp is a redirect to the login page,
+// which Opencast answers requests with an expired session with.
+func redirectsToLoginPage(resp *http.Response) bool {
+	if resp.StatusCode < 300 || 400 <= resp.StatusCode {
+		return false
+	}
+	loc, err := resp.Location()
+	return err == nil && isLoginPage(loc)
+}
+
+func isLoginPage(u *url.URL) bool {
+	return strings.HasSuffix(u.Path, SessionLoginPage) || strings.HasSuffix(u.Path, SessionLoginPath)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+type countingTransport struct {
+	logins atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == oc.SessionLoginPath {
+		t.logins.Add(1)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newSessionClient(t *testing.T, srv *opencasttest.Server, h http.Client) extapiclientv1.Client {
+	t.Helper()
+	c, err := oc.New(srv.ServiceMapper(),
+		oc.WithHTTPClient(h),
+		oc.WithRequestOptions(oc.WithAuthenticator(oc.NewSessionAuthenticator(opencasttest.DefaultUsername, opencasttest.DefaultPassword))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return extapiclientv1.New(c)
+}
+
+func TestSessionAuthenticatorUsesHTTPClient(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	transport := &countingTransport{}
+	api := newSessionClient(t, srv, http.Client{Transport: transport})
+
+	ctx := context.Background()
+	for range 3 {
+		if _, _, err := api.GetAPIVersion(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := transport.logins.Load(); n != 1 {
+		t.Fatalf("got %d logins, want 1", n)
+	}
+
+	srv.ExpireSessions()
+	if _, _, err := api.GetAPIVersion(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := transport.logins.Load(); n != 2 {
+		t.Fatalf("got %d logins after session expired, want 2", n)
+	}
+}
+
+func TestSessionAuthenticatorRedirectToLoginPage(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	transport := &countingTransport{}
+	api := newSessionClient(t, srv, http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	})
+
+	ctx := context.Background()
+	if _, _, err := api.GetAPIVersion(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.InjectFault(opencasttest.Fault{
+		Method:     http.MethodGet,
+		Path:       "/api/version",
+		StatusCode: http.StatusFound,
+		Header:     http.Header{"Location": {oc.SessionLoginPage}},
+		Times:      1,
+	})
+	_, resp, err := api.GetAPIVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if n := transport.logins.Load(); n != 2 {
+		t.Fatalf("got %d logins, want 2", n)
+	}
+}
+
+func TestSessionAuthenticatorWrongPassword(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	c, err := oc.New(srv.ServiceMapper(),
+		oc.WithRequestOptions(oc.WithAuthenticator(oc.NewSessionAuthenticator(opencasttest.DefaultUsername, "wrong"))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = extapiclientv1.New(c).GetAPIVersion(context.Background())
+	if err == nil || !errors.Is(err, oc.LoginFailedErr) {
+		t.Fatalf("got error %v, want %v", err, oc.LoginFailedErr)
+	}
+}
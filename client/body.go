@@ -33,6 +33,16 @@ type Body interface {
 	ContentType() string
 }
 
+// Rewindable reports whether the body can be read more than once, e.g. for
+// retrying a request. Bodies are rewindable unless they implement
+// Rewindable() bool reporting otherwise.
+func Rewindable(b Body) bool {
+	if r, ok := b.(interface{ Rewindable() bool }); ok {
+		return r.Rewindable()
+	}
+	return true
+}
+
 var NoBody = noBody{}
 
 type noBody struct{}
@@ -244,6 +254,10 @@ func (b *multipartBody) Reader() (io.ReadCloser, error) {
 	return r, nil
 }
 
+func (b *multipartBody) Rewindable() bool {
+	return b.mp.Rewindable()
+}
+
 func (b *multipartBody) ContentType() string {
 	return `multipart/form-data; boundary="` + b.mp.Boundary() + `"`
 }
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var CircuitOpenErr = errors.New("circuit open")
+
+type CircuitState int
+
+const (
+	// ClosedCircuitState lets all requests pass.
+	ClosedCircuitState CircuitState = iota
+	// OpenCircuitState rejects all requests.
+	OpenCircuitState
+	// HalfOpenCircuitState lets a limited number of trial requests pass.
+	HalfOpenCircuitState
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case ClosedCircuitState:
+		return "closed"
+	case OpenCircuitState:
+		return "open"
+	case HalfOpenCircuitState:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit. Defaults to 5.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before trial requests
+	// are let through. Defaults to 30s.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of concurrent trial requests in the
+	// half-open state. Defaults to 1.
+	HalfOpenMaxRequests int
+	// SuccessThreshold is the number of successful trial requests closing the
+	// circuit. Defaults to 1.
+	SuccessThreshold int
+
+	// IsFailure reports whether a request failed. Defaults to transport
+	// errors and 5xx responses. Canceled requests are never recorded, e.g.
+	// the losers of hedged requests, they only release their trial slot.
+	IsFailure func(resp *Response, err error) bool
+	// OnStateChange is called after the circuit of a host changed its state,
+	// e.g. for alerting. It must not block.
+	OnStateChange func(host string, from, to CircuitState)
+}
+
+// CircuitBreaker tracks the health of hosts and stops requests to hosts
+// failing repeatedly. Hosts are identified by scheme and host of their URL.
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mtx   sync.Mutex
+	hosts map[string]*circuit // protected by mtx
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	successes int
+	trials    int
+	openedAt  time.Time
+}
+
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxRequests <= 0 {
+		cfg.HalfOpenMaxRequests = 1
+	}
+	if cfg.SuccessThreshold <= 0 {
+		cfg.SuccessThreshold = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = defaultIsFailure
+	}
+	return &CircuitBreaker{
+		cfg:   cfg,
+		hosts: make(map[string]*circuit),
+	}
+}
+
+// WithCircuitBreaker rejects requests to hosts with an open circuit with
+// CircuitOpenErr. The breaker may be shared by multiple clients and service
+// mappers. See [SkipOpenCircuits] to route requests to other hosts instead.
+func WithCircuitBreaker(cb *CircuitBreaker) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		c.cb = cb
+		return nil
+	})
+}
+
+func defaultIsFailure(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// State returns the state of the circuit of host.
+func (cb *CircuitBreaker) State(host string) CircuitState {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	if c, ok := cb.hosts[normalizeHost(host)]; ok {
+		return c.state
+	}
+	return ClosedCircuitState
+}
+
+// Available reports whether requests to host would currently be let through.
+func (cb *CircuitBreaker) Available(host string) bool {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	c, ok := cb.hosts[normalizeHost(host)]
+	if !ok {
+		return true
+	}
+	switch c.state {
+	case OpenCircuitState:
+		return time.Since(c.openedAt) >= cb.cfg.OpenTimeout
+	case HalfOpenCircuitState:
+		return c.trials < cb.cfg.HalfOpenMaxRequests
+	default:
+		return true
+	}
+}
+
+// Reset closes the circuit of host.
+func (cb *CircuitBreaker) Reset(host string) {
+	host = normalizeHost(host)
+	cb.mtx.Lock()
+	c, ok := cb.hosts[host]
+	var from CircuitState
+	if ok {
+		from = c.state
+		delete(cb.hosts, host)
+	}
+	cb.mtx.Unlock()
+	if ok && from != ClosedCircuitState {
+		cb.notify(host, from, ClosedCircuitState)
+	}
+}
+
+// allow checks whether a request to host may be sent. done must be called
+// with the outcome of the request.
+func (cb *CircuitBreaker) allow(host string) (done func(resp *Response, err error), err error) {
+	cb.mtx.Lock()
+	c, ok := cb.hosts[host]
+	if !ok {
+		c = &circuit{}
+		cb.hosts[host] = c
+	}
+
+	from := c.state
+	if c.state == OpenCircuitState {
+		if time.Since(c.openedAt) < cb.cfg.OpenTimeout {
+			cb.mtx.Unlock()
+			return nil, fmt.Errorf("%w: %s", CircuitOpenErr, host)
+		}
+		c.state = HalfOpenCircuitState
+		c.trials, c.successes = 0, 0
+	}
+
+	trial := c.state == HalfOpenCircuitState
+	if trial {
+		if c.trials < cb.cfg.HalfOpenMaxRequests {
+			c.trials++
+		} else {
+			err = fmt.Errorf("%w: %s", CircuitOpenErr, host)
+		}
+	}
+	to := c.state
+	cb.mtx.Unlock()
+	if from != to {
+		cb.notify(host, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	return func(resp *Response, err error) {
+		once.Do(func() {
+			canceled := errors.Is(err, context.Canceled)
+			cb.record(host, trial, canceled, !canceled && cb.cfg.IsFailure(resp, err))
+		})
+	}, nil
+}
+
+// record records the outcome of a request. Canceled requests only release
+// their trial slot.
+func (cb *CircuitBreaker) record(host string, trial, canceled, failed bool) {
+	cb.mtx.Lock()
+	c := cb.hosts[host]
+	if c == nil {
+		// reset in the meantime
+		cb.mtx.Unlock()
+		return
+	}
+
+	from := c.state
+	if trial && c.state == HalfOpenCircuitState {
+		c.trials--
+	}
+	switch {
+	case canceled:
+		// says nothing about the health of the host
+
+	case failed && c.state == HalfOpenCircuitState:
+		c.state = OpenCircuitState
+		c.openedAt = time.Now()
+
+	case failed && c.state == ClosedCircuitState:
+		c.failures++
+		if c.failures >= cb.cfg.FailureThreshold {
+			c.state = OpenCircuitState
+			c.openedAt = time.Now()
+		}
+
+	case !failed && c.state == HalfOpenCircuitState:
+		c.successes++
+		if c.successes >= cb.cfg.SuccessThreshold {
+			c.state = ClosedCircuitState
+			c.failures = 0
+		}
+
+	case !failed && c.state == ClosedCircuitState:
+		c.failures = 0
+	}
+	to := c.state
+	cb.mtx.Unlock()
+
+	if from != to {
+		cb.notify(host, from, to)
+	}
+}
+
+func (cb *CircuitBreaker) notify(host string, from, to CircuitState) {
+	if cb.cfg.OnStateChange != nil {
+		cb.cfg.OnStateChange(host, from, to)
+	}
+}
+
+// hostKey returns the scheme and host of u identifying a host of the circuit
+// breaker.
+func hostKey(u *url.URL) string {
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+func normalizeHost(host string) string {
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(host)
+	}
+	return hostKey(u)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newBreakerServer(t *testing.T) *opencasttest.Server {
+	t.Helper()
+	srv := opencasttest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test", func(http.ResponseWriter, *http.Request) {})
+	return srv
+}
+
+func openCircuit(t *testing.T, srv *opencasttest.Server, c oc.Client, cb *oc.CircuitBreaker) {
+	t.Helper()
+	remove := srv.InjectStatus(http.MethodGet, "/test", http.StatusServiceUnavailable)
+	defer remove()
+	for cb.State(srv.URL) != oc.OpenCircuitState {
+		if err := doTest(t, c, context.Background()); errors.Is(err, oc.CircuitOpenErr) {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	srv := newBreakerServer(t)
+	cb := oc.NewCircuitBreaker(oc.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond})
+	c, err := srv.Client(oc.WithCircuitBreaker(cb))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	openCircuit(t, srv, c, cb)
+	if err := doTest(t, c, context.Background()); !errors.Is(err, oc.CircuitOpenErr) {
+		t.Fatalf("got error %v, want %v", err, oc.CircuitOpenErr)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if err := doTest(t, c, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := cb.State(srv.URL); s != oc.ClosedCircuitState {
+		t.Fatalf("got state %s, want %s", s, oc.ClosedCircuitState)
+	}
+}
+
+func TestCircuitBreakerIgnoresCanceledTrial(t *testing.T) {
+	srv := newBreakerServer(t)
+	cb := oc.NewCircuitBreaker(oc.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+	c, err := srv.Client(oc.WithCircuitBreaker(cb))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	openCircuit(t, srv, c, cb)
+	time.Sleep(30 * time.Millisecond)
+
+	remove := srv.InjectLatency(http.MethodGet, "/test", time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if err := doTest(t, c, ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	remove()
+
+	if s := cb.State(srv.URL); s != oc.HalfOpenCircuitState {
+		t.Fatalf("got state %s after canceled trial, want %s", s, oc.HalfOpenCircuitState)
+	}
+	if !cb.Available(srv.URL) {
+		t.Fatal("trial slot of canceled request was not released")
+	}
+}
+
+func TestDynamicServiceMapperSkipOpenCircuits(t *testing.T) {
+	healthy := newBreakerServer(t)
+	failing := newBreakerServer(t)
+	healthy.RegisterService(testService, healthy.URL, failing.URL)
+
+	cb := oc.NewCircuitBreaker(oc.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	registry, err := healthy.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := oc.NewDynamicServiceMapper(registry, time.Minute, oc.SkipOpenCircuits(cb))
+
+	hosts, err := sm.GetHosts(testService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("got hosts %v, want both", hosts)
+	}
+
+	c, err := failing.Client(oc.WithCircuitBreaker(cb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openCircuit(t, failing, c, cb)
+
+	hosts, err = sm.GetHosts(testService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || hosts[0] != healthy.URL {
+		t.Fatalf("got hosts %v, want %s only", hosts, healthy.URL)
+	}
+}
@@ -0,0 +1,539 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached response.
+type CacheEntry struct {
+	Key        string      `json:"key"`
+	Path       string      `json:"path"`
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Expires      time.Time `json:"expires"`
+}
+
+// CacheStore stores cached responses. Implementations must be safe for
+// concurrent use.
+type CacheStore interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(e *CacheEntry)
+	Delete(key string)
+	// DeleteFunc deletes all entries for which del returns true given their
+	// key and resource path.
+	DeleteFunc(del func(key, path string) bool)
+}
+
+// IdentitySecretCacheStore is implemented by stores providing the secret
+// the credentials in cache keys are hashed with. Clients need the same secret
+// to share per-user entries, stores not implementing it get a random secret
+// per client.
+type IdentitySecretCacheStore interface {
+	CacheStore
+	IdentitySecret() []byte
+}
+
+type responseCache struct {
+	store  CacheStore
+	ttl    time.Duration
+	secret []byte
+}
+
+// CacheMaxEntrySize is the size of the largest response body cached. Larger
+// responses are passed through without being buffered completely.
+const CacheMaxEntrySize = 1 << 20
+
+// WithCache caches responses of GET requests in store. Responses with ETag or
+// Last-Modified header are revalidated with conditional requests, others are
+// cached for ttl. The Cache-Control directives no-store, no-cache, private and
+// max-age of responses are honored. Responses are cached per user, i.e. per
+// credentials, authenticator and run-as headers of the request, and only if
+// their body is at most CacheMaxEntrySize bytes.
+//
+// Successful requests with other methods, except for requests marked as
+// idempotent, invalidate the cached responses of the same resource path, its
+// descendants and its ancestors below the top-level path, e.g. /api.
+func WithCache(store CacheStore, ttl time.Duration) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		c.cache = &responseCache{store: store, ttl: ttl}
+		if s, ok := store.(IdentitySecretCacheStore); ok {
+			c.cache.secret = s.IdentitySecret()
+		} else {
+			c.cache.secret = []byte(rand.Text())
+		}
+		return nil
+	})
+}
+
+// cacheVaryHeaders are the request headers affecting the response.
+var cacheVaryHeaders = []string{"Accept", "Accept-Language", RunAsUserHeader, RunWithRolesHeader}
+
+// cacheIdentityHeaders are the request headers carrying credentials. They are
+// part of the cache key as keyed hash only, since keys may be written to
+// disk.
+var cacheIdentityHeaders = []string{"Authorization", "Cookie"}
+
+func (rc *responseCache) key(req *Request) string {
+	query := req.Query
+	if query.Has("jwt") {
+		query = maps.Clone(query)
+		query.Del("jwt")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(req.Service)
+	sb.WriteByte(' ')
+	sb.WriteString(cachePath(req.Path))
+	sb.WriteByte('?')
+	sb.WriteString(query.Encode())
+	for _, h := range cacheVaryHeaders {
+		if v := req.Header.Get(h); v != "" {
+			sb.WriteString("\n" + h + ": " + v)
+		}
+	}
+	if id := rc.identity(req); id != "" {
+		sb.WriteString("\nidentity: " + id)
+	}
+	return sb.String()
+}
+
+// identity returns an HMAC of the credentials of the request or an empty
+// string for anonymous requests.
+func (rc *responseCache) identity(req *Request) string {
+	h := hmac.New(sha256.New, rc.secret)
+	anonymous := true
+	for _, k := range cacheIdentityHeaders {
+		for _, v := range req.Header.Values(k) {
+			_, _ = fmt.Fprintf(h, "%s: %s\n", k, v)
+			anonymous = false
+		}
+	}
+	if v := req.Query.Get("jwt"); v != "" {
+		_, _ = fmt.Fprintf(h, "jwt: %s\n", v)
+		anonymous = false
+	}
+	if req.Authenticator != nil {
+		_, _ = fmt.Fprintf(h, "authenticator: %p\n", req.Authenticator)
+		anonymous = false
+	}
+	if anonymous {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func cachePath(p string) string {
+	return path.Clean("/" + p)
+}
+
+func (rc *responseCache) do(req *Request, do func(*Request) (*Response, error)) (*Response, error) {
+	if req.Method != http.MethodGet || req.Stream {
+		resp, err := do(req)
+		if err == nil && 200 <= resp.StatusCode && resp.StatusCode < 300 && !req.IsIdempotent() {
+			rc.invalidate(req.Path)
+		}
+		return resp, err
+	}
+
+	key := rc.key(req)
+	e, cached := rc.store.Get(key)
+	if cached {
+		if time.Now().Before(e.Expires) {
+			return e.response(), nil
+		}
+		if e.ETag != "" {
+			req.Header.Set("If-None-Match", e.ETag)
+		}
+		if e.LastModified != "" {
+			req.Header.Set("If-Modified-Since", e.LastModified)
+		}
+	}
+
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cached && resp.StatusCode == http.StatusNotModified:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if expires, ok := rc.expires(req, resp.Header, e.ETag != "" || e.LastModified != ""); ok {
+			e.Expires = expires
+			rc.store.Set(e)
+		} else {
+			rc.store.Delete(key)
+		}
+		cachedResp := e.response()
+		cachedResp.Meta.Duration = resp.Meta.Duration
+		return cachedResp, nil
+
+	case resp.StatusCode == http.StatusOK:
+		validators := resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+		expires, ok := rc.expires(req, resp.Header, validators)
+		if !ok || resp.ContentLength > CacheMaxEntrySize {
+			if cached {
+				rc.store.Delete(key)
+			}
+			return resp, nil
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, CacheMaxEntrySize+1))
+		if err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > CacheMaxEntrySize {
+			if cached {
+				rc.store.Delete(key)
+			}
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		rc.store.Set(&CacheEntry{
+			Key:          key,
+			Path:         cachePath(req.Path),
+			StatusCode:   resp.StatusCode,
+			Header:       resp.Header.Clone(),
+			Body:         body,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Expires:      expires,
+		})
+	}
+	return resp, nil
+}
+
+// expires returns until when a response may be served from the cache without
+// revalidation and reports whether it may be cached at all. Responses with
+// validators are revalidated each time unless max-age says otherwise.
+func (rc *responseCache) expires(req *Request, header http.Header, validators bool) (time.Time, bool) {
+	now := time.Now()
+	expires := now.Add(rc.ttl)
+	if validators {
+		expires = now
+	}
+
+	for _, v := range header.Values("Cache-Control") {
+		for directive := range strings.SplitSeq(v, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-store":
+				return time.Time{}, false
+			case "private":
+				// the cache is shared by all anonymous requests
+				if rc.identity(req) == "" {
+					return time.Time{}, false
+				}
+			case "no-cache":
+				if !validators {
+					return time.Time{}, false
+				}
+				return now, true
+			case "max-age":
+				if secs, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+					expires = now.Add(time.Duration(secs) * time.Second)
+				}
+			}
+		}
+	}
+	if !validators && !expires.After(now) {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+// invalidate deletes all entries of p, its descendants and its ancestors
+// below the top-level path.
+func (rc *responseCache) invalidate(p string) {
+	p = cachePath(p)
+	rc.store.DeleteFunc(func(_, entryPath string) bool {
+		if isPathPrefix(p, entryPath) {
+			return true
+		}
+		return isPathPrefix(entryPath, p) && strings.Count(entryPath, "/") > 1
+	})
+}
+
+func isPathPrefix(prefix, p string) bool {
+	return p == prefix || strings.HasPrefix(p, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+func (e *CacheEntry) response() *Response {
+	resp := &Response{Response: http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+	}}
+	resp.Meta.Cached = true
+	return resp
+}
+
+type lruCacheStore struct {
+	maxEntries int
+	secret     []byte
+
+	mtx     sync.Mutex
+	entries map[string]*list.Element // protected by mtx
+	lru     *list.List               // protected by mtx, front is most recent
+}
+
+var _ IdentitySecretCacheStore = &lruCacheStore{}
+
+// NewLRUCacheStore returns an in-memory store evicting the least recently
+// used entries if it holds more than maxEntries.
+func NewLRUCacheStore(maxEntries int) *lruCacheStore {
+	return &lruCacheStore{
+		maxEntries: maxEntries,
+		secret:     []byte(rand.Text()),
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (s *lruCacheStore) IdentitySecret() []byte {
+	return s.secret
+}
+
+func (s *lruCacheStore) Get(key string) (*CacheEntry, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.lru.MoveToFront(el)
+	e := *el.Value.(*CacheEntry)
+	return &e, true
+}
+
+func (s *lruCacheStore) Set(e *CacheEntry) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if el, ok := s.entries[e.Key]; ok {
+		el.Value = e
+		s.lru.MoveToFront(el)
+		return
+	}
+	s.entries[e.Key] = s.lru.PushFront(e)
+	for s.maxEntries > 0 && s.lru.Len() > s.maxEntries {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*CacheEntry).Key)
+	}
+}
+
+func (s *lruCacheStore) Delete(key string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.lru.Remove(el)
+		delete(s.entries, key)
+	}
+}
+
+func (s *lruCacheStore) DeleteFunc(del func(key, path string) bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for key, el := range s.entries {
+		if del(key, el.Value.(*CacheEntry).Path) {
+			s.lru.Remove(el)
+			delete(s.entries, key)
+		}
+	}
+}
+
+type diskCacheStore struct {
+	dir    string
+	secret []byte
+
+	mtx   sync.Mutex
+	paths map[string]string // key -> resource path, protected by mtx
+}
+
+var _ IdentitySecretCacheStore = &diskCacheStore{}
+
+// diskCacheSecretFile is the file in the cache directory holding the secret
+// the credentials in cache keys are hashed with.
+const diskCacheSecretFile = "identity.secret"
+
+// NewDiskCacheStore returns a store keeping one file per entry in dir. Entries
+// stored by previous processes are reused. The directory and the files are
+// accessible by the owner only.
+func NewDiskCacheStore(dir string) (*diskCacheStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	secret, err := readOrCreateSecret(filepath.Join(dir, diskCacheSecretFile))
+	if err != nil {
+		return nil, err
+	}
+	s := &diskCacheStore{
+		dir:    dir,
+		secret: secret,
+		paths:  make(map[string]string),
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		e, err := readCacheEntry(f)
+		if err != nil {
+			_ = os.Remove(f)
+			continue
+		}
+		s.paths[e.Key] = e.Path
+	}
+	return s, nil
+}
+
+// readOrCreateSecret reads the secret from file or creates the file with a
+// random secret if it does not exist yet.
+func readOrCreateSecret(file string) ([]byte, error) {
+	secret := []byte(rand.Text())
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.Write(secret)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(file)
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (s *diskCacheStore) IdentitySecret() []byte {
+	return s.secret
+}
+
+func (s *diskCacheStore) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func readCacheEntry(file string) (*CacheEntry, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	e := &CacheEntry{}
+	if err := json.Unmarshal(b, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *diskCacheStore) Get(key string) (*CacheEntry, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.paths[key]; !ok {
+		return nil, false
+	}
+	e, err := readCacheEntry(s.file(key))
+	if err != nil || e.Key != key {
+		if errors.Is(err, fs.ErrNotExist) {
+			delete(s.paths, key)
+		}
+		return nil, false
+	}
+	return e, true
+}
+
+func (s *diskCacheStore) Set(e *CacheEntry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	file := s.file(e.Key)
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return
+	}
+	s.paths[e.Key] = e.Path
+}
+
+func (s *diskCacheStore) Delete(key string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.delete(key)
+}
+
+func (s *diskCacheStore) delete(key string) {
+	// s.mtx is assumed to be locked
+	_ = os.Remove(s.file(key))
+	delete(s.paths, key)
+}
+
+func (s *diskCacheStore) DeleteFunc(del func(key, path string) bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for key, p := range s.paths {
+		if del(key, p) {
+			s.delete(key)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+// newEchoServer returns a server without authentication whose /test
+// endpoints answer with the Authorization header and the Cache-Control
+// header given by the cc query parameter.
+func newEchoServer(t *testing.T, hits *atomic.Int32) *opencasttest.Server {
+	t.Helper()
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test/", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if cc := r.URL.Query().Get("cc"); cc != "" {
+			w.Header().Set("Cache-Control", cc)
+		}
+		_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+	})
+	srv.HandleFunc("POST /test/", func(http.ResponseWriter, *http.Request) {})
+	return srv
+}
+
+func doPath(t *testing.T, c oc.Client, method, path string, opts ...oc.RequestOpts) *oc.Response {
+	t.Helper()
+	req, err := oc.NewRequest(context.Background(), method, testService, path, oc.NoBody, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func readBody(t *testing.T, resp *oc.Response) string {
+	t.Helper()
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestCacheNotSharedAcrossCredentials(t *testing.T) {
+	var hits atomic.Int32
+	srv := newEchoServer(t, &hits)
+	store := oc.NewLRUCacheStore(10)
+
+	alice, err := oc.New(srv.ServiceMapper(), oc.WithCache(store, time.Minute), oc.WithRequestOptions(oc.WithBasicAuth("alice", "a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := oc.New(srv.ServiceMapper(), oc.WithCache(store, time.Minute), oc.WithRequestOptions(oc.WithBasicAuth("bob", "b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := readBody(t, doPath(t, alice, http.MethodGet, "/test/x"))
+	b := readBody(t, doPath(t, bob, http.MethodGet, "/test/x"))
+	if a == b {
+		t.Fatalf("bob got the cached response of alice: %q", b)
+	}
+	resp := doPath(t, alice, http.MethodGet, "/test/x")
+	if !resp.Meta.Cached || readBody(t, resp) != a {
+		t.Fatal("response of alice was not cached")
+	}
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("got %d hits, want 2", n)
+	}
+}
+
+func TestCacheHonorsCacheControl(t *testing.T) {
+	tests := []struct {
+		cc       string
+		basic    bool
+		wantHits int32
+	}{
+		{cc: "", wantHits: 1},
+		{cc: "no-store", wantHits: 2},
+		{cc: "no-cache", wantHits: 2},
+		{cc: "max-age=0", wantHits: 2},
+		{cc: "max-age=60", wantHits: 1},
+		{cc: "private", wantHits: 2},
+		{cc: "private", basic: true, wantHits: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cc, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := newEchoServer(t, &hits)
+			opts := []oc.ClientOpts{oc.WithCache(oc.NewLRUCacheStore(10), time.Minute)}
+			if tt.basic {
+				opts = append(opts, oc.WithAdditionalRequestOptions(oc.WithBasicAuth("alice", "a")))
+			}
+			c, err := oc.New(srv.ServiceMapper(), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range 2 {
+				readBody(t, doPath(t, c, http.MethodGet, "/test/x", oc.WithQuery("cc", tt.cc)))
+			}
+			if n := hits.Load(); n != tt.wantHits {
+				t.Fatalf("got %d hits, want %d", n, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	var hits atomic.Int32
+	srv := newEchoServer(t, &hits)
+	c, err := oc.New(srv.ServiceMapper(), oc.WithCache(oc.NewLRUCacheStore(10), time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []string{"/test", "/test/a", "/test/a/b/c", "/test/other"}
+	for _, p := range paths {
+		readBody(t, doPath(t, c, http.MethodGet, p+"/"))
+	}
+	readBody(t, doPath(t, c, http.MethodPost, "/test/a/b"))
+	readBody(t, doPath(t, c, http.MethodPost, "/test/other", oc.WithIdempotent()))
+
+	want := map[string]bool{"/test": true, "/test/a": false, "/test/a/b/c": false, "/test/other": true}
+	for _, p := range paths {
+		if resp := doPath(t, c, http.MethodGet, p+"/"); resp.Meta.Cached != want[p] {
+			t.Errorf("%s: got cached %v, want %v", p, resp.Meta.Cached, want[p])
+		}
+	}
+}
+
+func TestCacheSkipsLargeResponses(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	defer srv.Close()
+	large := strings.Repeat("x", oc.CacheMaxEntrySize+1)
+	var hits atomic.Int32
+	srv.HandleFunc("GET /large", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// no Content-Length, so the size is only known after reading
+		w.(http.Flusher).Flush()
+		_, _ = io.WriteString(w, large)
+	})
+
+	c, err := oc.New(srv.ServiceMapper(), oc.WithCache(oc.NewLRUCacheStore(10), time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if body := readBody(t, doPath(t, c, http.MethodGet, "/large")); body != large {
+			t.Fatalf("got body of %d bytes, want %d", len(body), len(large))
+		}
+	}
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("got %d hits, want 2", n)
+	}
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithETags())
+	defer srv.Close()
+	c, err := srv.Client(oc.WithCache(oc.NewLRUCacheStore(10), time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := extapiclientv1.New(c)
+
+	var conditional atomic.Int32
+	srv.OnRequest(func(r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditional.Add(1)
+		}
+	})
+	for i := range 2 {
+		_, resp, err := api.GetAPIVersion(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Meta.Cached != (i == 1) {
+			t.Fatalf("request %d: got cached %v", i, resp.Meta.Cached)
+		}
+	}
+	if n := conditional.Load(); n != 1 {
+		t.Fatalf("got %d conditional requests, want 1", n)
+	}
+}
+
+func TestDiskCacheStore(t *testing.T) {
+	var hits atomic.Int32
+	srv := newEchoServer(t, &hits)
+	dir := filepath.Join(t.TempDir(), "cache")
+	auth := oc.WithBasicAuth("alice", "a")
+
+	newClient := func() oc.Client {
+		store, err := oc.NewDiskCacheStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := oc.New(srv.ServiceMapper(), oc.WithCache(store, time.Minute), oc.WithRequestOptions(auth))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	readBody(t, doPath(t, newClient(), http.MethodGet, "/test/x", oc.WithQuery("cc", "private")))
+	// a second process reuses the per-user entry
+	resp := doPath(t, newClient(), http.MethodGet, "/test/x", oc.WithQuery("cc", "private"))
+	if !resp.Meta.Cached {
+		t.Error("entry of the first store was not reused")
+	}
+	readBody(t, resp)
+	if n := hits.Load(); n != 1 {
+		t.Errorf("got %d hits, want 1", n)
+	}
+
+	if fi, err := os.Stat(dir); err != nil || fi.Mode().Perm() != 0o700 {
+		t.Errorf("cache directory: %v, %v", fi.Mode(), err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsalted := sha256.Sum256([]byte("Authorization: Basic YWxpY2U6YQ==\n"))
+	for _, f := range files {
+		fi, err := f.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0o600 {
+			t.Errorf("%s has mode %v, want 0600", f.Name(), fi.Mode().Perm())
+		}
+		if filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		b, err := fs.ReadFile(os.DirFS(dir), f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var e oc.CacheEntry
+		if err := json.Unmarshal(b, &e); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(e.Key, hex.EncodeToString(unsalted[:])) {
+			t.Errorf("key %q contains the unkeyed hash of the credentials", e.Key)
+		}
+	}
+}
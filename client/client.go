@@ -17,7 +17,12 @@ limitations under the License.
 package client
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"time"
 )
 
@@ -26,6 +31,8 @@ const (
 	UserAgent = "OpencastGoClient/" + Version
 )
 
+var BodyNotRewindableErr = errors.New("request body cannot be read again")
+
 type Doer interface {
 	Do(*Request) (*Response, error)
 }
@@ -38,6 +45,12 @@ type client struct {
 	sm      ServiceMapper
 	http    http.Client
 	reqOpts []RequestOpts
+	rec     *recorder
+	limits  map[string]*serviceLimiter
+	cb      *CircuitBreaker
+	cache   *responseCache
+	flights *flightGroup
+	hedger  *hedger
 }
 
 var _ Client = &client{}
@@ -69,11 +82,110 @@ func (c *client) Do(req *Request) (*Response, error) {
 		return nil, err
 	}
 
+	do := c.doAuthenticated
+	if c.hedger != nil {
+		do = func(req *Request) (*Response, error) { return c.hedger.do(c, req, c.doAuthenticated) }
+	}
+	if c.cache != nil {
+		hedged := do
+		do = func(req *Request) (*Response, error) { return c.cache.do(req, hedged) }
+	}
+	if c.flights != nil {
+		shared := do
+		do = func(req *Request) (*Response, error) { return c.flights.do(req, shared) }
+	}
+	resp, err := do(req)
+	if resp != nil {
+		resp.Meta.DecodeMode = req.DecodeMode
+		resp.decoders = req.Decoders
+	}
+	return resp, err
+}
+
+func (c *client) doAuthenticated(req *Request) (*Response, error) {
+	resp, err := c.do(req)
+	if err != nil || req.Authenticator == nil {
+		return resp, err
+	}
+
+	retry, err := req.Authenticator.Reauthenticate(&resp.Response)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if !retry {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if req.Body != nil && !Rewindable(req.Body) {
+		return nil, fmt.Errorf("%w: cannot retry %s %s after reauthentication", BodyNotRewindableErr, req.Method, req.Path)
+	}
+	return c.do(req)
+}
+
+func (c *client) do(req *Request) (*Response, error) {
+	l := c.limiterFor(req.Service)
+	if l != nil {
+		release, err := l.acquire(req.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	resp, err := c.roundTrip(req)
+	if l != nil {
+		l.observe(resp, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) roundTrip(req *Request) (*Response, error) {
 	httpReq, err := req.HTTPRequest(c.sm)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Authenticator != nil {
+		// keep credentials of previous attempts out of req.Header
+		httpReq.Header = httpReq.Header.Clone()
+		httpReq = httpReq.WithContext(context.WithValue(httpReq.Context(), senderCtxKey{}, clientSender{c: c, service: req.Service}))
+		if err := req.Authenticator.Authenticate(httpReq); err != nil {
+			_ = httpReq.Body.Close()
+			return nil, err
+		}
+	}
+
+	if c.cb == nil {
+		return c.send(req, httpReq)
+	}
+
+	host := hostKey(httpReq.URL)
+	done, err := c.cb.allow(host)
+	if err != nil {
+		_ = httpReq.Body.Close()
+		return nil, err
+	}
+	resp, err := c.send(req, httpReq)
+	done(resp, err)
+	return resp, err
+}
+
+func (c *client) send(req *Request, httpReq *http.Request) (*Response, error) {
+	if c.rec != nil {
+		httpResp, duration, err := c.rec.roundTrip(&c.http, req, httpReq)
+		if err != nil {
+			return nil, err
+		}
+		resp := newResponse(httpResp)
+		resp.Meta.Duration = duration
+		return resp, nil
+	}
+
 	reqStart := time.Now()
 	httpResp, err := c.http.Do(httpReq)
 	if err != nil {
@@ -86,6 +198,47 @@ func (c *client) Do(req *Request) (*Response, error) {
 	return resp, nil
 }
 
+// Sender sends HTTP requests through the HTTP client and the recorder of a
+// client without following redirects. Authenticators use it for requests of
+// their own, e.g. logins.
+type Sender interface {
+	Send(httpReq *http.Request) (*http.Response, error)
+}
+
+type senderCtxKey struct{}
+
+// SenderFromContext returns the sender of the client sending the request ctx
+// belongs to. It is available to [Authenticator] implementations.
+func SenderFromContext(ctx context.Context) (Sender, bool) {
+	s, ok := ctx.Value(senderCtxKey{}).(Sender)
+	return s, ok
+}
+
+type clientSender struct {
+	c       *client
+	service string
+}
+
+func (s clientSender) Send(httpReq *http.Request) (*http.Response, error) {
+	h := s.c.http
+	h.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	if s.c.rec == nil {
+		return h.Do(httpReq)
+	}
+
+	if httpReq.Body == nil {
+		httpReq.Body = http.NoBody
+	}
+	req := &Request{
+		Service: s.service,
+		Method:  httpReq.Method,
+		Path:    httpReq.URL.Path,
+		Query:   httpReq.URL.Query(),
+	}
+	httpResp, _, err := s.c.rec.roundTrip(&h, req, httpReq)
+	return httpResp, err
+}
+
 type ClientOpts interface {
 	Apply(*client) error
 }
@@ -101,6 +254,8 @@ func WithHTTPClient(h http.Client) ClientOpts {
 	})
 }
 
+// WithRequestOptions replaces the options applied to all requests, including
+// the default User-Agent header.
 func WithRequestOptions(opts ...RequestOpts) ClientOpts {
 	return ClientOptsFunc(func(c *client) error {
 		c.reqOpts = opts
@@ -108,4 +263,13 @@ func WithRequestOptions(opts ...RequestOpts) ClientOpts {
 	})
 }
 
+// WithAdditionalRequestOptions adds options applied to all requests after the
+// ones already configured.
+func WithAdditionalRequestOptions(opts ...RequestOpts) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		c.reqOpts = append(slices.Clip(c.reqOpts), opts...)
+		return nil
+	})
+}
+
 // TODO: add WithBackoff
@@ -0,0 +1,246 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var UnknownFieldsErr = errors.New("unknown fields")
+
+type DecodeMode int
+
+const (
+	// DefaultDecodeMode ignores unknown fields and fails on any error.
+	DefaultDecodeMode DecodeMode = iota
+	// StrictDecodeMode fails if the response contains fields unknown to the
+	// decoded type, e.g. for contract tests against new Opencast versions.
+	StrictDecodeMode
+	// LenientDecodeMode skips elements of lists that cannot be decoded
+	// instead of failing the whole list.
+	LenientDecodeMode
+)
+
+type DiagnosticKind string
+
+const (
+	UnknownFieldDiagnosticKind DiagnosticKind = "unknown-field"
+	DecodeErrorDiagnosticKind  DiagnosticKind = "decode-error"
+)
+
+// DecodeDiagnostic describes a problem found while decoding a response in
+// strict or lenient mode.
+type DecodeDiagnostic struct {
+	Kind DiagnosticKind
+	// Path is the JSON path of the problem, e.g. $[3].metadata.
+	Path string
+	Err  error
+}
+
+func (d DecodeDiagnostic) String() string {
+	if d.Err != nil {
+		return fmt.Sprintf("%s at %s: %v", d.Kind, d.Path, d.Err)
+	}
+	return fmt.Sprintf("%s at %s", d.Kind, d.Path)
+}
+
+// WithDecodeMode sets how JSON responses are decoded. Problems are reported in
+// ResponseMeta.Diagnostics.
+func WithDecodeMode(mode DecodeMode) RequestOpts {
+	return RequestOptsFunc(func(req *Request) error {
+		req.DecodeMode = mode
+		return nil
+	})
+}
+
+// diagnosticJsonDecoder decodes the response in strict or lenient mode.
+func diagnosticJsonDecoder(v any, resp *Response) error {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	lenient := resp.Meta.DecodeMode == LenientDecodeMode
+	if lenient && rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Slice &&
+		bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		resp.Meta.Diagnostics = append(resp.Meta.Diagnostics, decodeListLeniently(b, rv.Elem())...)
+	} else if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	return checkUnknownFields(b, rv.Type(), "$", resp)
+}
+
+// decodeStreamedElement decodes the element at path of a streamed JSON array
+// in strict or lenient mode. It reports false if the element was skipped.
+func decodeStreamedElement(b []byte, v any, path string, resp *Response) (bool, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		if resp.Meta.DecodeMode != LenientDecodeMode {
+			return false, err
+		}
+		resp.Meta.Diagnostics = append(resp.Meta.Diagnostics, DecodeDiagnostic{
+			Kind: DecodeErrorDiagnosticKind,
+			Path: path,
+			Err:  err,
+		})
+		return false, nil
+	}
+	return true, checkUnknownFields(b, reflect.TypeOf(v), path, resp)
+}
+
+// checkUnknownFields adds the unknown fields of the JSON value b at path to
+// the diagnostics of the response. In strict mode they are an error.
+func checkUnknownFields(b []byte, t reflect.Type, path string, resp *Response) error {
+	var raw any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	var unknown []string
+	findUnknownFields(raw, t, path, func(path string) {
+		unknown = append(unknown, path)
+		resp.Meta.Diagnostics = append(resp.Meta.Diagnostics, DecodeDiagnostic{
+			Kind: UnknownFieldDiagnosticKind,
+			Path: path,
+		})
+	})
+
+	if len(unknown) > 0 && resp.Meta.DecodeMode == StrictDecodeMode {
+		return fmt.Errorf("%w: %s", UnknownFieldsErr, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// decodeListLeniently decodes the elements of the JSON array b into the slice
+// s one by one, skipping elements that cannot be decoded.
+func decodeListLeniently(b []byte, s reflect.Value) []DecodeDiagnostic {
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		return []DecodeDiagnostic{{Kind: DecodeErrorDiagnosticKind, Path: "$", Err: err}}
+	}
+
+	var diags []DecodeDiagnostic
+	list := reflect.MakeSlice(s.Type(), 0, len(items))
+	for i, item := range items {
+		elem := reflect.New(s.Type().Elem())
+		if err := json.Unmarshal(item, elem.Interface()); err != nil {
+			diags = append(diags, DecodeDiagnostic{
+				Kind: DecodeErrorDiagnosticKind,
+				Path: "$[" + strconv.Itoa(i) + "]",
+				Err:  err,
+			})
+			continue
+		}
+		list = reflect.Append(list, elem.Elem())
+	}
+	s.Set(list)
+	return diags
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// findUnknownFields reports the paths of object keys in raw without a
+// matching field in t. Types with custom unmarshaling are not inspected.
+func findUnknownFields(raw any, t reflect.Type, path string, report func(path string)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			value := obj[key]
+			ft, ok := fields[strings.ToLower(key)]
+			if !ok {
+				report(path + "." + key)
+				continue
+			}
+			findUnknownFields(value, ft, path+"."+key, report)
+		}
+
+	case reflect.Slice, reflect.Array:
+		list, ok := raw.([]any)
+		if !ok {
+			return
+		}
+		for i, value := range list {
+			findUnknownFields(value, t.Elem(), path+"["+strconv.Itoa(i)+"]", report)
+		}
+
+	case reflect.Map:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			findUnknownFields(obj[key], t.Elem(), path+"."+key, report)
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of struct t by their lower-cased
+// JSON name, including fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// promoted fields are part of VisibleFields
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		key := strings.ToLower(name)
+		// fields of outer structs take precedence
+		if _, ok := fields[key]; !ok || len(f.Index) == 1 {
+			fields[key] = f.Type
+		}
+	}
+	return fields
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+type decodedItem struct {
+	A     int `json:"a"`
+	Inner struct {
+		B string `json:"b"`
+	} `json:"inner"`
+}
+
+func decodeItems(t *testing.T, body string, mode oc.DecodeMode) ([]decodedItem, *oc.Response, error) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := newListServer(t, &hits, body)
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestOptions(oc.WithDecodeMode(mode)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return oc.GenericAutoDecodedDo[[]decodedItem](c, func() (*oc.Request, error) {
+		return oc.NewRequest(t.Context(), http.MethodGet, testService, "/test/list", oc.NoBody)
+	})
+}
+
+func TestDefaultDecodeModeIgnoresUnknownFields(t *testing.T) {
+	items, resp, err := decodeItems(t, `[{"a":1,"extra":true}]`, oc.DefaultDecodeMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || len(resp.Meta.Diagnostics) != 0 {
+		t.Fatalf("got items %v and diagnostics %v", items, resp.Meta.Diagnostics)
+	}
+}
+
+func TestStrictDecodeModeReportsUnknownFields(t *testing.T) {
+	_, resp, err := decodeItems(t, `[{"a":1,"inner":{"b":"x","c":1}},{"a":2,"d":null}]`, oc.StrictDecodeMode)
+	if !errors.Is(err, oc.UnknownFieldsErr) {
+		t.Fatalf("got error %v, want %v", err, oc.UnknownFieldsErr)
+	}
+	var paths []string
+	for _, d := range resp.Meta.Diagnostics {
+		if d.Kind != oc.UnknownFieldDiagnosticKind {
+			t.Errorf("got diagnostic kind %s, want %s", d.Kind, oc.UnknownFieldDiagnosticKind)
+		}
+		paths = append(paths, d.Path)
+	}
+	if len(paths) != 2 || paths[0] != "$[0].inner.c" || paths[1] != "$[1].d" {
+		t.Fatalf("got unknown fields %v, want [$[0].inner.c $[1].d]", paths)
+	}
+}
+
+func TestLenientDecodeModeSkipsBrokenElements(t *testing.T) {
+	items, resp, err := decodeItems(t, `[{"a":1},{"a":"x"},{"a":3}]`, oc.LenientDecodeMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].A != 1 || items[1].A != 3 {
+		t.Fatalf("got items %v, want the valid ones", items)
+	}
+	if len(resp.Meta.Diagnostics) != 1 {
+		t.Fatalf("got diagnostics %v, want 1", resp.Meta.Diagnostics)
+	}
+	if d := resp.Meta.Diagnostics[0]; d.Kind != oc.DecodeErrorDiagnosticKind || d.Path != "$[1]" || d.Err == nil {
+		t.Errorf("got diagnostic %s, want decode error at $[1]", d)
+	}
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// Header name for requesting the authentication scheme. Opencast only
+	// challenges for Digest auth if it is set to Digest.
+	RequestedAuthHeader = "X-Requested-Auth"
+)
+
+var UnsupportedChallengeErr = errors.New("unsupported authentication challenge")
+
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+	cnonce    string
+	nc        uint32
+}
+
+type digestAuthenticator struct {
+	username string
+	password string
+
+	mtx        sync.Mutex
+	challenges map[string]*digestChallenge // by host, protected by mtx
+}
+
+var _ Authenticator = &digestAuthenticator{}
+
+// NewDigestAuthenticator returns an authenticator using HTTP Digest auth. The
+// nonce of the last challenge of a host is reused for further requests until
+// the server challenges again. Before sending the first request with a body to
+// a host, the challenge is requested with a HEAD request.
+func NewDigestAuthenticator(username, password string) Authenticator {
+	return &digestAuthenticator{
+		username:   username,
+		password:   password,
+		challenges: make(map[string]*digestChallenge),
+	}
+}
+
+// WithDigestAuth authenticates requests with HTTP Digest auth. See
+// [NewDigestAuthenticator].
+func WithDigestAuth(username, password string) RequestOpts {
+	return WithAuthenticator(NewDigestAuthenticator(username, password))
+}
+
+func (a *digestAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set(RequestedAuthHeader, "Digest")
+
+	a.mtx.Lock()
+	c, ok := a.challenges[req.URL.Host]
+	a.mtx.Unlock()
+	if !ok && req.Body != nil && req.Body != http.NoBody {
+		// avoid uploading the body just to receive the challenge, the body
+		// might not even be readable a second time
+		if err := a.probe(req); err != nil {
+			return err
+		}
+	}
+
+	a.mtx.Lock()
+	c, ok = a.challenges[req.URL.Host]
+	if !ok {
+		a.mtx.Unlock()
+		return nil
+	}
+	c.nc++
+	ch := *c
+	a.mtx.Unlock()
+
+	req.Header.Set("Authorization", a.authorization(&ch, req.Method, req.URL.RequestURI()))
+	return nil
+}
+
+// probe requests a challenge for the host of req with a HEAD request.
+func (a *digestAuthenticator) probe(req *http.Request) error {
+	probeReq, err := http.NewRequestWithContext(req.Context(), http.MethodHead, req.URL.String(), nil)
+	if err != nil {
+		return err
+	}
+	probeReq.Header = req.Header.Clone()
+	probeReq.Header.Del("Content-Type")
+
+	sender, ok := SenderFromContext(req.Context())
+	if !ok {
+		sender = defaultSender
+	}
+	resp, err := sender.Send(probeReq)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	_, err = a.Reauthenticate(resp)
+	return err
+}
+
+func (a *digestAuthenticator) Reauthenticate(resp *http.Response) (bool, error) {
+	if resp.StatusCode != http.StatusUnauthorized || resp.Request == nil {
+		return false, nil
+	}
+
+	var ch *digestChallenge
+	var parseErr error
+	for _, v := range resp.Header.Values("WWW-Authenticate") {
+		c, err := parseDigestChallenge(v)
+		if err != nil {
+			parseErr = err
+			continue
+		}
+		// prefer SHA-256 if offered
+		if c != nil && (ch == nil || !strings.HasPrefix(ch.algorithm, "SHA-256")) {
+			ch = c
+		}
+	}
+	if ch == nil {
+		return false, parseErr
+	}
+
+	a.mtx.Lock()
+	a.challenges[resp.Request.URL.Host] = ch
+	a.mtx.Unlock()
+	return true, nil
+}
+
+func (a *digestAuthenticator) authorization(ch *digestChallenge, method, uri string) string {
+	h := md5.New
+	if strings.HasPrefix(ch.algorithm, "SHA-256") {
+		h = sha256.New
+	}
+
+	ha1 := digestHash(h, a.username+":"+ch.realm+":"+a.password)
+	if strings.HasSuffix(ch.algorithm, "-sess") {
+		ha1 = digestHash(h, ha1+":"+ch.nonce+":"+ch.cnonce)
+	}
+	ha2 := digestHash(h, method+":"+uri)
+
+	nc := fmt.Sprintf("%08x", ch.nc)
+	var response string
+	if ch.qop == "" {
+		response = digestHash(h, ha1+":"+ch.nonce+":"+ha2)
+	} else {
+		response = digestHash(h, ha1+":"+ch.nonce+":"+nc+":"+ch.cnonce+":"+ch.qop+":"+ha2)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`,
+		quoteAuthParam(a.username), quoteAuthParam(ch.realm), quoteAuthParam(ch.nonce), quoteAuthParam(uri), response)
+	if ch.algorithm != "" {
+		fmt.Fprintf(&sb, `, algorithm=%s`, ch.algorithm)
+	}
+	if ch.opaque != "" {
+		fmt.Fprintf(&sb, `, opaque="%s"`, quoteAuthParam(ch.opaque))
+	}
+	if ch.qop != "" {
+		fmt.Fprintf(&sb, `, qop=%s, nc=%s, cnonce="%s"`, ch.qop, nc, ch.cnonce)
+	}
+	return sb.String()
+}
+
+var authParamReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func quoteAuthParam(s string) string {
+	return authParamReplacer.Replace(s)
+}
+
+func digestHash(h func() hash.Hash, s string) string {
+	hh := h()
+	_, _ = hh.Write([]byte(s))
+	return hex.EncodeToString(hh.Sum(nil))
+}
+
+// parseDigestChallenge parses a WWW-Authenticate header value. It returns nil
+// if the value is no Digest challenge.
+func parseDigestChallenge(v string) (*digestChallenge, error) {
+	scheme, params, _ := strings.Cut(strings.TrimSpace(v), " ")
+	if !strings.EqualFold(scheme, "Digest") {
+		return nil, nil
+	}
+
+	ch := &digestChallenge{}
+	for k, v := range parseAuthParams(params) {
+		switch strings.ToLower(k) {
+		case "realm":
+			ch.realm = v
+		case "nonce":
+			ch.nonce = v
+		case "opaque":
+			ch.opaque = v
+		case "algorithm":
+			ch.algorithm = strings.ToUpper(v)
+		case "qop":
+			for qop := range strings.SplitSeq(v, ",") {
+				if strings.TrimSpace(qop) == "auth" {
+					ch.qop = "auth"
+				}
+			}
+			if ch.qop == "" {
+				return nil, fmt.Errorf("%w: qop %s", UnsupportedChallengeErr, v)
+			}
+		}
+	}
+
+	switch ch.algorithm {
+	case "", "MD5", "MD5-SESS", "SHA-256", "SHA-256-SESS":
+		ch.algorithm = strings.Replace(ch.algorithm, "-SESS", "-sess", 1)
+	default:
+		return nil, fmt.Errorf("%w: algorithm %s", UnsupportedChallengeErr, ch.algorithm)
+	}
+	if ch.nonce == "" {
+		return nil, fmt.Errorf("%w: missing nonce", UnsupportedChallengeErr)
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	ch.cnonce = hex.EncodeToString(b[:])
+	return ch, nil
+}
+
+// parseAuthParams parses comma separated key=value pairs with optionally
+// quoted values.
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			return params
+		}
+		key = strings.TrimSpace(key)
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(rest, `"`) {
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				value.WriteByte(rest[i])
+			}
+			s = rest[min(i+1, len(rest)):]
+		} else {
+			v, r, _ := strings.Cut(rest, ",")
+			value.WriteString(strings.TrimSpace(v))
+			s = r
+		}
+		params[key] = value.String()
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newDigestClient(t *testing.T, srv *opencasttest.Server) extapiclientv1.Client {
+	t.Helper()
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestOptions(oc.WithDigestAuth(opencasttest.DefaultUsername, opencasttest.DefaultPassword)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return extapiclientv1.New(c)
+}
+
+func streamedEvent() *extapiclientv1.CreateEventRequestBody {
+	return &extapiclientv1.CreateEventRequestBody{
+		Metadata: []extapiv1.Catalog{{
+			Flavor: base.DublinCoreEpisodeFlavor,
+			Fields: []extapiv1.Field{{
+				ID:    extapiv1.TitleFieldID,
+				Value: extapiv1.TextFieldValue("Streamed"),
+			}},
+		}},
+		PresenterStreamFilename: "presenter.mp4",
+		PresenterStream:         io.NopCloser(strings.NewReader("video")),
+	}
+}
+
+func TestDigestAuthStreamedBody(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	var uploads atomic.Int32
+	srv.OnRequest(func(r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/events" {
+			uploads.Add(1)
+		}
+	})
+
+	api := newDigestClient(t, srv)
+	if _, _, err := api.CreateEvent(context.Background(), streamedEvent()); err != nil {
+		t.Fatal(err)
+	}
+	if n := uploads.Load(); n != 1 {
+		t.Fatalf("got %d uploads, want 1", n)
+	}
+}
+
+func TestDigestAuthStaleNonce(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+	api := newDigestClient(t, srv)
+	ctx := context.Background()
+
+	if _, _, err := api.GetAPIVersion(ctx); err != nil {
+		t.Fatal(err)
+	}
+	srv.ExpireNonces()
+	if _, _, err := api.GetAPIVersion(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.ExpireNonces()
+	if _, _, err := api.CreateEvent(ctx, streamedEvent()); !errors.Is(err, oc.BodyNotRewindableErr) {
+		t.Fatalf("got error %v, want %v", err, oc.BodyNotRewindableErr)
+	}
+}
+
+func TestDigestAuthWrongPassword(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestOptions(oc.WithDigestAuth(opencasttest.DefaultUsername, "wrong")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, resp, err := extapiclientv1.New(c).GetAPIVersion(context.Background())
+	if err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want status %d", err, http.StatusUnauthorized)
+	}
+}
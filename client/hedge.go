@@ -0,0 +1,248 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+type HedgingConfig struct {
+	// Services are the service types to hedge requests for. All service types
+	// are hedged if empty.
+	Services []string
+	// Percentile of the recent response times of a service after which the
+	// hedged request is sent. Defaults to 0.95.
+	Percentile float64
+	// InitialDelay is used until enough response times were observed.
+	// Defaults to 100ms.
+	InitialDelay time.Duration
+	// MinDelay bounds the delay from below. Defaults to 10ms.
+	MinDelay time.Duration
+}
+
+// WithHedging sends a second request to another host of the service if the
+// first one did not respond within a percentile of the recent response times.
+// The first successful response is used and the other request is canceled.
+// Only idempotent requests are hedged, see [Request.IsIdempotent], and only if
+// the service mapper implements [MultiHostServiceMapper]. Requests are not
+// hedged with a [StaticServiceMapper], which knows only a single host. The
+// response is streamed; its request is canceled once the body is closed.
+func WithHedging(cfg HedgingConfig) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		if cfg.Percentile <= 0 || cfg.Percentile >= 1 {
+			cfg.Percentile = 0.95
+		}
+		if cfg.InitialDelay <= 0 {
+			cfg.InitialDelay = 100 * time.Millisecond
+		}
+		if cfg.MinDelay <= 0 {
+			cfg.MinDelay = 10 * time.Millisecond
+		}
+		c.hedger = &hedger{
+			cfg:       cfg,
+			latencies: make(map[string]*latencyRing),
+		}
+		return nil
+	})
+}
+
+const (
+	hedgingSamples    = 100
+	minHedgingSamples = 10
+)
+
+type hedger struct {
+	cfg HedgingConfig
+
+	mtx       sync.Mutex
+	latencies map[string]*latencyRing // by service, protected by mtx
+}
+
+// latencyRing holds the most recent response times of a service.
+type latencyRing struct {
+	samples [hedgingSamples]time.Duration
+	n       int
+	next    int
+}
+
+func (w *latencyRing) add(d time.Duration) {
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % hedgingSamples
+	w.n = min(w.n+1, hedgingSamples)
+}
+
+func (w *latencyRing) percentile(p float64) time.Duration {
+	s := slices.Clone(w.samples[:w.n])
+	slices.Sort(s)
+	return s[int(p*float64(len(s)-1))]
+}
+
+func (h *hedger) delay(svc string) time.Duration {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	w, ok := h.latencies[svc]
+	if !ok || w.n < minHedgingSamples {
+		return h.cfg.InitialDelay
+	}
+	return max(h.cfg.MinDelay, w.percentile(h.cfg.Percentile))
+}
+
+func (h *hedger) observe(svc string, d time.Duration) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	w, ok := h.latencies[svc]
+	if !ok {
+		w = &latencyRing{}
+		h.latencies[svc] = w
+	}
+	w.add(d)
+}
+
+type hedgeResult struct {
+	i      int // index of the request
+	resp   *Response
+	err    error
+	cancel context.CancelFunc
+}
+
+func (r *hedgeResult) ok() bool {
+	return r.err == nil && r.resp.StatusCode < 500
+}
+
+// discard closes the response and cancels the request.
+func (r *hedgeResult) discard() {
+	if r.err == nil {
+		_ = r.resp.Body.Close()
+	}
+	r.cancel()
+}
+
+// response returns the response, whose request is canceled once the body is
+// closed.
+func (r *hedgeResult) response() (*Response, error) {
+	if r.err != nil {
+		r.cancel()
+		return nil, r.err
+	}
+	r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: r.cancel}
+	return r.resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func (h *hedger) do(c *client, req *Request, do func(*Request) (*Response, error)) (*Response, error) {
+	if !req.IsIdempotent() || req.Host != "" || req.Stream ||
+		(len(h.cfg.Services) > 0 && !slices.Contains(h.cfg.Services, req.Service)) {
+		return do(req)
+	}
+	msm, ok := c.sm.(MultiHostServiceMapper)
+	if !ok {
+		return do(req)
+	}
+	hosts, err := msm.GetHosts(req.Service)
+	if err != nil || len(hosts) < 2 {
+		return do(req)
+	}
+	perm := rand.Perm(len(hosts))
+
+	results := make(chan *hedgeResult, 2)
+	var cancels []context.CancelFunc
+	pending := 0
+	send := func(host string) {
+		ctx, cancel := context.WithCancel(req.Ctx)
+		i := len(cancels)
+		cancels = append(cancels, cancel)
+		pending++
+		r := req.clone(ctx)
+		r.Host = host
+		go func() {
+			start := time.Now()
+			resp, err := do(r)
+			if err == nil {
+				h.observe(req.Service, time.Since(start))
+			}
+			results <- &hedgeResult{i: i, resp: resp, err: err, cancel: cancel}
+		}()
+	}
+	// finish cancels the other request and discards its late result.
+	finish := func(res *hedgeResult) (*Response, error) {
+		for i, cancel := range cancels {
+			if i != res.i {
+				cancel()
+			}
+		}
+		go func(pending int) {
+			for range pending {
+				(<-results).discard()
+			}
+		}(pending)
+		return res.response()
+	}
+
+	send(hosts[perm[0]])
+	hedged := false
+	hedge := func() {
+		if !hedged {
+			hedged = true
+			send(hosts[perm[1]])
+		}
+	}
+
+	timer := time.NewTimer(h.delay(req.Service))
+	defer timer.Stop()
+
+	var failed *hedgeResult
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			hedge()
+		case res := <-results:
+			pending--
+			if res.ok() {
+				if failed != nil {
+					failed.discard()
+				}
+				return finish(res)
+			}
+			if failed == nil || failed.err != nil {
+				if failed != nil {
+					failed.discard()
+				}
+				failed = res
+			} else {
+				res.discard()
+			}
+			// the first request failed, try the other host right away
+			hedge()
+		}
+	}
+	return finish(failed)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+// newHedgingClient returns a client hedging requests across the given
+// servers, whose service registry is served by the first one.
+func newHedgingClient(t *testing.T, cfg oc.HedgingConfig, srvs ...*opencasttest.Server) oc.Client {
+	t.Helper()
+	var hosts []string
+	for _, srv := range srvs {
+		hosts = append(hosts, srv.URL)
+	}
+	srvs[0].RegisterService(testService, hosts...)
+	registry, err := srvs[0].Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := oc.New(oc.NewDynamicServiceMapper(registry, time.Minute), oc.WithHedging(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func newHedgingServer(t *testing.T, h http.HandlerFunc) *opencasttest.Server {
+	t.Helper()
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test/", h)
+	return srv
+}
+
+func TestHedgingStreamsWinnerAndCancelsLoser(t *testing.T) {
+	canceled := make(chan struct{}, 1)
+	slow := newHedgingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			canceled <- struct{}{}
+		case <-time.After(5 * time.Second):
+		}
+	})
+	release := make(chan struct{})
+	fast := newHedgingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// answer after the hedge delay, so that both hosts are asked
+		time.Sleep(30 * time.Millisecond)
+		_, _ = io.WriteString(w, "head")
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, "tail")
+	})
+	c := newHedgingClient(t, oc.HedgingConfig{InitialDelay: 10 * time.Millisecond}, slow, fast)
+
+	// the response is returned before the body is complete
+	resp := doPath(t, c, http.MethodGet, "/test/a")
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("request to the slow host was not canceled")
+	}
+	close(release)
+	if body := readBody(t, resp); body != "headtail" {
+		t.Errorf("got body %q, want %q", body, "headtail")
+	}
+}
+
+func TestHedgingFailsOverToOtherHost(t *testing.T) {
+	failing := newHedgingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	healthy := newHedgingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	})
+	c := newHedgingClient(t, oc.HedgingConfig{InitialDelay: time.Minute}, failing, healthy)
+
+	for range 5 {
+		resp := doPath(t, c, http.MethodGet, "/test/a")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+		if body := readBody(t, resp); body != "ok" {
+			t.Fatalf("got body %q, want %q", body, "ok")
+		}
+	}
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AllServices configures limits for all service types without own limits.
+const AllServices = ""
+
+// WithRateLimit limits the requests to the service type to rps requests per
+// second with bursts of up to burst requests. A rate of zero or less disables
+// rate limiting, e.g. to exclude a service type from the rate limit of
+// AllServices. Waiting respects the context of the request.
+func WithRateLimit(svc string, rps float64, burst int) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		l := c.limiter(svc)
+		l.rate, l.adaptive, l.rateSet = nil, nil, true
+		if rps > 0 {
+			l.rate = newRateLimiter(rps, burst)
+		}
+		return nil
+	})
+}
+
+// WithMaxInFlight limits the concurrent requests to the service type. A
+// request is in flight until its response headers are received. A limit of
+// zero or less disables the limit, e.g. to exclude a service type from the
+// limit of AllServices.
+func WithMaxInFlight(svc string, n int) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		l := c.limiter(svc)
+		l.inFlight, l.inFlightSet = nil, true
+		if n > 0 {
+			l.inFlight = make(chan struct{}, n)
+		}
+		return nil
+	})
+}
+
+type AdaptiveRateLimitConfig struct {
+	// MinRate and MaxRate bound the requests per second.
+	MinRate float64
+	MaxRate float64
+	Burst   int
+
+	// IncreaseStep is added to the rate after each response not indicating
+	// pressure. Defaults to 1% of MaxRate.
+	IncreaseStep float64
+	// DecreaseFactor is multiplied with the rate when pressure is detected.
+	// Defaults to 0.5.
+	DecreaseFactor float64
+	// LatencyFactor is how many times slower than the average response a
+	// response must be to indicate pressure. Defaults to 3.
+	LatencyFactor float64
+}
+
+// WithAdaptiveRateLimit limits the requests to the service type like
+// WithRateLimit, but adapts the rate between cfg.MinRate and cfg.MaxRate. The
+// rate is decreased multiplicatively on 429 and 503 responses, or if responses
+// get considerably slower, or requests fail without response, and increased
+// additively otherwise. Retry-After headers pause all requests to the service
+// type.
+func WithAdaptiveRateLimit(svc string, cfg AdaptiveRateLimitConfig) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		if cfg.MaxRate <= 0 {
+			cfg.MaxRate = 1
+		}
+		if cfg.MinRate <= 0 || cfg.MinRate > cfg.MaxRate {
+			cfg.MinRate = min(1, cfg.MaxRate)
+		}
+		if cfg.IncreaseStep <= 0 {
+			cfg.IncreaseStep = cfg.MaxRate / 100
+		}
+		if cfg.DecreaseFactor <= 0 || cfg.DecreaseFactor >= 1 {
+			cfg.DecreaseFactor = 0.5
+		}
+		if cfg.LatencyFactor <= 1 {
+			cfg.LatencyFactor = 3
+		}
+
+		l := c.limiter(svc)
+		l.rate = newRateLimiter(cfg.MaxRate, cfg.Burst)
+		l.adaptive, l.rateSet = &cfg, true
+		return nil
+	})
+}
+
+func (c *client) limiter(svc string) *serviceLimiter {
+	if c.limits == nil {
+		c.limits = make(map[string]*serviceLimiter)
+	}
+	l, ok := c.limits[svc]
+	if !ok {
+		l = &serviceLimiter{}
+		c.limits[svc] = l
+	}
+	return l
+}
+
+// limiterFor returns the limits of the service type or nil. Limits not
+// configured for the service type are taken from AllServices.
+func (c *client) limiterFor(svc string) *serviceLimiter {
+	l, ok := c.limits[svc]
+	all := c.limits[AllServices]
+	switch {
+	case !ok:
+		return all
+	case all == nil || svc == AllServices:
+		return l
+	}
+
+	resolved := *l
+	if !l.rateSet {
+		resolved.rate, resolved.adaptive = all.rate, all.adaptive
+	}
+	if !l.inFlightSet {
+		resolved.inFlight = all.inFlight
+	}
+	return &resolved
+}
+
+type serviceLimiter struct {
+	rate     *rateLimiter
+	inFlight chan struct{}
+	adaptive *AdaptiveRateLimitConfig
+
+	// rateSet and inFlightSet report whether the limit was configured for
+	// the service type. Otherwise the one of AllServices applies.
+	rateSet     bool
+	inFlightSet bool
+}
+
+// acquire waits until the request may be sent. The returned function must be
+// called once the response was received.
+func (l *serviceLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.rate != nil {
+		if err := l.rate.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// observe adapts the rate to the response or the transport error of a request
+// failed without response. Canceled requests and errors raised before sending
+// the request, e.g. by an open circuit, are ignored.
+func (l *serviceLimiter) observe(resp *Response, err error) {
+	if l.adaptive == nil || l.rate == nil {
+		return
+	}
+	var urlErr *url.Error
+	if err != nil && (!errors.As(err, &urlErr) || errors.Is(err, context.Canceled)) {
+		return
+	}
+	cfg := l.adaptive
+	r := l.rate
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var duration time.Duration
+	pressure := err != nil
+	if resp != nil {
+		duration = resp.Meta.Duration
+		pressure = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+		if !pressure && r.samples >= minLatencySamples &&
+			float64(duration) > cfg.LatencyFactor*r.avgDuration {
+			pressure = true
+		}
+	}
+
+	now := time.Now()
+	if pressure {
+		if resp != nil {
+			if d, ok := retryAfter(resp, now); ok {
+				r.pausedUntil = now.Add(d)
+			}
+		}
+		// decrease once per round trip instead of once per concurrent response
+		if now.Sub(r.lastDecrease) > max(duration, time.Duration(float64(time.Second)/r.rps)) {
+			r.setRate(max(cfg.MinRate, r.rps*cfg.DecreaseFactor), now)
+			r.lastDecrease = now
+		}
+		return
+	}
+
+	r.samples++
+	r.avgDuration += (float64(duration) - r.avgDuration) / float64(min(r.samples, latencyWindow))
+	r.setRate(min(cfg.MaxRate, r.rps+cfg.IncreaseStep), now)
+}
+
+const (
+	minLatencySamples = 10
+	latencyWindow     = 50
+)
+
+func retryAfter(resp *Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t.Sub(now), t.After(now)
+	}
+	return 0, false
+}
+
+// rateLimiter is a token bucket. Waiting requests reserve tokens in advance,
+// so they are served in order.
+type rateLimiter struct {
+	mtx         sync.Mutex
+	rps         float64   // protected by mtx
+	burst       float64   // protected by mtx
+	tokens      float64   // protected by mtx
+	last        time.Time // protected by mtx
+	pausedUntil time.Time // protected by mtx
+
+	// adaptive mode, protected by mtx
+	samples      int
+	avgDuration  float64
+	lastDecrease time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	b := float64(max(burst, 1))
+	return &rateLimiter{
+		rps:    rps,
+		burst:  b,
+		tokens: b,
+		last:   time.Now(),
+	}
+}
+
+func (r *rateLimiter) wait(ctx context.Context) error {
+	r.mtx.Lock()
+	now := time.Now()
+	r.refill(now)
+	r.tokens--
+	var delay time.Duration
+	if r.tokens < 0 {
+		delay = time.Duration(-r.tokens / r.rps * float64(time.Second))
+	}
+	if r.pausedUntil.After(now) {
+		delay = max(delay, r.pausedUntil.Sub(now))
+	}
+	r.mtx.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		// return the reserved token
+		r.mtx.Lock()
+		r.tokens = min(r.burst, r.tokens+1)
+		r.mtx.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens accumulated since the last refill. mtx must be held.
+func (r *rateLimiter) refill(now time.Time) {
+	r.tokens = min(r.burst, r.tokens+now.Sub(r.last).Seconds()*r.rps)
+	r.last = now
+}
+
+// setRate changes the rate. mtx must be held.
+func (r *rateLimiter) setRate(rps float64, now time.Time) {
+	r.refill(now)
+	r.rps = rps
+}
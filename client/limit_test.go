@@ -0,0 +1,133 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+const testService = "org.opencastproject.test"
+
+func doTest(t *testing.T, c oc.Client, ctx context.Context) error {
+	t.Helper()
+	req, err := oc.NewRequest(ctx, http.MethodGet, testService, "/test", oc.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestMaxInFlightOfAllServicesAppliesToRateExemptService(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	var inFlight, maxInFlight atomic.Int32
+	srv.HandleFunc("GET /test", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+	})
+
+	c, err := srv.Client(
+		oc.WithMaxInFlight(oc.AllServices, 1),
+		oc.WithRateLimit(oc.AllServices, 1000, 10),
+		oc.WithRateLimit(testService, 0, 0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			if err := doTest(t, c, context.Background()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+	if n := maxInFlight.Load(); n != 1 {
+		t.Fatalf("got %d requests in flight, want 1", n)
+	}
+}
+
+func TestRateLimitRespectsContext(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("GET /test", func(http.ResponseWriter, *http.Request) {})
+
+	c, err := srv.Client(oc.WithRateLimit(testService, 0.1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doTest(t, c, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := doTest(t, c, ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestAdaptiveRateLimitObservesTransportErrors(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("GET /test", func(http.ResponseWriter, *http.Request) {})
+
+	c, err := srv.Client(oc.WithAdaptiveRateLimit(testService, oc.AdaptiveRateLimitConfig{
+		MinRate:        4,
+		MaxRate:        1000,
+		Burst:          1,
+		IncreaseStep:   0.001,
+		DecreaseFactor: 0.001,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.InjectFault(opencasttest.Fault{Path: "/test", Drop: true, Times: 1})
+	if err := doTest(t, c, context.Background()); err == nil {
+		t.Fatal("got no error for dropped connection")
+	}
+
+	start := time.Now()
+	for range 2 {
+		if err := doTest(t, c, context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 150*time.Millisecond {
+		t.Fatalf("requests took %s, rate was not decreased", d)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const OIDCDiscoveryPath = "/.well-known/openid-configuration"
+
+var TokenRequestFailedErr = errors.New("token request failed")
+
+// ClientCredentialsConfig describes an OAuth 2.0 client using the client
+// credentials grant, e.g. a confidential client of an OIDC provider.
+type ClientCredentialsConfig struct {
+	// Issuer is used to discover the token endpoint if TokenURL is empty.
+	Issuer   string
+	TokenURL string
+
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// EndpointParams are added to the token request.
+	EndpointParams url.Values
+
+	// HTTPClient is used for requests to the provider. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type clientCredentialsTokenSource struct {
+	ctx context.Context
+	cfg *ClientCredentialsConfig
+
+	mtx      sync.Mutex
+	tokenURL string // protected by mtx
+}
+
+// TokenSource returns a token source requesting new tokens from the provider
+// once the previous one expired. Requests authenticated with the token source
+// use their own context for requests to the provider, ctx is only used if
+// Token is called directly.
+func (cfg *ClientCredentialsConfig) TokenSource(ctx context.Context) TokenSource {
+	return ReuseTokenSource(&clientCredentialsTokenSource{
+		ctx:      ctx,
+		cfg:      cfg,
+		tokenURL: cfg.TokenURL,
+	})
+}
+
+func (cfg *ClientCredentialsConfig) httpClient() *http.Client {
+	if cfg.HTTPClient != nil {
+		return cfg.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+var _ ContextTokenSource = &clientCredentialsTokenSource{}
+
+func (s *clientCredentialsTokenSource) Token() (*Token, error) {
+	return s.TokenContext(s.ctx)
+}
+
+func (s *clientCredentialsTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	tokenURL, err := s.discoverTokenURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	for k, v := range s.cfg.EndpointParams {
+		form[k] = v
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.cfg.ClientID), url.QueryEscape(s.cfg.ClientSecret))
+
+	resp, err := s.cfg.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenResp struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil && resp.StatusCode < 300 {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || 300 <= resp.StatusCode || tokenResp.Error != "" {
+		msg := tokenResp.Error
+		if tokenResp.ErrorDescription != "" {
+			msg += ": " + tokenResp.ErrorDescription
+		}
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		return nil, fmt.Errorf("%w: %s", TokenRequestFailedErr, msg)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("%w: response contains no access token", TokenRequestFailedErr)
+	}
+
+	t := &Token{AccessToken: tokenResp.AccessToken}
+	if tokenResp.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	return t, nil
+}
+
+func (s *clientCredentialsTokenSource) discoverTokenURL(ctx context.Context) (string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.tokenURL != "" {
+		return s.tokenURL, nil
+	}
+	if s.cfg.Issuer == "" {
+		return "", fmt.Errorf("%w: neither token URL nor issuer configured", TokenRequestFailedErr)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(s.cfg.Issuer, "/")+OIDCDiscoveryPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.cfg.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: discovery failed: %s", TokenRequestFailedErr, resp.Status)
+	}
+
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", err
+	}
+	if discovery.TokenEndpoint == "" {
+		return "", fmt.Errorf("%w: discovery document contains no token endpoint", TokenRequestFailedErr)
+	}
+	s.tokenURL = discovery.TokenEndpoint
+	return s.tokenURL, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newTokenClient(t *testing.T, srv *opencasttest.Server, ts oc.TokenSource) extapiclientv1.Client {
+	t.Helper()
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestOptions(oc.WithJWTHeaderTokenSource("Authorization", "Bearer ", ts)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return extapiclientv1.New(c)
+}
+
+func TestClientCredentialsTokenSourceUsesRequestContext(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithOIDCClient("client", "secret"))
+	defer srv.Close()
+
+	cfg := &oc.ClientCredentialsConfig{Issuer: srv.URL, ClientID: "client", ClientSecret: "secret"}
+	tsCtx, cancel := context.WithCancel(context.Background())
+	ts := cfg.TokenSource(tsCtx)
+	cancel()
+
+	api := newTokenClient(t, srv, ts)
+
+	reqCtx, reqCancel := context.WithCancel(context.Background())
+	reqCancel()
+	if _, _, err := api.GetAPIVersion(reqCtx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	// neither the canceled constructor context nor the canceled request
+	// affect later requests
+	if _, _, err := api.GetAPIVersion(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientCredentialsTokenSourceReusesTokens(t *testing.T) {
+	srv := opencasttest.NewServer(
+		opencasttest.WithOIDCClient("client", "secret"),
+		opencasttest.WithTokenTTL(time.Hour),
+	)
+	defer srv.Close()
+
+	var tokenRequests atomic.Int32
+	srv.OnRequest(func(r *http.Request) {
+		if r.URL.Path == opencasttest.TokenPath {
+			tokenRequests.Add(1)
+		}
+	})
+
+	cfg := &oc.ClientCredentialsConfig{Issuer: srv.URL, ClientID: "client", ClientSecret: "secret"}
+	api := newTokenClient(t, srv, cfg.TokenSource(context.Background()))
+
+	ctx := context.Background()
+	for range 3 {
+		if _, _, err := api.GetAPIVersion(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := tokenRequests.Load(); n != 1 {
+		t.Fatalf("got %d token requests, want 1", n)
+	}
+}
+
+func TestClientCredentialsTokenSourceWrongSecret(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithOIDCClient("client", "secret"))
+	defer srv.Close()
+
+	cfg := &oc.ClientCredentialsConfig{TokenURL: srv.URL + opencasttest.TokenPath, ClientID: "client", ClientSecret: "wrong"}
+	api := newTokenClient(t, srv, cfg.TokenSource(context.Background()))
+	if _, _, err := api.GetAPIVersion(context.Background()); !errors.Is(err, oc.TokenRequestFailedErr) {
+		t.Fatalf("got error %v, want %v", err, oc.TokenRequestFailedErr)
+	}
+}
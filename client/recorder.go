@@ -0,0 +1,389 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+var InteractionNotFoundErr = errors.New("recorder: no recorded interaction matches the request")
+
+type RecorderMode int
+
+const (
+	// RecordRecorderMode sends all requests and records them into a new
+	// cassette, replacing an existing one.
+	RecordRecorderMode RecorderMode = iota
+	// ReplayRecorderMode replays recorded interactions only and never sends
+	// requests.
+	ReplayRecorderMode
+	// ReplayOrRecordRecorderMode replays recorded interactions and records
+	// requests that have not been recorded yet.
+	ReplayOrRecordRecorderMode
+)
+
+const scrubbed = "[SCRUBBED]"
+
+var (
+	scrubbedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	scrubbedQuery   = []string{"jwt"}
+	// form fields of session logins and token requests left out of the body
+	// hash
+	scrubbedFormFields = []string{sessionPasswordKey, "password", "client_secret", "client_assertion", "assertion", "refresh_token"}
+
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]*`)
+)
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Key      string           `json:"key"`
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Service  string      `json:"service"`
+	Method   string      `json:"method"`
+	Path     string      `json:"path"`
+	Query    url.Values  `json:"query,omitempty"`
+	Header   http.Header `json:"header,omitempty"`
+	BodyHash string      `json:"bodyHash,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int           `json:"statusCode"`
+	Header     http.Header   `json:"header,omitempty"`
+	Body       string        `json:"body,omitempty"`
+	BodyBase64 string        `json:"bodyBase64,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+}
+
+type recorder struct {
+	path string
+	mode RecorderMode
+
+	mtx      sync.Mutex
+	cassette Cassette         // protected by mtx
+	replays  map[string][]int // protected by mtx
+}
+
+// WithRecorder records the exchanged requests and responses into the cassette
+// file at path or replays them from there, depending on mode. Interactions
+// are matched by service type, method, path, query and a hash of the request
+// body. Multipart bodies are compared part by part, so the random boundary
+// does not matter. Requests of authenticators, e.g. session logins, are
+// recorded as well. Credentials are scrubbed before writing the cassette.
+//
+// Request bodies are buffered in memory while recording or replaying.
+func WithRecorder(path string, mode RecorderMode) ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		rec := &recorder{
+			path: path,
+			mode: mode,
+		}
+		if mode != RecordRecorderMode {
+			err := rec.load()
+			if err != nil && (mode == ReplayRecorderMode || !errors.Is(err, fs.ErrNotExist)) {
+				return err
+			}
+		}
+		rec.index()
+		c.rec = rec
+		return nil
+	})
+}
+
+func (rec *recorder) load() error {
+	b, err := os.ReadFile(rec.path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &rec.cassette)
+}
+
+func (rec *recorder) save() error {
+	// rec.mtx is assumed to be locked
+	b, err := json.MarshalIndent(rec.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(rec.path), 0o755); err != nil {
+		return err
+	}
+	tmp := rec.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, rec.path)
+}
+
+func (rec *recorder) index() {
+	rec.replays = make(map[string][]int)
+	for i, in := range rec.cassette.Interactions {
+		rec.replays[in.Key] = append(rec.replays[in.Key], i)
+	}
+}
+
+// replay returns the next recorded interaction for key. Interactions with the
+// same key are replayed in recording order, the last one is repeated once all
+// others were used.
+func (rec *recorder) replay(key string) (Interaction, bool) {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+
+	queue := rec.replays[key]
+	if len(queue) == 0 {
+		return Interaction{}, false
+	}
+	in := rec.cassette.Interactions[queue[0]]
+	if len(queue) > 1 {
+		rec.replays[key] = queue[1:]
+	}
+	return in, true
+}
+
+func (rec *recorder) record(in Interaction) error {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+
+	rec.cassette.Interactions = append(rec.cassette.Interactions, in)
+	return rec.save()
+}
+
+func (rec *recorder) roundTrip(h *http.Client, req *Request, httpReq *http.Request) (*http.Response, time.Duration, error) {
+	body, err := io.ReadAll(httpReq.Body)
+	_ = httpReq.Body.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bodyHash, err := hashBody(httpReq.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, 0, err
+	}
+	recReq := RecordedRequest{
+		Service:  req.Service,
+		Method:   req.Method,
+		Path:     req.Path,
+		Query:    scrubQuery(req.Query),
+		Header:   scrubHeader(httpReq.Header),
+		BodyHash: bodyHash,
+	}
+	key := recReq.key()
+
+	if rec.mode != RecordRecorderMode {
+		if in, ok := rec.replay(key); ok {
+			httpResp, err := in.Response.httpResponse(httpReq)
+			return httpResp, in.Response.Duration, err
+		}
+		if rec.mode == ReplayRecorderMode {
+			return nil, 0, fmt.Errorf("%w: %s %s %s", InteractionNotFoundErr, req.Service, req.Method, req.Path)
+		}
+	}
+
+	httpReq.Body = io.NopCloser(bytes.NewReader(body))
+	httpReq.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	httpReq.ContentLength = int64(len(body))
+
+	reqStart := time.Now()
+	httpResp, err := h.Do(httpReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	respBody, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+	duration := time.Since(reqStart)
+	httpResp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	recResp := RecordedResponse{
+		StatusCode: httpResp.StatusCode,
+		Header:     scrubHeader(httpResp.Header),
+		Duration:   duration,
+	}
+	if utf8.Valid(respBody) {
+		recResp.Body = jwtPattern.ReplaceAllString(string(respBody), scrubbed)
+	} else {
+		recResp.BodyBase64 = base64.StdEncoding.EncodeToString(respBody)
+	}
+
+	err = rec.record(Interaction{
+		Key:      key,
+		Request:  recReq,
+		Response: recResp,
+	})
+	return httpResp, duration, err
+}
+
+func (r RecordedRequest) key() string {
+	h := sha256.New()
+	for _, s := range []string{r.Service, r.Method, r.Path, r.Query.Encode(), r.BodyHash} {
+		_, _ = io.WriteString(h, s)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r RecordedResponse) httpResponse(httpReq *http.Request) (*http.Response, error) {
+	body := []byte(r.Body)
+	if r.BodyBase64 != "" {
+		var err error
+		body, err = base64.StdEncoding.DecodeString(r.BodyBase64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       httpReq,
+	}, nil
+}
+
+// hashBody hashes the request body. Multipart bodies are hashed part by part
+// to ignore the boundary. Credentials are removed from form bodies before
+// hashing, so the cassette does not depend on them.
+func hashBody(contentType string, body []byte) (string, error) {
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	h := sha256.New()
+	mt, params, err := mime.ParseMediaType(contentType)
+	if err == nil && mt == "application/x-www-form-urlencoded" {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", err
+		}
+		for _, k := range scrubbedFormFields {
+			form.Del(k)
+		}
+		_, _ = io.WriteString(h, scrubQuery(form).Encode())
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	if err != nil || !strings.HasPrefix(mt, "multipart/") {
+		_, _ = h.Write(body)
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		p, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		for _, k := range slices.Sorted(maps.Keys(p.Header)) {
+			for _, v := range p.Header[k] {
+				_, _ = fmt.Fprintf(h, "%s: %s\r\n", k, v)
+			}
+		}
+		_, _ = io.WriteString(h, "\r\n")
+		if _, err := io.Copy(h, p); err != nil {
+			return "", err
+		}
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func scrubHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for k, values := range header {
+		if strings.EqualFold(k, "Set-Cookie") {
+			header[k] = scrubSetCookies(values)
+			continue
+		}
+		if slices.ContainsFunc(scrubbedHeaders, func(s string) bool { return strings.EqualFold(s, k) }) {
+			header[k] = []string{scrubbed}
+			continue
+		}
+		for i := range values {
+			values[i] = jwtPattern.ReplaceAllString(values[i], scrubbed)
+		}
+	}
+	return header
+}
+
+// scrubSetCookies scrubs the cookie values but keeps their names and
+// attributes, so replayed logins still yield a session cookie.
+func scrubSetCookies(values []string) []string {
+	scrubbedValues := make([]string, len(values))
+	for i, v := range values {
+		c, err := http.ParseSetCookie(v)
+		if err != nil {
+			scrubbedValues[i] = scrubbed
+			continue
+		}
+		c.Value = scrubbed
+		scrubbedValues[i] = c.String()
+	}
+	return scrubbedValues
+}
+
+func scrubQuery(query url.Values) url.Values {
+	query = maps.Clone(query)
+	for _, k := range scrubbedQuery {
+		query.Del(k)
+	}
+	for k, values := range query {
+		values = slices.Clone(values)
+		for i := range values {
+			values[i] = jwtPattern.ReplaceAllString(values[i], scrubbed)
+		}
+		query[k] = values
+	}
+	return query
+}
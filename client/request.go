@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"encoding/base64"
+	"maps"
 	"net/http"
 	"net/url"
 	"path"
@@ -41,6 +42,22 @@ type Request struct {
 	Query   url.Values
 	Header  http.Header
 	Body    Body
+
+	Authenticator Authenticator
+	// Host overrides the host of the service mapper if set.
+	Host string
+	// Idempotent marks requests with methods other than GET, HEAD and OPTIONS
+	// as safe to send multiple times.
+	Idempotent bool
+	// DecodeMode is passed on to ResponseMeta.DecodeMode.
+	DecodeMode DecodeMode
+	// Decoders are used by AutoDecoder by media type, see WithDecoder.
+	Decoders map[string]DecoderReaderFunc
+	// Stream marks requests whose response body is read incrementally. They
+	// bypass the cache, request coalescing and hedging.
+	Stream bool
+
+	meta *ResponseMeta // set by WithResponseMeta
 }
 
 func NewRequest(ctx context.Context, method, service, path string, body Body, opts ...RequestOpts) (*Request, error) {
@@ -59,6 +76,25 @@ func NewRequest(ctx context.Context, method, service, path string, body Body, op
 	return req, nil
 }
 
+// IsIdempotent reports whether the request may be sent multiple times.
+func (req *Request) IsIdempotent() bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return req.Idempotent
+	}
+}
+
+// clone returns a copy of the request with the given context. Body is shared.
+func (req *Request) clone(ctx context.Context) *Request {
+	r := *req
+	r.Ctx = ctx
+	r.Query = maps.Clone(req.Query)
+	r.Header = req.Header.Clone()
+	return &r
+}
+
 func (req *Request) ApplyOptions(opts ...RequestOpts) error {
 	for _, opt := range opts {
 		if err := opt.Apply(req); err != nil {
@@ -69,9 +105,13 @@ func (req *Request) ApplyOptions(opts ...RequestOpts) error {
 }
 
 func (req *Request) URL(sm ServiceMapper) (*url.URL, error) {
-	hostURL, err := sm.GetHost(req.Service)
-	if err != nil {
-		return nil, err
+	hostURL := req.Host
+	if hostURL == "" {
+		var err error
+		hostURL, err = sm.GetHost(req.Service)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	url, err := url.Parse(hostURL)
@@ -165,6 +205,32 @@ func WithJWTQuery(token string) RequestOpts {
 	return WithQuery("jwt", token)
 }
 
+// WithIdempotent marks the request as safe to send multiple times, e.g. for
+// hedging POST requests without side effects.
+func WithIdempotent() RequestOpts {
+	return RequestOptsFunc(func(req *Request) error {
+		req.Idempotent = true
+		return nil
+	})
+}
+
+// WithStream marks the request as streamed, see [Request.Stream].
+func WithStream() RequestOpts {
+	return RequestOptsFunc(func(req *Request) error {
+		req.Stream = true
+		return nil
+	})
+}
+
+// WithResponseMeta stores the meta data of the response in meta once a
+// streamed response was read, see [GenericStreamedDo].
+func WithResponseMeta(meta *ResponseMeta) RequestOpts {
+	return RequestOptsFunc(func(req *Request) error {
+		req.meta = meta
+		return nil
+	})
+}
+
 func WithRunAsUser(username string) RequestOpts {
 	return WithHeader(RunAsUserHeader, username)
 }
@@ -172,3 +238,34 @@ func WithRunAsUser(username string) RequestOpts {
 func WithRunWithRoles(roles ...string) RequestOpts {
 	return WithHeader(RunWithRolesHeader, strings.Join(roles, ","))
 }
+
+// identityHeaders are the request headers selecting the user a request is
+// processed as.
+var identityHeaders = []string{"Authorization", "Cookie", RunAsUserHeader, RunWithRolesHeader}
+
+// WithIdentityOf sends the request as the same user to the same host as req,
+// i.e. copies its authenticator, host override, credential and run-as headers
+// and JWT query parameter. It is used for lookups done on behalf of a
+// request built from the caller's options.
+func WithIdentityOf(req *Request) RequestOpts {
+	return RequestOptsFunc(func(r *Request) error {
+		if req.Authenticator != nil {
+			r.Authenticator = req.Authenticator
+		}
+		if req.Host != "" {
+			r.Host = req.Host
+		}
+		for _, k := range identityHeaders {
+			if v := req.Header.Values(k); len(v) > 0 {
+				r.Header.Del(k)
+				for _, v := range v {
+					r.Header.Add(k, v)
+				}
+			}
+		}
+		if v := req.Query.Get("jwt"); v != "" {
+			r.Query.Set("jwt", v)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+func TestWithIdentityOf(t *testing.T) {
+	ctx := context.Background()
+	auth := oc.NewSessionAuthenticator("admin", "opencast")
+	update, err := oc.NewRequest(ctx, http.MethodPut, testService, "/update", oc.NoBody,
+		oc.WithAuthenticator(auth),
+		oc.WithBasicAuth("admin", "opencast"),
+		oc.WithRunAsUser("jdoe"),
+		oc.WithRunWithRoles("ROLE_A", "ROLE_B"),
+		oc.WithJWTQuery("token"),
+		oc.WithQuery("marker", "update"),
+		oc.WithHeader("If-Match", "etag"),
+		oc.RequestOptsFunc(func(r *oc.Request) error { r.Host = "https://tenant.example.org"; return nil }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookup, err := oc.NewRequest(ctx, http.MethodGet, testService, "/lookup", oc.NoBody, oc.WithIdentityOf(update))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lookup.Authenticator != auth || lookup.Host != update.Host {
+		t.Errorf("got authenticator %v and host %q, want those of the update", lookup.Authenticator, lookup.Host)
+	}
+	for _, k := range []string{"Authorization", oc.RunAsUserHeader, oc.RunWithRolesHeader} {
+		if got, want := lookup.Header.Get(k), update.Header.Get(k); got != want {
+			t.Errorf("got %s %q, want %q", k, got, want)
+		}
+	}
+	if got := lookup.Query.Get("jwt"); got != "token" {
+		t.Errorf("got jwt %q, want token", got)
+	}
+	if lookup.Query.Has("marker") || lookup.Header.Get("If-Match") != "" {
+		t.Errorf("options unrelated to the identity were copied: %v %v", lookup.Query, lookup.Header)
+	}
+}
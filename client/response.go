@@ -20,8 +20,10 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"maps"
 	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -30,10 +32,23 @@ type Response struct {
 	http.Response
 
 	Meta ResponseMeta
+
+	decoders map[string]DecoderReaderFunc // of the request
 }
 
 type ResponseMeta struct {
 	Duration time.Duration
+	// Cached reports whether the response was served from the cache.
+	Cached bool
+	// Shared reports whether the response was shared by concurrent identical
+	// requests.
+	Shared bool
+
+	// DecodeMode is the decode mode of the request.
+	DecodeMode DecodeMode
+	// Diagnostics are the problems found while decoding the response in
+	// strict or lenient mode.
+	Diagnostics []DecodeDiagnostic
 }
 
 func newResponse(httpResp *http.Response) *Response {
@@ -47,6 +62,20 @@ func (resp *Response) Decode(v any, f DecoderReaderFunc) error {
 
 type DecoderReaderFunc func(any, *Response) error
 
+// WithDecoder sets the decoder used by AutoDecoder for responses of the media
+// type. It takes precedence over the built-in JSON and XML decoders. Pass it
+// to WithAdditionalRequestOptions to use it for all requests of a client.
+func WithDecoder(mediaType string, f DecoderReaderFunc) RequestOpts {
+	return RequestOptsFunc(func(req *Request) error {
+		req.Decoders = maps.Clone(req.Decoders)
+		if req.Decoders == nil {
+			req.Decoders = make(map[string]DecoderReaderFunc)
+		}
+		req.Decoders[strings.ToLower(mediaType)] = f
+		return nil
+	})
+}
+
 func AutoDecoder(v any, resp *Response) error {
 	ct := resp.Header.Get("Content-Type")
 	mt, _, err := mime.ParseMediaType(ct)
@@ -54,6 +83,10 @@ func AutoDecoder(v any, resp *Response) error {
 		return err
 	}
 
+	if f, ok := resp.decoders[mt]; ok {
+		return f(v, resp)
+	}
+
 	switch {
 	// JSON
 	case strings.HasPrefix(mt, "application/") && strings.HasSuffix(mt, "+json"):
@@ -72,7 +105,6 @@ func AutoDecoder(v any, resp *Response) error {
 	default:
 		// Other media types used in Opencast
 		//   application/octet-stream
-		//   text/calendar
 		//   text/html
 		//   text/plain
 		//   */* (serving files)
@@ -81,10 +113,59 @@ func AutoDecoder(v any, resp *Response) error {
 }
 
 func JsonDecoder(v any, resp *Response) error {
+	if resp.Meta.DecodeMode != DefaultDecodeMode {
+		return diagnosticJsonDecoder(v, resp)
+	}
 	dec := json.NewDecoder(resp.Body)
 	return dec.Decode(v)
 }
 
+// JsonStreamDecoder decodes the elements of a JSON array one by one and passes
+// them to yield until it returns false. Only one element is held in memory at
+// a time. A null value is treated as an empty array. In strict and lenient
+// mode, problems are added to ResponseMeta.Diagnostics and lenient mode skips
+// elements that cannot be decoded.
+func JsonStreamDecoder[T any](resp *Response, yield func(T) bool) error {
+	dec := json.NewDecoder(resp.Body)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("JsonStreamDecoder: expected array, got %v", tok)
+	}
+
+	for i := 0; dec.More(); i++ {
+		var v T
+		if resp.Meta.DecodeMode == DefaultDecodeMode {
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+		} else {
+			var b json.RawMessage
+			if err := dec.Decode(&b); err != nil {
+				return err
+			}
+			ok, err := decodeStreamedElement(b, &v, "$["+strconv.Itoa(i)+"]", resp)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		if !yield(v) {
+			return nil
+		}
+	}
+
+	_, err = dec.Token() // ]
+	return err
+}
+
 func XMLDecoder(v any, resp *Response) error {
 	dec := xml.NewDecoder(resp.Body)
 	return dec.Decode(v)
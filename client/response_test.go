@@ -0,0 +1,99 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+const csvMediaType = "text/csv"
+
+func csvDecoder(v any, resp *oc.Response) error {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	*v.(*[]string) = strings.Split(strings.TrimSpace(string(b)), ",")
+	return nil
+}
+
+func newCSVServer(t *testing.T) *opencasttest.Server {
+	t.Helper()
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", csvMediaType+"; charset=utf-8")
+		_, _ = io.WriteString(w, "a,b,c\n")
+	})
+	return srv
+}
+
+func decodeCSV(c oc.Client, opts ...oc.RequestOpts) ([]string, error) {
+	v, _, err := oc.GenericAutoDecodedDo[[]string](c, func() (*oc.Request, error) {
+		return oc.NewRequest(context.Background(), http.MethodGet, testService, "/test/a", oc.NoBody, opts...)
+	})
+	return v, err
+}
+
+func TestWithDecoderPerRequest(t *testing.T) {
+	srv := newCSVServer(t)
+	c, err := oc.New(srv.ServiceMapper())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := decodeCSV(c); err == nil {
+		t.Fatal("decoded without decoder")
+	}
+	v, err := decodeCSV(c, oc.WithDecoder("TEXT/CSV", csvDecoder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 3 || v[2] != "c" {
+		t.Fatalf("got %v, want [a b c]", v)
+	}
+	// the decoder is not registered globally
+	if _, err := decodeCSV(c); err == nil {
+		t.Fatal("decoder of a previous request was used")
+	}
+}
+
+func TestWithDecoderPerClient(t *testing.T) {
+	srv := newCSVServer(t)
+	c, err := oc.New(srv.ServiceMapper(), oc.WithAdditionalRequestOptions(oc.WithDecoder(csvMediaType, csvDecoder)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := oc.New(srv.ServiceMapper())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := decodeCSV(c); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decodeCSV(other); err == nil {
+		t.Fatal("decoder of another client was used")
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WithRequestCoalescing sends concurrent identical GET requests only once.
+// Requests are identical if service, path, query and the headers identifying
+// the user and the requested representation match. Each caller gets its own
+// copy of the response.
+func WithRequestCoalescing() ClientOpts {
+	return ClientOptsFunc(func(c *client) error {
+		c.flights = &flightGroup{calls: make(map[string]*flightCall)}
+		return nil
+	})
+}
+
+// flightIdentityHeaders are the request headers distinguishing otherwise
+// identical requests.
+var flightIdentityHeaders = []string{
+	"Authorization", "Cookie", "Accept", "Accept-Language",
+	RunAsUserHeader, RunWithRolesHeader,
+	"If-None-Match", "If-Modified-Since",
+}
+
+type flightGroup struct {
+	mtx   sync.Mutex
+	calls map[string]*flightCall // protected by mtx
+}
+
+type flightCall struct {
+	done   chan struct{}
+	shared bool // protected by flightGroup.mtx
+
+	// set before done is closed
+	resp *Response
+	body []byte
+	err  error
+}
+
+func (g *flightGroup) key(req *Request) string {
+	var sb strings.Builder
+	sb.WriteString(req.Service)
+	sb.WriteByte(' ')
+	sb.WriteString(req.Path)
+	sb.WriteByte('?')
+	sb.WriteString(req.Query.Encode())
+	for _, h := range flightIdentityHeaders {
+		for _, v := range req.Header.Values(h) {
+			sb.WriteString("\n" + h + ": " + v)
+		}
+	}
+	if req.Authenticator != nil {
+		fmt.Fprintf(&sb, "\nauthenticator: %p", req.Authenticator)
+	}
+	return sb.String()
+}
+
+func (g *flightGroup) do(req *Request, do func(*Request) (*Response, error)) (*Response, error) {
+	if req.Method != http.MethodGet || req.Stream {
+		return do(req)
+	}
+
+	key := g.key(req)
+	g.mtx.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.shared = true
+		g.mtx.Unlock()
+
+		select {
+		case <-call.done:
+		case <-req.Ctx.Done():
+			return nil, req.Ctx.Err()
+		}
+		// the request of the leader was canceled, but not ours
+		if isContextErr(call.err) && req.Ctx.Err() == nil {
+			return do(req)
+		}
+		return call.response()
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mtx.Unlock()
+
+	call.resp, call.err = do(req)
+
+	// requests arriving from now on start a flight of their own
+	g.mtx.Lock()
+	delete(g.calls, key)
+	shared := call.shared
+	g.mtx.Unlock()
+	if !shared {
+		close(call.done)
+		return call.resp, call.err
+	}
+
+	if call.err == nil {
+		call.body, call.err = io.ReadAll(call.resp.Body)
+		_ = call.resp.Body.Close()
+	}
+	close(call.done)
+	return call.response()
+}
+
+// response returns a copy of the response of the call.
+func (call *flightCall) response() (*Response, error) {
+	if call.err != nil {
+		return nil, call.err
+	}
+	resp := *call.resp
+	resp.Header = call.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(call.body))
+	resp.Meta.Shared = true
+	return &resp, nil
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func TestRequestCoalescingSharesResponse(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test/", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = io.WriteString(w, "shared")
+	})
+
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestCoalescing())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	resps := make([]*oc.Response, 3)
+	for i := range resps {
+		wg.Go(func() { resps[i] = doPath(t, c, http.MethodGet, "/test/a") })
+	}
+	// give the followers time to join the flight of the leader
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+	for _, resp := range resps {
+		if !resp.Meta.Shared {
+			t.Error("response not marked as shared")
+		}
+		if body := readBody(t, resp); body != "shared" {
+			t.Errorf("got body %q, want %q", body, "shared")
+		}
+	}
+}
+
+func TestRequestCoalescingStreamsUnsharedResponse(t *testing.T) {
+	release := make(chan struct{})
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "head")
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, "tail")
+	})
+
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestCoalescing())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the response is returned before the body is complete
+	resp := doPath(t, c, http.MethodGet, "/test/a")
+	if resp.Meta.Shared {
+		t.Error("unshared response marked as shared")
+	}
+	close(release)
+	if body := readBody(t, resp); body != "headtail" {
+		t.Errorf("got body %q, want %q", body, "headtail")
+	}
+}
@@ -35,6 +35,13 @@ type ServiceMapper interface {
 	GetHost(svc string) (string, error)
 }
 
+// MultiHostServiceMapper is implemented by service mappers knowing all hosts
+// of a service.
+type MultiHostServiceMapper interface {
+	ServiceMapper
+	GetHosts(svc string) ([]string, error)
+}
+
 type StaticServiceMapper struct {
 	Default     string
 	ServiceHost map[string]string
@@ -52,6 +59,7 @@ func (m *StaticServiceMapper) GetHost(svc string) (string, error) {
 type dynamicServiceMapper struct {
 	occ     Client
 	itemTTL time.Duration
+	cb      *CircuitBreaker
 
 	mtx         sync.RWMutex
 	serviceHost map[string]dynamicServiceItem // protected by mtx
@@ -64,15 +72,51 @@ type dynamicServiceItem struct {
 
 var _ ServiceMapper = &dynamicServiceMapper{}
 
-func NewDynamicServiceMapper(serviceRegistryClient Client, ttl time.Duration) *dynamicServiceMapper {
-	return &dynamicServiceMapper{
+type DynamicServiceMapperOpts interface {
+	Apply(*dynamicServiceMapper)
+}
+
+type DynamicServiceMapperOptsFunc func(*dynamicServiceMapper)
+
+func (f DynamicServiceMapperOptsFunc) Apply(m *dynamicServiceMapper) { f(m) }
+
+// SkipOpenCircuits makes the service mapper hand out hosts with an open
+// circuit only if all hosts of a service have an open circuit. The
+// StaticServiceMapper knows a single host per service only, requests to it
+// fail with CircuitOpenErr while its circuit is open.
+func SkipOpenCircuits(cb *CircuitBreaker) DynamicServiceMapperOpts {
+	return DynamicServiceMapperOptsFunc(func(m *dynamicServiceMapper) {
+		m.cb = cb
+	})
+}
+
+func NewDynamicServiceMapper(serviceRegistryClient Client, ttl time.Duration, opts ...DynamicServiceMapperOpts) *dynamicServiceMapper {
+	m := &dynamicServiceMapper{
 		occ:         serviceRegistryClient,
 		itemTTL:     ttl,
 		serviceHost: make(map[string]dynamicServiceItem),
 	}
+	for _, opt := range opts {
+		opt.Apply(m)
+	}
+	return m
 }
 
 func (m *dynamicServiceMapper) GetHost(svc string) (string, error) {
+	hosts, err := m.GetHosts(svc)
+	if err != nil {
+		return "", err
+	}
+	if len(hosts) == 0 {
+		return "", ServiceNotFoundErr
+	}
+	i := rand.IntN(len(hosts))
+	return hosts[i], nil
+}
+
+// GetHosts returns the hosts of the service. Hosts with an open circuit are
+// left out unless all hosts have an open circuit.
+func (m *dynamicServiceMapper) GetHosts(svc string) ([]string, error) {
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
 
@@ -82,19 +126,30 @@ func (m *dynamicServiceMapper) GetHost(svc string) (string, error) {
 	if !ok {
 		svcItem, err = m.resolveService(svc)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
 	if time.Now().Unix() > svcItem.expired {
 		svcItem, err = m.resolveService(svc)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
-	i := rand.IntN(len(svcItem.hosts))
-	return svcItem.hosts[i], nil
+	hosts := svcItem.hosts
+	if m.cb != nil {
+		available := make([]string, 0, len(hosts))
+		for _, host := range hosts {
+			if m.cb.Available(host) {
+				available = append(available, host)
+			}
+		}
+		if len(available) > 0 {
+			hosts = available
+		}
+	}
+	return hosts, nil
 }
 
 func (m *dynamicServiceMapper) resolveService(svc string) (dynamicServiceItem, error) {
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/pkg/jwt"
+)
+
+// tokenExpiryDelta is how early a token is considered expired.
+const tokenExpiryDelta = 10 * time.Second
+
+type Token struct {
+	AccessToken string
+	// Expiry is the expiration time of the token. The zero value means the
+	// token does not expire.
+	Expiry time.Time
+}
+
+// Valid reports whether the token is set and not about to expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry.IsZero() {
+		return true
+	}
+	return time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource provides tokens for authenticating requests. It is modeled
+// after oauth2.TokenSource.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// ContextTokenSource is implemented by token sources requesting tokens from
+// other services. Requests authenticated with such a token source pass their
+// context to TokenContext, so token requests are canceled with them.
+type ContextTokenSource interface {
+	TokenSource
+	TokenContext(ctx context.Context) (*Token, error)
+}
+
+// tokenContext returns a token of ts, using ctx if ts supports it.
+func tokenContext(ctx context.Context, ts TokenSource) (*Token, error) {
+	if cts, ok := ts.(ContextTokenSource); ok && ctx != nil {
+		return cts.TokenContext(ctx)
+	}
+	return ts.Token()
+}
+
+type TokenSourceFunc func() (*Token, error)
+
+func (f TokenSourceFunc) Token() (*Token, error) { return f() }
+
+// StaticTokenSource returns a token source always returning the given token.
+func StaticTokenSource(accessToken string) TokenSource {
+	return TokenSourceFunc(func() (*Token, error) {
+		return &Token{AccessToken: accessToken}, nil
+	})
+}
+
+type reuseTokenSource struct {
+	src TokenSource
+
+	mtx sync.Mutex
+	t   *Token // protected by mtx
+}
+
+var _ ContextTokenSource = &reuseTokenSource{}
+
+// ReuseTokenSource returns a token source caching the tokens of src until
+// shortly before they expire. If a token has no expiry set, the exp claim of
+// the JWT is used instead.
+func ReuseTokenSource(src TokenSource) TokenSource {
+	if rts, ok := src.(*reuseTokenSource); ok {
+		return rts
+	}
+	return &reuseTokenSource{src: src}
+}
+
+func (s *reuseTokenSource) Token() (*Token, error) {
+	return s.token(s.src.Token)
+}
+
+func (s *reuseTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	return s.token(func() (*Token, error) { return tokenContext(ctx, s.src) })
+}
+
+func (s *reuseTokenSource) token(fetch func() (*Token, error)) (*Token, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.t.Valid() {
+		return s.t, nil
+	}
+
+	t, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if t.Expiry.IsZero() {
+		if _, claims, err := jwt.Decode(t.AccessToken); err == nil {
+			t = &Token{AccessToken: t.AccessToken, Expiry: claims.Expiry()}
+		}
+	}
+	s.t = t
+	return t, nil
+}
+
+type signedTokenSource struct {
+	signer *jwt.Signer
+	claims jwt.Claims
+	ttl    time.Duration
+}
+
+var _ TokenSource = &signedTokenSource{}
+
+// SignedTokenSource returns a token source minting tokens locally, e.g. for
+// the JWT auth provider of Opencast trusting the key of signer. The tokens
+// carry the given claims and are valid for ttl. New tokens are minted shortly
+// before the previous one expires.
+func SignedTokenSource(signer *jwt.Signer, claims jwt.Claims, ttl time.Duration) TokenSource {
+	return ReuseTokenSource(&signedTokenSource{
+		signer: signer,
+		claims: claims,
+		ttl:    ttl,
+	})
+}
+
+func (s *signedTokenSource) Token() (*Token, error) {
+	now := time.Now()
+	claims := s.claims
+	claims.IssuedAt = now.Unix()
+	claims.ExpiresAt = now.Add(s.ttl).Unix()
+
+	token, err := s.signer.Sign(&claims)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{AccessToken: token, Expiry: time.Unix(claims.ExpiresAt, 0)}, nil
+}
+
+// WithJWTHeaderTokenSource authenticates requests with a JWT of ts in the
+// given header. Tokens are reused until shortly before they expire. See
+// [ContextTokenSource] for the context of token requests.
+func WithJWTHeaderTokenSource(header, prefix string, ts TokenSource) RequestOpts {
+	ts = ReuseTokenSource(ts)
+	return RequestOptsFunc(func(req *Request) error {
+		t, err := tokenContext(req.Ctx, ts)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(header, prefix+t.AccessToken)
+		return nil
+	})
+}
+
+// WithJWTQueryTokenSource authenticates requests with a JWT of ts in the jwt
+// query parameter. Tokens are reused until shortly before they expire. See
+// [ContextTokenSource] for the context of token requests.
+func WithJWTQueryTokenSource(ts TokenSource) RequestOpts {
+	ts = ReuseTokenSource(ts)
+	return RequestOptsFunc(func(req *Request) error {
+		t, err := tokenContext(req.Ctx, ts)
+		if err != nil {
+			return err
+		}
+		req.Query.Set("jwt", t.AccessToken)
+		return nil
+	})
+}
+
+// WithSignedJWT authenticates requests with locally minted tokens in the
+// Authorization header. See [SignedTokenSource].
+func WithSignedJWT(signer *jwt.Signer, claims jwt.Claims, ttl time.Duration) RequestOpts {
+	return WithJWTHeaderTokenSource("Authorization", "Bearer ", SignedTokenSource(signer, claims, ttl))
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+	"shio.solutions/tales.media/opencast-client-go/pkg/jwt"
+)
+
+func TestWithSignedJWT(t *testing.T) {
+	secret := []byte("secret")
+	srv := opencasttest.NewServer(opencasttest.WithTrustedJWTKey(secret))
+	defer srv.Close()
+
+	signer, err := jwt.NewSigner(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newTokenClient(t, srv, oc.SignedTokenSource(signer, jwt.Claims{Username: "admin"}, time.Minute))
+	if _, _, err := api.GetAPIVersion(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	untrusted, err := jwt.NewSigner([]byte("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api = newTokenClient(t, srv, oc.SignedTokenSource(untrusted, jwt.Claims{Username: "admin"}, time.Minute))
+	_, resp, err := api.GetAPIVersion(context.Background())
+	if err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want status %d", err, http.StatusUnauthorized)
+	}
+}
+
+func TestReuseTokenSourceUsesJWTExpiry(t *testing.T) {
+	signer, err := jwt.NewSigner([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	ts := oc.ReuseTokenSource(oc.TokenSourceFunc(func() (*oc.Token, error) {
+		calls++
+		token, err := signer.Sign(&jwt.Claims{ExpiresAt: time.Now().Add(time.Hour).Unix()})
+		return &oc.Token{AccessToken: token}, err
+	}))
+	for range 3 {
+		if _, err := ts.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
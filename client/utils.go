@@ -18,6 +18,7 @@ package client
 
 import (
 	"errors"
+	"iter"
 	"net/http"
 )
 
@@ -90,3 +91,45 @@ func responseErr(resp *Response) error {
 	}
 	return UnexpectedStatusCodeErr
 }
+
+// GenericStreamedDo sends the request as streamed request, see
+// [Request.Stream], and iterates over the elements of the JSON array in the
+// response body without decoding the whole array into memory. Errors are
+// yielded as the last element. The meta data of the response, e.g. the
+// diagnostics of the decode mode, is available via [WithResponseMeta].
+func GenericStreamedDo[T any](do Doer, reqFunc func() (*Request, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		var req *Request
+		resp, err := GenericDo(do, func() (*Request, error) {
+			var err error
+			req, err = reqFunc()
+			if err == nil {
+				req.Stream = true
+			}
+			return req, err
+		})
+		if resp != nil {
+			defer func() {
+				_ = resp.Body.Close()
+				if req.meta != nil {
+					*req.meta = resp.Meta
+				}
+			}()
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		stopped := false
+		err = JsonStreamDecoder(resp, func(v T) bool {
+			stopped = !yield(v, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(zero, err)
+		}
+	}
+}
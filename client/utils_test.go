@@ -0,0 +1,129 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+type streamedItem struct {
+	A int `json:"a"`
+}
+
+func newListServer(t *testing.T, hits *atomic.Int32, body string) *opencasttest.Server {
+	t.Helper()
+	srv := opencasttest.NewServer(opencasttest.WithCredentials("", ""))
+	t.Cleanup(srv.Close)
+	srv.HandleFunc("GET /test/", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = io.WriteString(w, body)
+	})
+	return srv
+}
+
+func streamItems(c oc.Client, opts ...oc.RequestOpts) ([]streamedItem, error) {
+	var items []streamedItem
+	for item, err := range oc.GenericStreamedDo[streamedItem](c, func() (*oc.Request, error) {
+		return oc.NewRequest(context.Background(), http.MethodGet, testService, "/test/list", oc.NoBody, opts...)
+	}) {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func TestStreamedRequestBypassesCacheAndCoalescing(t *testing.T) {
+	var hits atomic.Int32
+	srv := newListServer(t, &hits, `[{"a":1},{"a":2}]`)
+	c, err := oc.New(srv.ServiceMapper(),
+		oc.WithCache(oc.NewLRUCacheStore(10), time.Minute),
+		oc.WithRequestCoalescing(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		items, err := streamItems(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("got %d items, want 2", len(items))
+		}
+	}
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("got %d requests, want 2", n)
+	}
+}
+
+func TestStreamedDoLenientDecodeMode(t *testing.T) {
+	var hits atomic.Int32
+	srv := newListServer(t, &hits, `[{"a":1},{"a":"x"},{"a":2,"b":3}]`)
+	c, err := oc.New(srv.ServiceMapper())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var meta oc.ResponseMeta
+	items, err := streamItems(c, oc.WithDecodeMode(oc.LenientDecodeMode), oc.WithResponseMeta(&meta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].A != 1 || items[1].A != 2 {
+		t.Fatalf("got items %v, want the valid ones", items)
+	}
+	if len(meta.Diagnostics) != 2 {
+		t.Fatalf("got diagnostics %v, want 2", meta.Diagnostics)
+	}
+	if d := meta.Diagnostics[0]; d.Kind != oc.DecodeErrorDiagnosticKind || d.Path != "$[1]" {
+		t.Errorf("got diagnostic %s, want decode error at $[1]", d)
+	}
+	if d := meta.Diagnostics[1]; d.Kind != oc.UnknownFieldDiagnosticKind || d.Path != "$[2].b" {
+		t.Errorf("got diagnostic %s, want unknown field at $[2].b", d)
+	}
+}
+
+func TestStreamedDoStrictDecodeMode(t *testing.T) {
+	var hits atomic.Int32
+	srv := newListServer(t, &hits, `[{"a":1},{"a":2,"b":3}]`)
+	c, err := oc.New(srv.ServiceMapper())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := streamItems(c, oc.WithDecodeMode(oc.StrictDecodeMode))
+	if !errors.Is(err, oc.UnknownFieldsErr) {
+		t.Fatalf("got error %v, want %v", err, oc.UnknownFieldsErr)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d items before the error, want 1", len(items))
+	}
+}
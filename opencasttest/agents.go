@@ -0,0 +1,49 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+)
+
+func (s *Server) registerAgents() {
+	s.mux.HandleFunc("GET /api/agents", s.listAgents)
+	s.mux.HandleFunc("GET /api/agents/{id}", s.getAgent)
+}
+
+func (s *Server) listAgents(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	agents := values(s.st.agents)
+	s.mtx.RUnlock()
+
+	writeJSON(w, http.StatusOK, paginate(r, agents))
+}
+
+func (s *Server) getAgent(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, a := find(s.st.agents, func(a *extapiv1.Agent) bool { return a.AgentID == id })
+	if a == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+// registerAssetManager serves the asset manager. Ingested media packages are
+// archived as new snapshot, copying the uploaded files.
+func (s *Server) registerAssetManager() {
+	// /assets/episode/{id} overlaps with the per media package listings
+	s.mux.HandleFunc("GET /assets/{mp}/{name}", s.getAssets)
+	s.mux.HandleFunc("GET /assets/{mp}/snapshots/{version}", s.getSnapshot)
+	s.mux.HandleFunc("GET /assets/assets/{mp}/{el}/{version}/{filename}", s.getAsset)
+	s.mux.HandleFunc("POST /assets/add", s.addSnapshot)
+	s.mux.HandleFunc("DELETE /assets/delete/{id}", s.deleteSnapshots)
+	s.mux.HandleFunc("POST /assets/{mp}/properties", s.setAssetProperty)
+}
+
+// takeSnapshot archives a copy of the media package whose elements point to
+// the asset manager.
+func (s *Server) takeSnapshot(mp *mediapackage.MediaPackage) *assetmanager.Snapshot {
+	// s.mtx is assumed to be locked
+	version := len(s.st.snapshots[mp.ID])
+	archived := cloneMediaPackage(mp)
+	for _, el := range elements(archived) {
+		u := s.URL + "/assets/assets/" + mp.ID + "/" + el.ID + "/" + strconv.Itoa(version) + "/" + elementFilename(el.URL)
+		if b, ok := s.st.files[el.URL]; ok {
+			s.st.files[u] = b
+		}
+		el.URL = u
+	}
+	snapshot := &assetmanager.Snapshot{
+		Version:        base.Int(version),
+		OrganizationID: s.st.organization.ID,
+		ArchivalDate:   base.DateTime{Time: time.Now().UTC().Truncate(time.Second)},
+		Availability:   assetmanager.OnlineAvailability,
+		StorageID:      "local-filesystem",
+		Owner:          s.st.me.Username,
+		MediaPackage:   *archived,
+	}
+	s.st.snapshots[mp.ID] = append(s.st.snapshots[mp.ID], snapshot)
+	if _, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == mp.ID }); e != nil {
+		e.ArchiveVersion = new(snapshot.Version)
+	}
+	return snapshot
+}
+
+func cloneMediaPackage(mp *mediapackage.MediaPackage) *mediapackage.MediaPackage {
+	b, err := xml.Marshal(mp)
+	if err != nil {
+		panic(err)
+	}
+	c := &mediapackage.MediaPackage{}
+	if err := xml.Unmarshal(b, c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func elements(mp *mediapackage.MediaPackage) []*mediapackage.Element {
+	var l []*mediapackage.Element
+	if mp.Media != nil {
+		for i := range mp.Media.Track {
+			l = append(l, &mp.Media.Track[i].Element)
+		}
+	}
+	if mp.Metadata != nil {
+		for i := range mp.Metadata.Catalog {
+			l = append(l, &mp.Metadata.Catalog[i].Element)
+		}
+	}
+	if mp.Attachments != nil {
+		for i := range mp.Attachments.Attachment {
+			l = append(l, &mp.Attachments.Attachment[i].Element)
+		}
+	}
+	return l
+}
+
+func elementFilename(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || path.Base(u.Path) == "/" || path.Base(u.Path) == "." {
+		return "file"
+	}
+	return path.Base(u.Path)
+}
+
+func (s *Server) getAssets(w http.ResponseWriter, r *http.Request) {
+	mpID, name := r.PathValue("mp"), r.PathValue("name")
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	switch {
+	case mpID == "episode":
+		snapshots := s.st.snapshots[name]
+		if len(snapshots) == 0 {
+			writeStatus(w, http.StatusNotFound)
+			return
+		}
+		writeXML(w, http.StatusOK, &snapshots[len(snapshots)-1].MediaPackage)
+
+	case name == "snapshots.json":
+		snapshots := s.st.snapshots[mpID]
+		if len(snapshots) == 0 {
+			writeStatus(w, http.StatusNotFound)
+			return
+		}
+		writeJSONContentType(w, jsonContentType, http.StatusOK, &assetmanager.SnapshotsResponse{
+			Snapshots: assetmanager.SnapshotList{Snapshot: values(snapshots)},
+		})
+
+	case name == "properties.json":
+		var props []assetmanager.Property
+		for _, p := range s.st.assetProperties[mpID] {
+			if ns := r.URL.Query().Get("namespace"); ns == "" || p.Namespace == ns {
+				props = append(props, p)
+			}
+		}
+		writeJSONContentType(w, jsonContentType, http.StatusOK, &assetmanager.PropertiesResponse{
+			Properties: assetmanager.PropertyList{Property: props},
+		})
+
+	default:
+		writeStatus(w, http.StatusNotFound)
+	}
+}
+
+// findSnapshot returns the snapshot of the "mp" and "version" path values.
+func (s *Server) findSnapshot(r *http.Request) *assetmanager.Snapshot {
+	// s.mtx is assumed to be locked
+	version, err := strconv.Atoi(strings.TrimSuffix(r.PathValue("version"), ".json"))
+	snapshots := s.st.snapshots[r.PathValue("mp")]
+	if err != nil || version < 0 || version >= len(snapshots) {
+		return nil
+	}
+	return snapshots[version]
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	snapshot := s.findSnapshot(r)
+	if snapshot == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, &assetmanager.SnapshotResponse{Snapshot: *snapshot})
+}
+
+func (s *Server) getAsset(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	snapshot := s.findSnapshot(r)
+	if snapshot == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	for _, el := range elements(&snapshot.MediaPackage) {
+		if el.ID != r.PathValue("el") {
+			continue
+		}
+		if el.MimeType != "" {
+			w.Header().Set("Content-Type", el.MimeType)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(s.st.files[el.URL])))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(s.st.files[el.URL])
+		return
+	}
+	writeStatus(w, http.StatusNotFound)
+}
+
+func (s *Server) addSnapshot(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mp := &mediapackage.MediaPackage{}
+	if err := xml.Unmarshal([]byte(r.FormValue("mediapackage")), mp); err != nil || mp.ID == "" {
+		http.Error(w, "invalid media package", http.StatusBadRequest)
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.takeSnapshot(mp)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSnapshots(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.st.snapshots[id]) == 0 {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	for _, snapshot := range s.st.snapshots[id] {
+		for _, el := range elements(&snapshot.MediaPackage) {
+			delete(s.st.files, el.URL)
+		}
+	}
+	delete(s.st.snapshots, id)
+	delete(s.st.assetProperties, id)
+	if _, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == id }); e != nil {
+		e.ArchiveVersion = nil
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setAssetProperty(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mpID := r.PathValue("mp")
+	prop := assetmanager.Property{
+		Namespace: r.FormValue("namespace"),
+		Name:      r.FormValue("name"),
+		Type:      assetmanager.PropertyType(r.FormValue("type")),
+		Value:     r.FormValue("value"),
+	}
+	if prop.Namespace == "" || prop.Name == "" {
+		http.Error(w, "namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	if !validPropertyValue(prop) {
+		http.Error(w, "invalid "+string(prop.Type)+" value", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.st.snapshots[mpID]) == 0 {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	props := s.st.assetProperties[mpID]
+	for i, p := range props {
+		if p.Namespace == prop.Namespace && p.Name == prop.Name {
+			props[i] = prop
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	s.st.assetProperties[mpID] = append(props, prop)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validPropertyValue(p assetmanager.Property) bool {
+	var err error
+	switch p.Type {
+	case assetmanager.StringPropertyType:
+	case assetmanager.LongPropertyType, assetmanager.VersionPropertyType:
+		_, err = p.Long()
+	case assetmanager.BooleanPropertyType:
+		_, err = p.Boolean()
+	case assetmanager.DatePropertyType:
+		_, err = p.Date()
+	default:
+		return false
+	}
+	return err == nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+// DefaultScheduleWorkflow is the workflow definition capture agents are told
+// to start for scheduled recordings.
+const DefaultScheduleWorkflow = "schedule-and-upload"
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// dublinCoreXML returns a Dublin Core catalog with the given terms and values.
+func dublinCoreXML(terms ...string) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	buf.WriteString(`<dublincore xmlns="http://www.opencastproject.org/xsd/1.0/dublincore/" xmlns:dcterms="http://purl.org/dc/terms/">`)
+	for i := 0; i+1 < len(terms); i += 2 {
+		if terms[i+1] == "" {
+			continue
+		}
+		buf.WriteString("<dcterms:" + terms[i] + ">")
+		_ = xml.EscapeText(buf, []byte(terms[i+1]))
+		buf.WriteString("</dcterms:" + terms[i] + ">")
+	}
+	buf.WriteString("</dublincore>")
+	return buf.Bytes()
+}
+
+func (s *Server) calendarEvent(e *EventFixture) scheduler.CalendarEvent {
+	// s.mtx is assumed to be locked
+	episode := dublinCoreXML(
+		"identifier", e.Identifier,
+		"title", e.Title,
+		"description", e.Description,
+		"creator", strings.Join(e.Presenter, ", "),
+		"isPartOf", e.IsPartOf,
+		"spatial", e.Scheduling.AgentID,
+		"temporal", "start="+e.Scheduling.Start.Time.Format(time.RFC3339)+"; end="+e.Scheduling.End.Time.Format(time.RFC3339)+"; scheme=W3C-DTF;",
+	)
+
+	props := base.Properties{
+		"event.title":    e.Title,
+		"event.location": e.Scheduling.AgentID,
+		scheduler.WorkflowDefinitionAgentProperty: DefaultScheduleWorkflow,
+	}
+	if e.IsPartOf != "" {
+		props["event.series"] = e.IsPartOf
+	}
+	if len(e.Scheduling.Inputs) > 0 {
+		props[scheduler.DeviceNamesAgentProperty] = strings.Join(e.Scheduling.Inputs, ",")
+	}
+
+	ce := scheduler.CalendarEvent{
+		EventID:  e.Identifier,
+		Title:    e.Title,
+		SeriesID: e.IsPartOf,
+		AgentID:  e.Scheduling.AgentID,
+		Start:    e.Scheduling.Start.Time,
+		End:      e.Scheduling.End.Time,
+		TimeZone: time.UTC,
+		Attachments: []scheduler.CalendarAttachment{
+			{Filename: scheduler.EpisodeAttachment, MimeType: "application/xml", Data: episode},
+		},
+	}
+	if e.IsPartOf != "" {
+		ce.Attachments = append(ce.Attachments, scheduler.CalendarAttachment{
+			Filename: scheduler.SeriesAttachment,
+			MimeType: "application/xml",
+			Data:     dublinCoreXML("identifier", e.IsPartOf, "title", s.seriesTitle(e.IsPartOf)),
+		})
+	}
+	ce.Attachments = append(ce.Attachments, scheduler.CalendarAttachment{
+		Filename: scheduler.AgentPropertiesAttachment,
+		MimeType: "application/text",
+		Data:     []byte(props.Format()),
+	})
+	return ce
+}
+
+// getCalendar serves the upcoming recordings starting before the cutoff as
+// iCalendar.
+func (s *Server) getCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	agent, seriesID := q.Get("agentid"), q.Get("seriesid")
+	var cutoff time.Time
+	if v := q.Get("cutoff"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cutoff = time.UnixMilli(ms)
+	}
+
+	cal := &ical.Component{
+		Name: ical.CalendarComponent,
+		Properties: []ical.Property{
+			ical.NewTextProperty("VERSION", "2.0"),
+			ical.NewTextProperty("PRODID", "-//opencasttest//Scheduler//EN"),
+			ical.NewTextProperty("CALSCALE", "GREGORIAN"),
+		},
+	}
+	now := time.Now()
+	s.mtx.RLock()
+	for _, e := range s.st.events {
+		if !isRecording(e) || (agent != "" && e.Scheduling.AgentID != agent) ||
+			(seriesID != "" && e.IsPartOf != seriesID) ||
+			e.Scheduling.End.Time.Before(now) || (!cutoff.IsZero() && e.Scheduling.Start.Time.After(cutoff)) {
+			continue
+		}
+		ce := s.calendarEvent(e)
+		cal.Components = append(cal.Components, ce.ICal())
+	}
+	s.mtx.RUnlock()
+
+	buf := &bytes.Buffer{}
+	if err := cal.Encode(buf); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	_, _ = buf.WriteTo(w)
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/captureadmin"
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func (s *Server) registerCaptureAdmin() {
+	s.mux.HandleFunc("GET /capture-admin/agents.json", s.listAgentStates)
+	s.mux.HandleFunc("GET /capture-admin/agents/{file}", s.getAgentState)
+	s.mux.HandleFunc("POST /capture-admin/agents/{name}", s.updateAgentState)
+	s.mux.HandleFunc("DELETE /capture-admin/agents/{name}", s.deleteAgentState)
+	s.mux.HandleFunc("GET /capture-admin/agents/{name}/capabilities.json", s.getAgentCapabilities)
+	s.mux.HandleFunc("GET /capture-admin/agents/{name}/configuration.json", s.getAgentConfiguration)
+	s.mux.HandleFunc("POST /capture-admin/agents/{name}/configuration", s.updateAgentConfiguration)
+
+	s.mux.HandleFunc("GET /capture-admin/recordings.json", s.listRecordingStates)
+	s.mux.HandleFunc("GET /capture-admin/recordings/{file}", s.getRecordingState)
+	s.mux.HandleFunc("POST /capture-admin/recordings/{id}", s.updateRecordingState)
+	s.mux.HandleFunc("DELETE /capture-admin/recordings/{id}", s.deleteRecordingState)
+}
+
+var agentStates = []captureadmin.AgentState{
+	captureadmin.UnknownAgentState,
+	captureadmin.IdleAgentState,
+	captureadmin.CapturingAgentState,
+	captureadmin.UploadingAgentState,
+	captureadmin.ShuttingDownAgentState,
+	captureadmin.OfflineAgentState,
+	captureadmin.ErrorAgentState,
+}
+
+var recordingStates = []captureadmin.RecordingState{
+	captureadmin.UnknownRecordingState,
+	captureadmin.CapturingRecordingState,
+	captureadmin.CaptureFinishedRecordingState,
+	captureadmin.CaptureErrorRecordingState,
+	captureadmin.ManifestRecordingState,
+	captureadmin.ManifestErrorRecordingState,
+	captureadmin.ManifestFinishedRecordingState,
+	captureadmin.CompressingRecordingState,
+	captureadmin.CompressingErrorRecordingState,
+	captureadmin.UploadingRecordingState,
+	captureadmin.UploadFinishedRecordingState,
+	captureadmin.UploadErrorRecordingState,
+}
+
+func (s *Server) findAgent(name string) (int, *extapiv1.Agent) {
+	// s.mtx is assumed to be locked
+	return find(s.st.agents, func(a *extapiv1.Agent) bool { return a.AgentID == name })
+}
+
+func (s *Server) agentState(a *extapiv1.Agent) captureadmin.Agent {
+	// s.mtx is assumed to be locked
+	state := captureadmin.Agent{
+		Name:  a.AgentID,
+		State: captureadmin.AgentState(a.Status),
+		URL:   a.URL,
+	}
+	if !a.Update.IsZero() {
+		state.TimeSinceLastUpdate = base.Int(time.Since(a.Update.Time).Milliseconds())
+	}
+	if caps := capabilities(s.st.agentConfigs[a.AgentID]); len(caps) > 0 {
+		state.Capabilities = propertyList(caps)
+	}
+	return state
+}
+
+// capabilities returns the capture device properties of config.
+func capabilities(config base.Properties) base.Properties {
+	caps := make(base.Properties)
+	for k, v := range config {
+		if strings.HasPrefix(k, "capture.device.") {
+			caps[k] = v
+		}
+	}
+	return caps
+}
+
+func propertyList(props base.Properties) *captureadmin.PropertyList {
+	l := &captureadmin.PropertyList{}
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		l.Item = append(l.Item, captureadmin.PropertyItem{Key: k, Value: props[k]})
+	}
+	return l
+}
+
+func (s *Server) listAgentStates(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	resp := captureadmin.AgentsResponse{}
+	for _, a := range s.st.agents {
+		resp.Agents.Agent = append(resp.Agents.Agent, s.agentState(a))
+	}
+	s.mtx.RUnlock()
+
+	writeJSONContentType(w, jsonContentType, http.StatusOK, resp)
+}
+
+func (s *Server) getAgentState(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+	if !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, a := s.findAgent(name)
+	if a == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, captureadmin.AgentResponse{Agent: s.agentState(a)})
+}
+
+func (s *Server) updateAgentState(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.PathValue("name")
+	state := captureadmin.AgentState(r.FormValue("state"))
+	if !slices.Contains(agentStates, state) {
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, a := s.findAgent(name)
+	if a == nil {
+		a = &extapiv1.Agent{AgentID: name}
+		s.st.agents = append(s.st.agents, a)
+	}
+	a.Status = extapiv1.AgentStatus(state)
+	a.URL = r.FormValue("address")
+	a.Update = base.DateTime{Time: time.Now().UTC().Truncate(time.Second)}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteAgentState(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, a := s.findAgent(r.PathValue("name"))
+	if a == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.agents = slices.Delete(s.st.agents, i, i+1)
+	delete(s.st.agentConfigs, a.AgentID)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getAgentCapabilities(w http.ResponseWriter, r *http.Request) {
+	s.writeAgentProperties(w, r, capabilities)
+}
+
+func (s *Server) getAgentConfiguration(w http.ResponseWriter, r *http.Request) {
+	s.writeAgentProperties(w, r, func(config base.Properties) base.Properties { return config })
+}
+
+func (s *Server) writeAgentProperties(w http.ResponseWriter, r *http.Request, f func(base.Properties) base.Properties) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, a := s.findAgent(r.PathValue("name"))
+	if a == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	props := f(s.st.agentConfigs[a.AgentID])
+	writeJSONContentType(w, jsonContentType, http.StatusOK, captureadmin.PropertiesResponse{Properties: *propertyList(props)})
+}
+
+func (s *Server) updateAgentConfiguration(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	config := base.Properties{}
+	if err := json.Unmarshal([]byte(r.FormValue("configuration")), &config); err != nil {
+		http.Error(w, "opencasttest: configuration must be JSON", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, a := s.findAgent(r.PathValue("name"))
+	if a == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.agentConfigs[a.AgentID] = config
+	a.Inputs = nil
+	if names := config["capture.device.names"]; names != "" {
+		a.Inputs = strings.Split(names, ",")
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, captureadmin.PropertiesResponse{Properties: *propertyList(config)})
+}
+
+func (s *Server) listRecordingStates(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	resp := captureadmin.RecordingsResponse{}
+	for _, id := range slices.Sorted(maps.Keys(s.st.recordingStates)) {
+		resp.Recordings.Recording = append(resp.Recordings.Recording, s.st.recordingStates[id].view())
+	}
+	s.mtx.RUnlock()
+
+	writeJSONContentType(w, jsonContentType, http.StatusOK, resp)
+}
+
+type recordingState struct {
+	id      string
+	state   captureadmin.RecordingState
+	updated time.Time
+}
+
+func (rec *recordingState) view() captureadmin.Recording {
+	return captureadmin.Recording{
+		ID:                  rec.id,
+		State:               rec.state,
+		TimeSinceLastUpdate: base.Int(time.Since(rec.updated).Milliseconds()),
+	}
+}
+
+func (s *Server) getRecordingState(w http.ResponseWriter, r *http.Request) {
+	id, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+	if !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	rec, ok := s.st.recordingStates[id]
+	if !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, captureadmin.RecordingResponse{Recording: rec.view()})
+}
+
+func (s *Server) updateRecordingState(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	state := captureadmin.RecordingState(r.FormValue("state"))
+	if !slices.Contains(recordingStates, state) {
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+
+	id := r.PathValue("id")
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.st.recordingStates[id] = &recordingState{id: id, state: state, updated: time.Now()}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteRecordingState(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.st.recordingStates[id]; !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	delete(s.st.recordingStates, id)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+const DigestRealm = "Opencast"
+
+// ExpireNonces invalidates all nonces issued for Digest auth. Requests using
+// them are challenged again with stale=true.
+func (s *Server) ExpireNonces() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	clear(s.nonces)
+}
+
+// wantsDigest reports whether the client requested Digest auth like Opencast
+// expects it from node-to-node requests.
+func wantsDigest(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get(oc.RequestedAuthHeader), "Digest")
+}
+
+func (s *Server) digestChallenge(w http.ResponseWriter, r *http.Request) {
+	_, stale := s.checkDigest(r)
+	nonce := s.newIdentifier()
+
+	s.mtx.Lock()
+	s.nonces[nonce] = struct{}{}
+	s.mtx.Unlock()
+
+	challenge := `Digest realm="` + DigestRealm + `", qop="auth", nonce="` + nonce + `"`
+	if stale {
+		challenge += ", stale=true"
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func (s *Server) validDigest(r *http.Request) bool {
+	ok, _ := s.checkDigest(r)
+	return ok
+}
+
+// checkDigest verifies the Digest credentials of the request. stale reports
+// whether the credentials are correct but the nonce is unknown or expired.
+func (s *Server) checkDigest(r *http.Request) (ok, stale bool) {
+	scheme, rest, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Digest") {
+		return false, false
+	}
+	params := parseDigestParams(rest)
+	if params["username"] != s.username || params["realm"] != DigestRealm || params["uri"] != r.URL.RequestURI() {
+		return false, false
+	}
+
+	h := func(v string) string {
+		sum := md5.Sum([]byte(v))
+		return hex.EncodeToString(sum[:])
+	}
+	ha1 := h(s.username + ":" + DigestRealm + ":" + s.password)
+	ha2 := h(r.Method + ":" + params["uri"])
+	nonce := params["nonce"]
+	if params["response"] != h(ha1+":"+nonce+":"+params["nc"]+":"+params["cnonce"]+":"+params["qop"]+":"+ha2) {
+		return false, false
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if _, known := s.nonces[nonce]; !known {
+		return false, true
+	}
+	return true, false
+}
+
+func parseDigestParams(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		k, rest, ok := strings.Cut(strings.TrimLeft(s, " ,"), "=")
+		if !ok {
+			break
+		}
+		var v string
+		if strings.HasPrefix(rest, `"`) {
+			v, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			v, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(k))] = v
+		s = rest
+	}
+	return params
+}
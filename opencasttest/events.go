@@ -0,0 +1,719 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/pkg/rrule"
+)
+
+func (s *Server) registerEvents() {
+	s.mux.HandleFunc("GET /api/events", s.listEvents)
+	s.mux.HandleFunc("POST /api/events", s.createEvent)
+	s.mux.HandleFunc("GET /api/events/{id}", s.getEvent)
+	s.mux.HandleFunc("POST /api/events/{id}", s.updateEvent)
+	s.mux.HandleFunc("DELETE /api/events/{id}", s.deleteEvent)
+
+	s.mux.HandleFunc("GET /api/events/{id}/acl", s.getEventACL)
+	s.mux.HandleFunc("PUT /api/events/{id}/acl", s.updateEventACL)
+	s.mux.HandleFunc("POST /api/events/{id}/acl/{action}", s.createEventACE)
+	s.mux.HandleFunc("DELETE /api/events/{id}/acl/{action}/{role}", s.deleteEventACE)
+
+	s.mux.HandleFunc("GET /api/events/{id}/media", s.listEventMedia)
+	s.mux.HandleFunc("POST /api/events/{id}/track", s.createEventTrack)
+
+	s.mux.HandleFunc("GET /api/events/{id}/metadata", s.getEventMetadata)
+	s.mux.HandleFunc("PUT /api/events/{id}/metadata", s.updateEventMetadata)
+	s.mux.HandleFunc("DELETE /api/events/{id}/metadata", s.deleteEventMetadata)
+
+	s.mux.HandleFunc("GET /api/events/{id}/publications", s.listEventPublications)
+	s.mux.HandleFunc("GET /api/events/{id}/publications/{publicationID}", s.getEventPublication)
+
+	s.mux.HandleFunc("GET /api/events/{id}/scheduling", s.getEventScheduling)
+	s.mux.HandleFunc("PUT /api/events/{id}/scheduling", s.updateEventScheduling)
+}
+
+func (s *Server) findEvent(r *http.Request) (int, *EventFixture) {
+	// s.mtx is assumed to be locked
+	id := r.PathValue("id")
+	return find(s.st.events, func(e *EventFixture) bool { return e.Identifier == id })
+}
+
+func (s *Server) eventView(r *http.Request, e *EventFixture) extapiv1.Event {
+	// s.mtx is assumed to be locked
+	event := e.Event
+	if _, sf := find(s.st.series, func(sf *SeriesFixture) bool { return sf.Identifier == e.IsPartOf }); sf != nil {
+		event.Series = sf.Title
+	}
+	if !queryBool(r, "withacl") {
+		event.ACL = nil
+	}
+	if !queryBool(r, "withmetadata") {
+		event.Metadata = nil
+	}
+	if !queryBool(r, "withscheduling") {
+		event.Scheduling = extapiv1.Scheduling{}
+	}
+	if !queryBool(r, "withpublications") {
+		event.Publications = nil
+	} else if !queryBool(r, "includeInternalPublication") {
+		event.Publications = slices.DeleteFunc(slices.Clone(event.Publications), func(p extapiv1.Publication) bool {
+			return p.Channel == extapiv1.InternalChannel
+		})
+	}
+	return event
+}
+
+func matchEvent(e *EventFixture, filter map[string]string) bool {
+	for k, v := range filter {
+		switch k {
+		case "series", "is_part_of":
+			if e.IsPartOf != v && e.Series != v {
+				return false
+			}
+		case "identifier":
+			if e.Identifier != v {
+				return false
+			}
+		case "title":
+			if !containsFold(e.Title, v) {
+				return false
+			}
+		case "description":
+			if !containsFold(e.Description, v) {
+				return false
+			}
+		case "location":
+			if e.Location != v {
+				return false
+			}
+		case "agent_id":
+			if e.Scheduling.AgentID != v {
+				return false
+			}
+		case "presenters":
+			if !slices.Contains(e.Presenter, v) {
+				return false
+			}
+		case "status":
+			if string(e.Status) != v {
+				return false
+			}
+		case "textFilter":
+			if !containsFold(e.Title, v) && !containsFold(e.Description, v) && !containsFold(e.Identifier, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sortEvents(events []extapiv1.Event, rawSort string) {
+	by, dir, _ := strings.Cut(rawSort, ":")
+	var cmp func(a, b extapiv1.Event) int
+	switch by {
+	case "title":
+		cmp = func(a, b extapiv1.Event) int { return strings.Compare(a.Title, b.Title) }
+	case "start_date", "technical_start":
+		cmp = func(a, b extapiv1.Event) int { return a.Start.Time.Compare(b.Start.Time) }
+	case "location":
+		cmp = func(a, b extapiv1.Event) int { return strings.Compare(a.Location, b.Location) }
+	default:
+		return
+	}
+	slices.SortStableFunc(events, func(a, b extapiv1.Event) int {
+		if strings.EqualFold(dir, "DESC") {
+			return -cmp(a, b)
+		}
+		return cmp(a, b)
+	})
+}
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	events := make([]extapiv1.Event, 0, len(s.st.events))
+	for _, e := range s.st.events {
+		if matchEvent(e, filter) {
+			events = append(events, s.eventView(r, e))
+		}
+	}
+	s.mtx.RUnlock()
+
+	sortEvents(events, r.URL.Query().Get("sort"))
+	writeJSON(w, http.StatusOK, paginate(r, events))
+}
+
+func (s *Server) createEvent(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e := &EventFixture{}
+	e.Identifier = s.newIdentifier()
+	e.Created = base.DateTime{Time: time.Now().UTC().Truncate(time.Second)}
+	e.Creator = s.currentUsername(r)
+	e.Status = extapiv1.ProcessedEventStatus
+	e.ProcessingState = extapiv1.SucceededProcessingState
+	e.Metadata = []extapiv1.Catalog{newCatalog(base.DublinCoreEpisodeFlavor, "EVENTS.EVENTS.DETAILS.CATALOG.EPISODE", episodeFields)}
+
+	if _, err := unmarshalFormValue(r, "acl", &e.ACL); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var catalogs []rawCatalog
+	if _, err := unmarshalFormValue(r, "metadata", &catalogs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, rc := range catalogs {
+		_, c := findCatalog(e.Metadata, rc.Flavor)
+		if c == nil {
+			e.Metadata = append(e.Metadata, extapiv1.Catalog{Label: rc.Label, Flavor: rc.Flavor})
+			c = &e.Metadata[len(e.Metadata)-1]
+		}
+		setValues(c, rc.Fields)
+	}
+	_, dc := findCatalog(e.Metadata, base.DublinCoreEpisodeFlavor)
+	setValues(dc, []extapiv1.Value{{ID: extapiv1.IdentifierFieldID, Value: e.Identifier}})
+	syncEventFromMetadata(&e.Event)
+	if e.Title == "" {
+		http.Error(w, "title is required", http.StatusBadRequest)
+		return
+	}
+
+	var scheduling extapiv1.SchedulingRequest
+	hasScheduling, err := unmarshalFormValue(r, "scheduling", &scheduling)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var processing extapiv1.Processing
+	hasProcessing, err := unmarshalFormValue(r, "processing", &processing)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hasMedia := hasFile(r, "presenter") || hasFile(r, "presentation") || hasFile(r, "audio")
+	if !hasScheduling && !hasMedia {
+		http.Error(w, "either scheduling or media must be given", http.StatusBadRequest)
+		return
+	}
+
+	var periods []rrule.Period
+	if hasScheduling {
+		periods, err = scheduling.Periods(s.timeZone)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(periods) == 0 {
+			http.Error(w, "rrule yields no recordings", http.StatusBadRequest)
+			return
+		}
+		e.Location = scheduling.AgentID
+		e.Status = extapiv1.ScheduledEventStatus
+		e.ProcessingState = extapiv1.UndefinedProcessingState
+	}
+
+	for _, flavor := range []string{"presenter", "presentation", "audio"} {
+		if !hasFile(r, flavor) {
+			continue
+		}
+		fh := r.MultipartForm.File[flavor][0]
+		e.Media = append(e.Media, extapiv1.MediaTrackElement{
+			Identifier: new(s.newIdentifier()),
+			Flavor:     new(base.Flavor(flavor + "/source")),
+			MimeType:   new(fh.Header.Get("Content-Type")),
+			Size:       base.Int(fh.Size),
+			HasVideo:   flavor != "audio",
+			HasAudio:   true,
+			Tags:       []string{},
+		})
+	}
+
+	if scheduling.RRule != nil {
+		// one event per recording
+		ids := make([]extapiv1.Identifier, 0, len(periods))
+		s.mtx.Lock()
+		for i, p := range periods {
+			ev := e
+			if i > 0 {
+				ev = copyEvent(e)
+				ev.Identifier = s.newIdentifier()
+				_, dc := findCatalog(ev.Metadata, base.DublinCoreEpisodeFlavor)
+				setValues(dc, []extapiv1.Value{{ID: extapiv1.IdentifierFieldID, Value: ev.Identifier}})
+			}
+			setSchedulingPeriod(ev, scheduling, p)
+			s.st.events = append(s.st.events, ev)
+			ids = append(ids, extapiv1.Identifier{Identifier: ev.Identifier})
+		}
+		s.mtx.Unlock()
+
+		writeJSON(w, http.StatusCreated, ids)
+		return
+	}
+	if hasScheduling {
+		setSchedulingPeriod(e, scheduling, periods[0])
+	}
+
+	s.mtx.Lock()
+	s.st.events = append(s.st.events, e)
+	if hasProcessing && !hasScheduling {
+		s.startWorkflow(e.Identifier, processing.Workflow, processing.Configuration)
+	}
+	s.mtx.Unlock()
+
+	w.Header().Set("Location", s.URL+"/api/events/"+e.Identifier)
+	writeJSON(w, http.StatusCreated, extapiv1.Identifier{Identifier: e.Identifier})
+}
+
+func setSchedulingPeriod(e *EventFixture, req extapiv1.SchedulingRequest, p rrule.Period) {
+	e.Scheduling = extapiv1.Scheduling{
+		Start:   base.DateTime{Time: p.Start.UTC()},
+		End:     base.DateTime{Time: p.End.UTC()},
+		AgentID: req.AgentID,
+		Inputs:  req.Inputs,
+	}
+	e.Start = e.Scheduling.Start
+	e.Duration = new(base.Int(p.End.Sub(p.Start).Milliseconds()))
+}
+
+// copyEvent returns a copy of e whose metadata can be changed independently.
+func copyEvent(e *EventFixture) *EventFixture {
+	c := *e
+	c.Metadata = slices.Clone(e.Metadata)
+	for i := range c.Metadata {
+		c.Metadata[i].Fields = slices.Clone(c.Metadata[i].Fields)
+	}
+	c.ACL = slices.Clone(e.ACL)
+	c.Media = slices.Clone(e.Media)
+	return &c
+}
+
+func schedulingFromRequest(req extapiv1.SchedulingRequest) extapiv1.Scheduling {
+	sch := extapiv1.Scheduling{
+		Start:   req.Start,
+		AgentID: req.AgentID,
+		Inputs:  req.Inputs,
+	}
+	switch {
+	case req.End != nil:
+		sch.End = *req.End
+	case req.Duration != nil:
+		sch.End = base.DateTime{Time: req.Start.Time.Add(time.Duration(*req.Duration) * time.Millisecond)}
+	}
+	return sch
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.eventView(r, e))
+}
+
+func (s *Server) updateEvent(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var (
+		acl        extapiv1.ACL
+		catalogs   []rawCatalog
+		scheduling extapiv1.SchedulingRequest
+		processing extapiv1.Processing
+	)
+	hasACL, err := unmarshalFormValue(r, "acl", &acl)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hasMetadata, err := unmarshalFormValue(r, "metadata", &catalogs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hasScheduling, err := unmarshalFormValue(r, "scheduling", &scheduling)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hasProcessing, err := unmarshalFormValue(r, "processing", &processing)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if hasACL {
+		e.ACL = acl
+	}
+	if hasMetadata {
+		for _, rc := range catalogs {
+			_, c := findCatalog(e.Metadata, rc.Flavor)
+			if c == nil {
+				e.Metadata = append(e.Metadata, extapiv1.Catalog{Label: rc.Label, Flavor: rc.Flavor})
+				c = &e.Metadata[len(e.Metadata)-1]
+			}
+			setValues(c, rc.Fields)
+		}
+		syncEventFromMetadata(&e.Event)
+	}
+	if hasScheduling {
+		e.Scheduling = schedulingFromRequest(scheduling)
+		e.Start = e.Scheduling.Start
+	}
+	if hasProcessing {
+		s.startWorkflow(e.Identifier, processing.Workflow, processing.Configuration)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteEvent(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.events = slices.Delete(s.st.events, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getEventACL(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	acl := e.ACL
+	if acl == nil {
+		acl = extapiv1.ACL{}
+	}
+	writeJSON(w, http.StatusOK, acl)
+}
+
+func (s *Server) updateEventACL(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	acl := extapiv1.ACL{}
+	if _, err := unmarshalFormValue(r, "acl", &acl); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	e.ACL = acl
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createEventACE(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := base.Action(r.PathValue("action"))
+	role := r.FormValue("role")
+	if role == "" {
+		http.Error(w, "role is required", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if !hasRole(e.ACL, action, role) {
+		e.ACL = append(e.ACL, extapiv1.ACE{Allow: true, Action: action, Role: role})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteEventACE(w http.ResponseWriter, r *http.Request) {
+	action := base.Action(r.PathValue("action"))
+	role := r.PathValue("role")
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if !hasRole(e.ACL, action, role) {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	e.ACL = slices.DeleteFunc(e.ACL, func(ace extapiv1.ACE) bool {
+		return ace.Action == action && ace.Role == role
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listEventMedia(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	media := e.Media
+	if media == nil {
+		media = []extapiv1.MediaTrackElement{}
+	}
+	writeJSON(w, http.StatusOK, media)
+}
+
+func (s *Server) createEventTrack(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flavor := base.Flavor(r.FormValue("flavor"))
+	if flavor == "" || !hasFile(r, "track") {
+		http.Error(w, "flavor and track are required", http.StatusBadRequest)
+		return
+	}
+	overwrite, _ := strconv.ParseBool(r.FormValue("overwriteExisting"))
+	tags := []string{}
+	if t := r.FormValue("tags"); t != "" {
+		tags = strings.Split(t, ",")
+	}
+	fh := r.MultipartForm.File["track"][0]
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if overwrite {
+		e.Media = slices.DeleteFunc(e.Media, func(m extapiv1.MediaTrackElement) bool {
+			return m.Flavor != nil && *m.Flavor == flavor
+		})
+	}
+	e.Media = append(e.Media, extapiv1.MediaTrackElement{
+		Identifier: new(s.newIdentifier()),
+		Flavor:     new(flavor),
+		MimeType:   new(fh.Header.Get("Content-Type")),
+		Size:       base.Int(fh.Size),
+		Tags:       tags,
+	})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getEventMetadata(w http.ResponseWriter, r *http.Request) {
+	flavor := base.Flavor(r.URL.Query().Get("type"))
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if flavor == "" {
+		writeJSON(w, http.StatusOK, e.Metadata)
+		return
+	}
+	_, c := findCatalog(e.Metadata, flavor)
+	if c == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Fields)
+}
+
+func (s *Server) updateEventMetadata(w http.ResponseWriter, r *http.Request) {
+	flavor := base.Flavor(r.URL.Query().Get("type"))
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var values []extapiv1.Value
+	if _, err := unmarshalFormValue(r, "metadata", &values); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	_, c := findCatalog(e.Metadata, flavor)
+	if c == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	setValues(c, values)
+	syncEventFromMetadata(&e.Event)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteEventMetadata(w http.ResponseWriter, r *http.Request) {
+	flavor := base.Flavor(r.URL.Query().Get("type"))
+	if flavor == base.DublinCoreEpisodeFlavor {
+		http.Error(w, "the main catalog cannot be deleted", http.StatusForbidden)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	i, _ := findCatalog(e.Metadata, flavor)
+	if i < 0 {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	e.Metadata = slices.Delete(e.Metadata, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listEventPublications(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	publications := e.Publications
+	if publications == nil {
+		publications = []extapiv1.Publication{}
+	}
+	writeJSON(w, http.StatusOK, publications)
+}
+
+func (s *Server) getEventPublication(w http.ResponseWriter, r *http.Request) {
+	publicationID := r.PathValue("publicationID")
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	i := slices.IndexFunc(e.Publications, func(p extapiv1.Publication) bool { return p.ID == publicationID })
+	if i < 0 {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, e.Publications[i])
+}
+
+func (s *Server) getEventScheduling(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if e.Scheduling.Start.IsZero() {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, e.Scheduling)
+}
+
+func (s *Server) updateEventScheduling(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var scheduling extapiv1.SchedulingRequest
+	if ok, err := unmarshalFormValue(r, "scheduling", &scheduling); err != nil || !ok {
+		http.Error(w, "scheduling is required", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := s.findEvent(r)
+	if e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if e.Scheduling.Start.IsZero() {
+		http.Error(w, "event is not scheduled", http.StatusBadRequest)
+		return
+	}
+	sch := schedulingFromRequest(scheduling)
+	if sch.Start.IsZero() {
+		sch.Start = e.Scheduling.Start
+	}
+	if sch.End.IsZero() {
+		sch.End = e.Scheduling.End
+	}
+	if sch.AgentID == "" {
+		sch.AgentID = e.Scheduling.AgentID
+	}
+	if sch.Inputs == nil {
+		sch.Inputs = e.Scheduling.Inputs
+	}
+	e.Scheduling = sch
+	e.Start = sch.Start
+	w.WriteHeader(http.StatusNoContent)
+}
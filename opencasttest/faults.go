@@ -0,0 +1,165 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+)
+
+// Fault describes a misbehavior injected into matching requests.
+type Fault struct {
+	// Method to match. Empty matches all methods.
+	Method string
+	// Path pattern to match as understood by [path.Match]. Empty matches all
+	// paths.
+	Path string
+
+	// Latency is added before the request is handled.
+	Latency time.Duration
+	// StatusCode is returned instead of handling the request, if set.
+	StatusCode int
+	// Header is added to the response of StatusCode.
+	Header http.Header
+	// Body is returned together with StatusCode, as plain text unless Header
+	// sets the Content-Type.
+	Body string
+	// Drop closes the connection without responding.
+	Drop bool
+
+	// Times limits how often the fault is applied. Zero means unlimited.
+	Times int
+}
+
+type faultRule struct {
+	Fault
+	applied int
+}
+
+// InjectFault registers f and returns a function removing it again.
+func (s *Server) InjectFault(f Fault) (remove func()) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	rule := &faultRule{Fault: f}
+	s.faults = append(s.faults, rule)
+	return func() {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		s.faults = slices.DeleteFunc(s.faults, func(r *faultRule) bool { return r == rule })
+	}
+}
+
+func (s *Server) InjectLatency(method, pathPattern string, latency time.Duration) (remove func()) {
+	return s.InjectFault(Fault{Method: method, Path: pathPattern, Latency: latency})
+}
+
+func (s *Server) InjectStatus(method, pathPattern string, statusCode int) (remove func()) {
+	return s.InjectFault(Fault{Method: method, Path: pathPattern, StatusCode: statusCode})
+}
+
+func (s *Server) InjectFailure(method, pathPattern string) (remove func()) {
+	return s.InjectFault(Fault{Method: method, Path: pathPattern, Drop: true})
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.faults = nil
+}
+
+// applyFaults applies matching faults and reports whether the request was
+// answered.
+func (s *Server) applyFaults(w http.ResponseWriter, r *http.Request) bool {
+	var matched []Fault
+
+	s.mtx.Lock()
+	for _, rule := range s.faults {
+		if !rule.matches(r) {
+			continue
+		}
+		rule.applied++
+		matched = append(matched, rule.Fault)
+	}
+	s.faults = slices.DeleteFunc(s.faults, func(rule *faultRule) bool {
+		return rule.Times > 0 && rule.applied >= rule.Times
+	})
+	s.mtx.Unlock()
+
+	for _, f := range matched {
+		if f.Latency > 0 {
+			t := time.NewTimer(f.Latency)
+			select {
+			case <-r.Context().Done():
+				t.Stop()
+				return true
+			case <-t.C:
+			}
+		}
+
+		if f.Drop {
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				panic(http.ErrAbortHandler)
+			}
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				panic(http.ErrAbortHandler)
+			}
+			_ = conn.Close()
+			return true
+		}
+
+		if f.StatusCode != 0 {
+			for k, v := range f.Header {
+				w.Header()[k] = v
+			}
+			body := f.Body
+			if body == "" {
+				body = http.StatusText(f.StatusCode)
+			}
+			if f.Header.Get("Content-Type") == "" {
+				http.Error(w, body, f.StatusCode)
+				return true
+			}
+			w.WriteHeader(f.StatusCode)
+			_, _ = io.WriteString(w, body)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rule *faultRule) matches(r *http.Request) bool {
+	if rule.Times > 0 && rule.applied >= rule.Times {
+		return false
+	}
+	if rule.Method != "" && rule.Method != r.Method {
+		return false
+	}
+	if rule.Path != "" {
+		ok, err := path.Match(rule.Path, r.URL.Path)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/assetmanager"
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+	"shio.solutions/tales.media/opencast-client-go/apis/userdirectory"
+)
+
+// Fixtures describe the initial state of a [Server]. Fixtures can be loaded
+// from JSON files using [LoadFixtures].
+type Fixtures struct {
+	Organization        *extapiv1.Organization        `json:"organization,omitempty"`
+	OrganizationProps   base.Properties               `json:"organizationProperties,omitempty"`
+	Me                  *extapiv1.Me                  `json:"me,omitempty"`
+	Roles               []string                      `json:"roles,omitempty"`
+	Events              []EventFixture                `json:"events,omitempty"`
+	Series              []SeriesFixture               `json:"series,omitempty"`
+	Groups              []extapiv1.Group              `json:"groups,omitempty"`
+	Playlists           []extapiv1.Playlist           `json:"playlists,omitempty"`
+	Workflows           []extapiv1.WorkflowInstance   `json:"workflows,omitempty"`
+	WorkflowDefinitions []extapiv1.WorkflowDefinition `json:"workflowDefinitions,omitempty"`
+	Agents              []extapiv1.Agent              `json:"agents,omitempty"`
+	ListProviders       map[string]base.Properties    `json:"listProviders,omitempty"`
+	Users               []UserFixture                 `json:"users,omitempty"`
+	Themes              []themes.Theme                `json:"themes,omitempty"`
+}
+
+type EventFixture struct {
+	extapiv1.Event
+	Media []extapiv1.MediaTrackElement `json:"media,omitempty"`
+}
+
+type SeriesFixture struct {
+	extapiv1.Series
+	Metadata   []extapiv1.Catalog `json:"metadata,omitempty"`
+	Properties base.Properties    `json:"properties,omitempty"`
+}
+
+type UserFixture struct {
+	userdirectory.User
+	Password string `json:"password,omitempty"`
+}
+
+func LoadFixtures(path string) (*Fixtures, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &Fixtures{}
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+type state struct {
+	organization        extapiv1.Organization
+	organizationProps   base.Properties
+	me                  extapiv1.Me
+	roles               []string
+	events              []*EventFixture
+	series              []*SeriesFixture
+	groups              []*extapiv1.Group
+	playlists           []*extapiv1.Playlist
+	workflows           []*extapiv1.WorkflowInstance
+	workflowDefinitions []*extapiv1.WorkflowDefinition
+	agents              []*extapiv1.Agent
+	listProviders       map[string]base.Properties
+	users               []*UserFixture
+	themes              []*themes.Theme
+
+	agentConfigs    map[string]base.Properties // by agent
+	recordingStates map[string]*recordingState // by event
+
+	files           map[string][]byte                   // by element URL
+	snapshots       map[string][]*assetmanager.Snapshot // by media package
+	assetProperties map[string][]assetmanager.Property  // by media package
+	staticFiles     map[string][]byte                   // by ID
+}
+
+func newState() state {
+	return state{
+		organization: extapiv1.Organization{
+			ID:            "mh_default_org",
+			Name:          "Opencast Project",
+			AdminRole:     "ROLE_ADMIN",
+			AnonymousRole: "ROLE_ANONYMOUS",
+		},
+		organizationProps: base.Properties{
+			"org.opencastproject.engage.ui.url": "http://localhost:8080",
+		},
+		me: extapiv1.Me{
+			Username: DefaultUsername,
+			Name:     "Opencast Project Administrator",
+			Email:    "admin@localhost",
+			UserRole: "ROLE_USER_ADMIN",
+			Provider: "opencast",
+		},
+		roles: []string{
+			"ROLE_ADMIN",
+			"ROLE_ANONYMOUS",
+			"ROLE_SUDO",
+			"ROLE_USER",
+			"ROLE_USER_ADMIN",
+		},
+		listProviders: map[string]base.Properties{
+			"LANGUAGES": {
+				extapiv1.EnglishLanguage: "LANGUAGES.ENG",
+				extapiv1.GermanLanguage:  "LANGUAGES.DEU",
+			},
+			"LICENSES": {
+				extapiv1.AllRightsReservedLicense: "EVENTS.LICENSE.ALLRIGHTS",
+				extapiv1.CCBYLicense:              "EVENTS.LICENSE.CCBY",
+			},
+		},
+		users: []*UserFixture{{
+			User: userdirectory.User{
+				Username: DefaultUsername,
+				Name:     "Opencast Project Administrator",
+				Email:    "admin@localhost",
+				Provider: "system",
+				Roles: []userdirectory.Role{
+					{Name: "ROLE_ADMIN", Type: userdirectory.SystemRoleType},
+					{Name: "ROLE_SUDO", Type: userdirectory.SystemRoleType},
+				},
+			},
+		}},
+		agentConfigs:    make(map[string]base.Properties),
+		recordingStates: make(map[string]*recordingState),
+
+		files:           make(map[string][]byte),
+		snapshots:       make(map[string][]*assetmanager.Snapshot),
+		assetProperties: make(map[string][]assetmanager.Property),
+		staticFiles:     make(map[string][]byte),
+	}
+}
+
+func (st *state) seed(f *Fixtures) {
+	if f == nil {
+		return
+	}
+	if f.Organization != nil {
+		st.organization = *f.Organization
+	}
+	for k, v := range f.OrganizationProps {
+		st.organizationProps[k] = v
+	}
+	if f.Me != nil {
+		st.me = *f.Me
+	}
+	if f.Roles != nil {
+		st.roles = slices.Clone(f.Roles)
+	}
+	for _, e := range f.Events {
+		st.events = append(st.events, &e)
+	}
+	for _, s := range f.Series {
+		st.series = append(st.series, &s)
+	}
+	for _, g := range f.Groups {
+		st.groups = append(st.groups, &g)
+	}
+	for _, p := range f.Playlists {
+		st.playlists = append(st.playlists, &p)
+	}
+	for _, w := range f.Workflows {
+		st.workflows = append(st.workflows, &w)
+	}
+	for _, wd := range f.WorkflowDefinitions {
+		st.workflowDefinitions = append(st.workflowDefinitions, &wd)
+	}
+	for _, a := range f.Agents {
+		st.agents = append(st.agents, &a)
+	}
+	for k, v := range f.ListProviders {
+		st.listProviders[k] = v
+	}
+	for _, u := range f.Users {
+		st.users = append(st.users, &u)
+	}
+	for _, t := range f.Themes {
+		st.themes = append(st.themes, &t)
+	}
+}
+
+func find[T any](list []*T, match func(*T) bool) (int, *T) {
+	for i, v := range list {
+		if match(v) {
+			return i, v
+		}
+	}
+	return -1, nil
+}
+
+func values[T any](list []*T) []T {
+	l := make([]T, 0, len(list))
+	for _, v := range list {
+		l = append(l, *v)
+	}
+	return l
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+)
+
+func (s *Server) registerGroups() {
+	s.mux.HandleFunc("GET /api/groups", s.listGroups)
+	s.mux.HandleFunc("POST /api/groups", s.createGroup)
+	s.mux.HandleFunc("GET /api/groups/{id}", s.getGroup)
+	s.mux.HandleFunc("POST /api/groups/{id}", s.updateGroup)
+	s.mux.HandleFunc("DELETE /api/groups/{id}", s.deleteGroup)
+	s.mux.HandleFunc("POST /api/groups/{id}/members", s.createGroupMember)
+	s.mux.HandleFunc("DELETE /api/groups/{id}/members/{memberID}", s.deleteGroupMember)
+}
+
+func (s *Server) findGroup(r *http.Request) (int, *extapiv1.Group) {
+	// s.mtx is assumed to be locked
+	id := r.PathValue("id")
+	return find(s.st.groups, func(g *extapiv1.Group) bool { return g.Identifier == id })
+}
+
+// groupIdentifier derives the group identifier from the name the same way
+// Opencast does.
+func groupIdentifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, name)
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	l := strings.Split(s, ",")
+	for i := range l {
+		l[i] = strings.TrimSpace(l[i])
+	}
+	return l
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	groups := make([]extapiv1.Group, 0, len(s.st.groups))
+	for _, g := range s.st.groups {
+		if name, ok := filter["name"]; ok && !containsFold(g.Name, name) {
+			continue
+		}
+		groups = append(groups, *g)
+	}
+	s.mtx.RUnlock()
+
+	by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), ":")
+	var key func(g extapiv1.Group) string
+	switch by {
+	case "name":
+		key = func(g extapiv1.Group) string { return g.Name }
+	case "description":
+		key = func(g extapiv1.Group) string { return g.Description }
+	case "role":
+		key = func(g extapiv1.Group) string { return g.Role }
+	}
+	if key != nil {
+		slices.SortStableFunc(groups, func(a, b extapiv1.Group) int {
+			if strings.EqualFold(dir, "DESC") {
+				return strings.Compare(key(b), key(a))
+			}
+			return strings.Compare(key(a), key(b))
+		})
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, groups))
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := r.FormValue("name")
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	id := groupIdentifier(name)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, g := find(s.st.groups, func(g *extapiv1.Group) bool { return g.Identifier == id }); g != nil {
+		writeStatus(w, http.StatusConflict)
+		return
+	}
+	s.st.groups = append(s.st.groups, &extapiv1.Group{
+		Identifier:   id,
+		Organization: s.st.organization.ID,
+		Role:         "ROLE_GROUP_" + strings.ToUpper(id),
+		Name:         name,
+		Description:  r.FormValue("description"),
+		Roles:        strings.Join(splitList(r.FormValue("roles")), ","),
+		Members:      strings.Join(splitList(r.FormValue("members")), ","),
+	})
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, g := s.findGroup(r)
+	if g == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, g := s.findGroup(r)
+	if g == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if r.Form.Has("name") {
+		g.Name = r.FormValue("name")
+	}
+	if r.Form.Has("description") {
+		g.Description = r.FormValue("description")
+	}
+	if r.Form.Has("roles") {
+		g.Roles = strings.Join(splitList(r.FormValue("roles")), ",")
+	}
+	if r.Form.Has("members") {
+		g.Members = strings.Join(splitList(r.FormValue("members")), ",")
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, g := s.findGroup(r)
+	if g == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.groups = slices.Delete(s.st.groups, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createGroupMember(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	member := r.FormValue("member")
+	if member == "" {
+		http.Error(w, "member is required", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, g := s.findGroup(r)
+	if g == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	members := splitList(g.Members)
+	if slices.Contains(members, member) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	g.Members = strings.Join(append(members, member), ",")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteGroupMember(w http.ResponseWriter, r *http.Request) {
+	memberID := r.PathValue("memberID")
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, g := s.findGroup(r)
+	if g == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	members := splitList(g.Members)
+	i := slices.Index(members, memberID)
+	if i < 0 {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	g.Members = strings.Join(slices.Delete(members, i, i+1), ",")
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func (s *Server) registerBase() {
+	s.mux.HandleFunc("GET /api/{$}", s.getAPI)
+	s.mux.HandleFunc("GET /api/version", s.getAPIVersion)
+	s.mux.HandleFunc("GET /api/default", s.getAPIVersionDefault)
+	s.mux.HandleFunc("POST /api/security/sign", s.signURL)
+	s.mux.HandleFunc("GET /api/listproviders/providers.json", s.listListProviders)
+	s.mux.HandleFunc("GET /api/listproviders/{source}", s.getListProvider)
+}
+
+func (s *Server) registerInfo() {
+	s.mux.HandleFunc("GET /api/info/organization", s.getInfoOrganization)
+	s.mux.HandleFunc("GET /api/info/organization/properties", s.getInfoOrganizationProperties)
+	s.mux.HandleFunc("GET /api/info/organization/properties/engageuiurl", s.getInfoOrganizationPropertiesEngageUIURL)
+	s.mux.HandleFunc("GET /api/info/me", s.getInfoMe)
+	s.mux.HandleFunc("GET /api/info/me/roles", s.getInfoMeRoles)
+}
+
+func (s *Server) getAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, extapiv1.API{
+		Version: s.apiVersions[len(s.apiVersions)-1],
+		URL:     s.URL + "/api",
+	})
+}
+
+func (s *Server) getAPIVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, extapiv1.APIVersion{
+		Default:  s.apiVersions[len(s.apiVersions)-1],
+		Versions: s.apiVersions,
+	})
+}
+
+func (s *Server) getAPIVersionDefault(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, extapiv1.APIVersion{
+		Default: s.apiVersions[len(s.apiVersions)-1],
+	})
+}
+
+func (s *Server) getInfoOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	writeJSON(w, http.StatusOK, s.st.organization)
+}
+
+func (s *Server) getInfoOrganizationProperties(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	writeJSON(w, http.StatusOK, s.st.organizationProps)
+}
+
+func (s *Server) getInfoOrganizationPropertiesEngageUIURL(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	const key = "org.opencastproject.engage.ui.url"
+	writeJSON(w, http.StatusOK, base.Properties{key: s.st.organizationProps[key]})
+}
+
+func (s *Server) getInfoMe(w http.ResponseWriter, r *http.Request) {
+	if username, _, ok := runAs(r); ok && username != "" {
+		writeJSON(w, http.StatusOK, extapiv1.Me{Username: username, Name: username})
+		return
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	writeJSON(w, http.StatusOK, s.st.me)
+}
+
+func (s *Server) getInfoMeRoles(w http.ResponseWriter, r *http.Request) {
+	if _, roles, ok := runAs(r); ok {
+		writeJSON(w, http.StatusOK, append([]string{}, roles...))
+		return
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	writeJSON(w, http.StatusOK, s.st.roles)
+}
+
+func (s *Server) signURL(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rawURL := r.FormValue("url")
+	u, err := url.Parse(rawURL)
+	if rawURL == "" || err != nil {
+		writeJSON(w, http.StatusOK, extapiv1.SignedURL{Error: extapiv1.URLCannotBeSignedError})
+		return
+	}
+
+	validUntil := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	if v := r.FormValue("valid-until"); v != "" {
+		validUntil, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	q := u.Query()
+	q.Set("policy", "fake-policy")
+	q.Set("keyId", "fake-key")
+	q.Set("signature", "fake-signature")
+	u.RawQuery = q.Encode()
+
+	writeJSON(w, http.StatusOK, extapiv1.SignedURL{
+		URL:        u.String(),
+		ValidUntil: base.DateTime{Time: validUntil},
+	})
+}
+
+func (s *Server) listListProviders(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	providers := make([]string, 0, len(s.st.listProviders))
+	for k := range s.st.listProviders {
+		providers = append(providers, k)
+	}
+	slices.Sort(providers)
+	writeJSON(w, http.StatusOK, [][]string{providers})
+}
+
+func (s *Server) getListProvider(w http.ResponseWriter, r *http.Request) {
+	source := strings.TrimSuffix(r.PathValue("source"), ".json")
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	props, ok := s.st.listProviders[source]
+	if !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, props)
+}
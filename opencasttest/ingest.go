@@ -0,0 +1,235 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+// DefaultIngestWorkflow is started for ingested media packages if no workflow
+// definition is given.
+const DefaultIngestWorkflow = "fast"
+
+// registerIngest serves the ingest service. Ingesting a media package with the identifier of a scheduled event adds the
+// tracks to the event.
+func (s *Server) registerIngest() {
+	s.mux.HandleFunc("GET /ingest/createMediaPackage", s.createMediaPackage)
+	s.mux.HandleFunc("PUT /ingest/createMediaPackageWithID/{id}", s.createMediaPackage)
+	s.mux.HandleFunc("POST /ingest/addDCCatalog", s.addDCCatalog)
+	s.mux.HandleFunc("POST /ingest/addTrack", s.addTrack)
+	s.mux.HandleFunc("POST /ingest/ingest", s.ingest)
+	s.mux.HandleFunc("POST /ingest/ingest/{wdID}", s.ingest)
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// formMediaPackage parses the mediaPackage form value. The form must be
+// parsed.
+func formMediaPackage(r *http.Request) (*mediapackage.MediaPackage, error) {
+	mp := &mediapackage.MediaPackage{}
+	if err := xml.Unmarshal([]byte(r.FormValue("mediaPackage")), mp); err != nil {
+		return nil, err
+	}
+	if mp.ID == "" {
+		return nil, fmt.Errorf("media package without identifier")
+	}
+	return mp, nil
+}
+
+func (s *Server) createMediaPackage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		id = s.newIdentifier()
+	}
+	writeXML(w, http.StatusOK, &mediapackage.MediaPackage{ID: id})
+}
+
+func (s *Server) elementURL(mp *mediapackage.MediaPackage, elementID, filename string) string {
+	return s.URL + "/files/mediapackage/" + mp.ID + "/" + elementID + "/" + filename
+}
+
+func (s *Server) addDCCatalog(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mp, err := formMediaPackage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.FormValue("dublinCore") == "" {
+		http.Error(w, "dublinCore is required", http.StatusBadRequest)
+		return
+	}
+	flavor := base.Flavor(r.FormValue("flavor"))
+	if flavor == "" {
+		flavor = base.DublinCoreEpisodeFlavor
+	}
+
+	id := s.newIdentifier()
+	u := s.elementURL(mp, id, "dublincore.xml")
+	s.mtx.Lock()
+	s.st.files[u] = []byte(r.FormValue("dublinCore"))
+	s.mtx.Unlock()
+	if mp.Metadata == nil {
+		mp.Metadata = &mediapackage.Metadata{}
+	}
+	mp.Metadata.Catalog = append(mp.Metadata.Catalog, mediapackage.Catalog{Element: mediapackage.Element{
+		ID:       id,
+		Flavor:   flavor,
+		MimeType: "text/xml",
+		URL:      u,
+		Size:     new(base.Int(len(r.FormValue("dublinCore")))),
+	}})
+	writeXML(w, http.StatusOK, mp)
+}
+
+func (s *Server) addTrack(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mp, err := formMediaPackage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !hasFile(r, "BODY") {
+		http.Error(w, "BODY is required", http.StatusBadRequest)
+		return
+	}
+	fh := r.MultipartForm.File["BODY"][0]
+	b, err := readFile(fh)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := s.newIdentifier()
+	u := s.elementURL(mp, id, fh.Filename)
+	s.mtx.Lock()
+	s.st.files[u] = b
+	s.mtx.Unlock()
+	track := mediapackage.Track{Element: mediapackage.Element{
+		ID:       id,
+		Flavor:   base.Flavor(r.FormValue("flavor")),
+		MimeType: fh.Header.Get("Content-Type"),
+		URL:      u,
+		Size:     new(base.Int(fh.Size)),
+	}}
+	if tags := r.Form["tags"]; len(tags) > 0 {
+		track.Tags = &mediapackage.Tags{Tag: tags}
+	}
+	if mp.Media == nil {
+		mp.Media = &mediapackage.Media{}
+	}
+	mp.Media.Track = append(mp.Media.Track, track)
+	writeXML(w, http.StatusOK, mp)
+}
+
+func readFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return io.ReadAll(f)
+}
+
+type ingestedWorkflow struct {
+	XMLName      xml.Name `xml:"http://workflow.opencastproject.org workflow"`
+	ID           base.Int `xml:"id,attr"`
+	State        string   `xml:"state,attr"`
+	Template     string   `xml:"template"`
+	MediaPackage *mediapackage.MediaPackage
+}
+
+func (s *Server) ingest(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mp, err := formMediaPackage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	wdID := r.PathValue("wdID")
+	if wdID == "" {
+		wdID = DefaultIngestWorkflow
+	}
+	config := base.Properties{}
+	for k := range r.Form {
+		if k != "mediaPackage" {
+			config[k] = r.FormValue(k)
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == mp.ID })
+	if e == nil {
+		e = &EventFixture{}
+		e.Identifier = mp.ID
+		e.Title = mp.Title
+		e.IsPartOf = mp.Series
+		e.Presenter = mp.CreatorList()
+		s.st.events = append(s.st.events, e)
+	}
+	for _, t := range mp.Tracks() {
+		e.Media = append(e.Media, extapiv1.MediaTrackElement{
+			Identifier: new(t.ID),
+			Flavor:     new(t.Flavor),
+			MimeType:   new(t.MimeType),
+			URI:        new(t.URL),
+			Size:       *t.Size,
+			HasVideo:   true,
+			HasAudio:   true,
+			Tags:       t.TagList(),
+		})
+	}
+	e.Status = extapiv1.ProcessingEventStatus
+	s.takeSnapshot(mp)
+	wf := s.startWorkflow(e.Identifier, wdID, config)
+
+	writeXML(w, http.StatusOK, &ingestedWorkflow{
+		ID:           wf.Identifier,
+		State:        "RUNNING",
+		Template:     wdID,
+		MediaPackage: mp,
+	})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"strconv"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+)
+
+func (s *Server) seriesTitle(id string) string {
+	// s.mtx is assumed to be locked
+	if _, sf := find(s.st.series, func(sf *SeriesFixture) bool { return sf.Identifier == id }); sf != nil {
+		return sf.Title
+	}
+	return ""
+}
+
+// mediaPackage returns the media package of the event including its
+// publications.
+func (s *Server) mediaPackage(e *EventFixture) *mediapackage.MediaPackage {
+	// s.mtx is assumed to be locked
+	mp := &mediapackage.MediaPackage{
+		ID:          e.Identifier,
+		Start:       e.Start,
+		Duration:    e.Duration,
+		Title:       e.Title,
+		Series:      e.IsPartOf,
+		SeriesTitle: s.seriesTitle(e.IsPartOf),
+		License:     e.License,
+		Language:    e.Language,
+	}
+	if len(e.Presenter) > 0 {
+		mp.Creators = &mediapackage.Creators{Creator: e.Presenter}
+	}
+	if len(e.Contributor) > 0 {
+		mp.Contributors = &mediapackage.Contributors{Contributor: e.Contributor}
+	}
+	if len(e.Subjects) > 0 {
+		mp.Subjects = &mediapackage.Subjects{Subject: e.Subjects}
+	}
+	var pubs []mediapackage.Publication
+	for _, p := range e.Publications {
+		if p.Channel == extapiv1.InternalChannel {
+			continue
+		}
+		pub := mediapackage.Publication{
+			ID:       p.ID,
+			Channel:  p.Channel,
+			MimeType: p.MediaType,
+			URL:      p.URL,
+		}
+		if len(p.Media) > 0 {
+			pub.Media = &mediapackage.Media{}
+			for _, t := range p.Media {
+				pub.Media.Track = append(pub.Media.Track, publishedTrack(t))
+			}
+		}
+		pubs = append(pubs, pub)
+	}
+	if len(pubs) > 0 {
+		mp.Publications = &mediapackage.Publications{Publication: pubs}
+	}
+	return mp
+}
+
+func publishedTrack(t extapiv1.TrackElement) mediapackage.Track {
+	track := mediapackage.Track{
+		Element: mediapackage.Element{
+			ID:       t.ID,
+			Flavor:   t.Flavor,
+			MimeType: t.MediaType,
+			URL:      t.URL,
+		},
+		Duration: t.Duration,
+		Live:     t.IsLive,
+	}
+	if t.Size != 0 {
+		track.Size = &t.Size
+	}
+	if len(t.Tags) > 0 {
+		track.Tags = &mediapackage.Tags{Tag: t.Tags}
+	}
+	if t.HasAudio {
+		track.Audio = &mediapackage.AudioStream{}
+	}
+	if t.HasVideo {
+		track.Video = &mediapackage.VideoStream{
+			BitRate:   t.BitRate,
+			FrameRate: t.FrameRate,
+		}
+		if t.Width != nil && t.Height != nil {
+			track.Video.Resolution = strconv.Itoa(int(*t.Width)) + "x" + strconv.Itoa(int(*t.Height))
+		}
+	}
+	return track
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"slices"
+	"strings"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+// rawCatalog is used to parse catalogs send by clients, which commonly omit
+// the field type.
+type rawCatalog struct {
+	Label  string           `json:"label,omitempty"`
+	Flavor base.Flavor      `json:"flavor,omitempty"`
+	Fields []extapiv1.Value `json:"fields,omitempty"`
+}
+
+var episodeFields = []extapiv1.Field{
+	{ID: extapiv1.TitleFieldID, Label: extapiv1.TitleFieldLabel, Type: extapiv1.TextFieldType, Required: true},
+	{ID: extapiv1.SubjectsFieldID, Label: extapiv1.SubjectsFieldLabel, Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.DescriptionFieldID, Label: extapiv1.DescriptionFieldLabel, Type: extapiv1.TextLongFieldType},
+	{ID: extapiv1.LanguageFieldID, Label: extapiv1.LanguageFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.RightsHolderFieldID, Label: extapiv1.RightsHolderFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.LicenseFieldID, Label: extapiv1.LicenseFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.IsPartOfFieldID, Label: extapiv1.IsPartOfFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.CreatorFieldID, Label: extapiv1.CreatorFieldLabel, Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.ContributorFieldID, Label: extapiv1.ContributorFieldLabel, Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.StartDateFieldID, Label: extapiv1.StartDateFieldLabel, Type: extapiv1.DateFieldType},
+	{ID: extapiv1.LocationFieldID, Label: extapiv1.LocationFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.SourceFieldID, Label: extapiv1.SourceFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.CreatedFieldID, Label: extapiv1.CreatedFieldLabel, Type: extapiv1.DateFieldType},
+	{ID: extapiv1.PublisherFieldID, Label: extapiv1.PublisherFieldLabel, Type: extapiv1.TextFieldType},
+	{ID: extapiv1.IdentifierFieldID, Label: extapiv1.IdentifierFieldLabel, Type: extapiv1.TextFieldType, ReadOnly: true},
+}
+
+var seriesFields = []extapiv1.Field{
+	{ID: extapiv1.TitleFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.TITLE", Type: extapiv1.TextFieldType, Required: true},
+	{ID: extapiv1.SubjectsFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.SUBJECT", Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.DescriptionFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.DESCRIPTION", Type: extapiv1.TextLongFieldType},
+	{ID: extapiv1.LanguageFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.LANGUAGE", Type: extapiv1.TextFieldType},
+	{ID: extapiv1.RightsHolderFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.RIGHTS", Type: extapiv1.TextFieldType},
+	{ID: extapiv1.LicenseFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.LICENSE", Type: extapiv1.TextFieldType},
+	{ID: extapiv1.CreatorFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.ORGANIZERS", Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.ContributorFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.CONTRIBUTORS", Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.PublisherFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.PUBLISHERS", Type: extapiv1.MixedTextFieldType},
+	{ID: extapiv1.IdentifierFieldID, Label: "EVENTS.SERIES.DETAILS.METADATA.ID", Type: extapiv1.TextFieldType, ReadOnly: true},
+}
+
+func newCatalog(flavor base.Flavor, label string, template []extapiv1.Field) extapiv1.Catalog {
+	fields := slices.Clone(template)
+	for i := range fields {
+		fields[i].Value = normalizeValue(fields[i].Type, nil)
+	}
+	return extapiv1.Catalog{
+		Label:  label,
+		Flavor: flavor,
+		Fields: fields,
+	}
+}
+
+func findCatalog(catalogs []extapiv1.Catalog, flavor base.Flavor) (int, *extapiv1.Catalog) {
+	for i := range catalogs {
+		if catalogs[i].Flavor == flavor {
+			return i, &catalogs[i]
+		}
+	}
+	return -1, nil
+}
+
+// setValues updates the fields of the catalog. Unknown fields are added.
+func setValues(c *extapiv1.Catalog, values []extapiv1.Value) {
+	for _, v := range values {
+		i := slices.IndexFunc(c.Fields, func(f extapiv1.Field) bool { return f.ID == v.ID })
+		if i < 0 {
+			f := extapiv1.Field{ID: v.ID, Type: extapiv1.TextFieldType}
+			if _, ok := v.Value.([]any); ok {
+				f.Type = extapiv1.IterableTextFieldType
+			}
+			c.Fields = append(c.Fields, f)
+			i = len(c.Fields) - 1
+		}
+		c.Fields[i].Value = normalizeValue(c.Fields[i].Type, v.Value)
+	}
+}
+
+func normalizeValue(t extapiv1.FieldType, v any) any {
+	switch t {
+	case extapiv1.MixedTextFieldType, extapiv1.IterableTextFieldType:
+		return fieldStrings(v)
+	case extapiv1.BooleanFieldType:
+		if b, ok := v.(bool); ok {
+			return b
+		}
+		return fieldString(v) == "true"
+	case extapiv1.NumberFieldType:
+		if v == nil {
+			return 0
+		}
+		return v
+	default:
+		// Opencast never omits a value, blank fields are reported as
+		// empty string.
+		if v == nil {
+			return ""
+		}
+		return v
+	}
+}
+
+func fieldValue(c *extapiv1.Catalog, id string) any {
+	if c == nil {
+		return nil
+	}
+	for _, f := range c.Fields {
+		if f.ID == id {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+func fieldString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case extapiv1.TextFieldValue:
+		return string(v)
+	case extapiv1.TextLongFieldValue:
+		return string(v)
+	case extapiv1.OrderedTextFieldValue:
+		return string(v)
+	case base.DateTime:
+		if v.IsZero() {
+			return ""
+		}
+		s, _ := v.MarshalText()
+		return string(s)
+	case []any, []string, extapiv1.MixedTextFieldValue, extapiv1.IterableTextFieldValue:
+		return strings.Join(fieldStrings(v), ", ")
+	default:
+		return ""
+	}
+}
+
+func fieldStrings(v any) []string {
+	switch v := v.(type) {
+	case nil:
+		return []string{}
+	case []string:
+		return v
+	case extapiv1.MixedTextFieldValue:
+		return v
+	case extapiv1.IterableTextFieldValue:
+		return v
+	case []any:
+		l := make([]string, 0, len(v))
+		for _, e := range v {
+			l = append(l, fieldString(e))
+		}
+		return l
+	default:
+		s := fieldString(v)
+		if s == "" {
+			return []string{}
+		}
+		return []string{s}
+	}
+}
+
+func fieldDateTime(v any) base.DateTime {
+	switch v := v.(type) {
+	case base.DateTime:
+		return v
+	default:
+		dt := base.DateTime{}
+		_ = dt.UnmarshalText([]byte(fieldString(v)))
+		return dt
+	}
+}
+
+func syncEventFromMetadata(e *extapiv1.Event) {
+	_, c := findCatalog(e.Metadata, base.DublinCoreEpisodeFlavor)
+	if c == nil {
+		return
+	}
+	e.Title = fieldString(fieldValue(c, extapiv1.TitleFieldID))
+	e.Description = fieldString(fieldValue(c, extapiv1.DescriptionFieldID))
+	e.Subjects = fieldStrings(fieldValue(c, extapiv1.SubjectsFieldID))
+	e.Language = fieldString(fieldValue(c, extapiv1.LanguageFieldID))
+	e.RightsHolder = fieldString(fieldValue(c, extapiv1.RightsHolderFieldID))
+	e.License = fieldString(fieldValue(c, extapiv1.LicenseFieldID))
+	e.IsPartOf = fieldString(fieldValue(c, extapiv1.IsPartOfFieldID))
+	e.Presenter = fieldStrings(fieldValue(c, extapiv1.CreatorFieldID))
+	e.Contributor = fieldStrings(fieldValue(c, extapiv1.ContributorFieldID))
+	e.Location = fieldString(fieldValue(c, extapiv1.LocationFieldID))
+	e.Source = fieldString(fieldValue(c, extapiv1.SourceFieldID))
+	if start := fieldDateTime(fieldValue(c, extapiv1.StartDateFieldID)); !start.IsZero() {
+		e.Start = start
+	}
+}
+
+func syncSeriesFromMetadata(s *SeriesFixture) {
+	_, c := findCatalog(s.Metadata, base.DublinCoreSeriesFlavor)
+	if c == nil {
+		return
+	}
+	s.Title = fieldString(fieldValue(c, extapiv1.TitleFieldID))
+	s.Description = fieldString(fieldValue(c, extapiv1.DescriptionFieldID))
+	s.Subjects = fieldStrings(fieldValue(c, extapiv1.SubjectsFieldID))
+	s.Language = fieldString(fieldValue(c, extapiv1.LanguageFieldID))
+	s.RightsHolder = fieldString(fieldValue(c, extapiv1.RightsHolderFieldID))
+	s.License = fieldString(fieldValue(c, extapiv1.LicenseFieldID))
+	s.Organizers = fieldStrings(fieldValue(c, extapiv1.CreatorFieldID))
+	s.Contributors = fieldStrings(fieldValue(c, extapiv1.ContributorFieldID))
+	s.Publishers = fieldStrings(fieldValue(c, extapiv1.PublisherFieldID))
+}
+
+// parseFilter parses the External API filter syntax `key:value,key:value`.
+func parseFilter(raw string) map[string]string {
+	filter := make(map[string]string)
+	if raw == "" {
+		return filter
+	}
+	for _, kv := range strings.Split(raw, ",") {
+		k, v, _ := strings.Cut(kv, ":")
+		filter[k] = v
+	}
+	return filter
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func hasRole(acl extapiv1.ACL, action base.Action, role string) bool {
+	return slices.ContainsFunc(acl, func(ace extapiv1.ACE) bool {
+		return ace.Action == action && ace.Role == role
+	})
+}
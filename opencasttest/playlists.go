@@ -0,0 +1,163 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func (s *Server) registerPlaylists() {
+	s.mux.HandleFunc("GET /api/playlists", s.listPlaylists)
+	s.mux.HandleFunc("POST /api/playlists", s.createPlaylist)
+	s.mux.HandleFunc("GET /api/playlists/{id}", s.getPlaylist)
+	s.mux.HandleFunc("POST /api/playlists/{id}", s.updatePlaylist)
+	s.mux.HandleFunc("DELETE /api/playlists/{id}", s.deletePlaylist)
+}
+
+func (s *Server) findPlaylist(r *http.Request) (int, *extapiv1.Playlist) {
+	// s.mtx is assumed to be locked
+	id := r.PathValue("id")
+	return find(s.st.playlists, func(p *extapiv1.Playlist) bool { return p.ID == id })
+}
+
+// preparePlaylist resolves the entry types the same way Opencast does.
+func (s *Server) preparePlaylist(p *extapiv1.Playlist) {
+	// s.mtx is assumed to be locked
+	for i := range p.Entries {
+		if p.Entries[i].ID == 0 {
+			p.Entries[i].ID = int64(i + 1)
+		}
+		_, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == p.Entries[i].ContentID })
+		if e == nil {
+			p.Entries[i].Type = extapiv1.InaccessiblePlaylistEntryType
+		} else {
+			p.Entries[i].Type = extapiv1.EventPlaylistEntryType
+		}
+	}
+	for i := range p.AccessControlEntries {
+		if p.AccessControlEntries[i].ID == 0 {
+			p.AccessControlEntries[i].ID = int64(i + 1)
+		}
+	}
+	p.Updated = base.DateTime{Time: time.Now().UTC().Truncate(time.Second)}
+}
+
+func (s *Server) listPlaylists(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	playlists := values(s.st.playlists)
+	s.mtx.RUnlock()
+
+	by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), ":")
+	if by == "updated" {
+		slices.SortStableFunc(playlists, func(a, b extapiv1.Playlist) int {
+			if strings.EqualFold(dir, "DESC") {
+				return b.Updated.Time.Compare(a.Updated.Time)
+			}
+			return a.Updated.Time.Compare(b.Updated.Time)
+		})
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, playlists))
+}
+
+func (s *Server) createPlaylist(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p := &extapiv1.Playlist{}
+	if ok, err := unmarshalFormValue(r, "playlist", p); err != nil || !ok {
+		http.Error(w, "playlist is required", http.StatusBadRequest)
+		return
+	}
+	p.ID = s.newIdentifier()
+	if p.Creator == "" {
+		p.Creator = s.currentUsername(r)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.preparePlaylist(p)
+	s.st.playlists = append(s.st.playlists, p)
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) getPlaylist(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, p := s.findPlaylist(r)
+	if p == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) updatePlaylist(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	update := extapiv1.Playlist{}
+	if ok, err := unmarshalFormValue(r, "playlist", &update); err != nil || !ok {
+		http.Error(w, "playlist is required", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, p := s.findPlaylist(r)
+	if p == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if update.Title != "" {
+		p.Title = update.Title
+	}
+	if update.Description != "" {
+		p.Description = update.Description
+	}
+	if update.Creator != "" {
+		p.Creator = update.Creator
+	}
+	if update.Entries != nil {
+		p.Entries = update.Entries
+	}
+	if update.AccessControlEntries != nil {
+		p.AccessControlEntries = update.AccessControlEntries
+	}
+	s.preparePlaylist(p)
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) deletePlaylist(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, p := s.findPlaylist(r)
+	if p == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.playlists = slices.Delete(s.st.playlists, i, i+1)
+	writeJSON(w, http.StatusOK, p)
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"encoding/xml"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/pkg/rrule"
+)
+
+// registerScheduler serves the scheduler service. Events with a capture agent
+// in their scheduling are considered scheduled recordings.
+func (s *Server) registerScheduler() {
+	s.mux.HandleFunc("GET /recordings/search.json", s.searchRecordings)
+	s.mux.HandleFunc("GET /recordings/conflicts.json", s.listConflicts)
+	s.mux.HandleFunc("GET /recordings/calendar", s.getCalendar)
+	s.mux.HandleFunc("POST /recordings", s.createRecording)
+	s.mux.HandleFunc("POST /recordings/multiple", s.createMultipleRecordings)
+	s.mux.HandleFunc("GET /recordings/{id}/mediapackage.xml", s.getRecording)
+	s.mux.HandleFunc("DELETE /recordings/{id}", s.deleteRecording)
+}
+
+func isRecording(e *EventFixture) bool {
+	return e.Scheduling.AgentID != ""
+}
+
+// queryMillis parses the query parameter key given in milliseconds since the
+// epoch.
+func queryMillis(r *http.Request, key string) (time.Time, bool, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return time.Time{}, false, nil
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.UnixMilli(ms), true, nil
+}
+
+func (s *Server) searchRecordings(w http.ResponseWriter, r *http.Request) {
+	agent := r.URL.Query().Get("agent")
+	var ranges [4]time.Time
+	var given [4]bool
+	for i, key := range []string{"startsfrom", "startsto", "endsfrom", "endsto"} {
+		t, ok, err := queryMillis(r, key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ranges[i], given[i] = t, ok
+	}
+	inRange := func(t time.Time, i int) bool {
+		return (!given[i] || !t.Before(ranges[i])) && (!given[i+1] || !t.After(ranges[i+1]))
+	}
+
+	s.mtx.RLock()
+	list := []mediapackage.Envelope{}
+	for _, e := range s.st.events {
+		if !isRecording(e) || (agent != "" && e.Scheduling.AgentID != agent) {
+			continue
+		}
+		if inRange(e.Scheduling.Start.Time, 0) && inRange(e.Scheduling.End.Time, 2) {
+			list = append(list, mediapackage.Envelope{MediaPackage: *s.mediaPackage(e)})
+		}
+	}
+	s.mtx.RUnlock()
+
+	writeJSONContentType(w, jsonContentType, http.StatusOK, list)
+}
+
+// conflicts returns the recordings of agent overlapping the range from start
+// to end.
+func (s *Server) conflicts(agent string, start, end time.Time) []mediapackage.Envelope {
+	// s.mtx is assumed to be locked
+	var list []mediapackage.Envelope
+	for _, e := range s.st.events {
+		if isRecording(e) && e.Scheduling.AgentID == agent &&
+			e.Scheduling.Start.Time.Before(end) && start.Before(e.Scheduling.End.Time) {
+			list = append(list, mediapackage.Envelope{MediaPackage: *s.mediaPackage(e)})
+		}
+	}
+	return list
+}
+
+// recurringConflicts returns the recordings of agent overlapping any of the
+// periods. Every recording is listed once.
+func (s *Server) recurringConflicts(agent string, periods []rrule.Period) []mediapackage.Envelope {
+	// s.mtx is assumed to be locked
+	var list []mediapackage.Envelope
+	for _, p := range periods {
+		for _, c := range s.conflicts(agent, p.Start, p.End) {
+			if !slices.ContainsFunc(list, func(e mediapackage.Envelope) bool { return e.MediaPackage.ID == c.MediaPackage.ID }) {
+				list = append(list, c)
+			}
+		}
+	}
+	return list
+}
+
+// expandRRule returns the recordings from start to end of a recurring
+// scheduling request.
+func expandRRule(rule, duration, tz string, start, end time.Time) ([]rrule.Period, error) {
+	r, err := rrule.Parse(rule)
+	if err != nil {
+		return nil, err
+	}
+	ms, err := strconv.ParseInt(duration, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, err
+		}
+	}
+	return r.Periods(start, end, time.Duration(ms)*time.Millisecond, loc)
+}
+
+func (s *Server) listConflicts(w http.ResponseWriter, r *http.Request) {
+	start, okStart, errStart := queryMillis(r, "start")
+	end, okEnd, errEnd := queryMillis(r, "end")
+	q := r.URL.Query()
+	agent := q.Get("agent")
+	if !okStart || !okEnd || errStart != nil || errEnd != nil || agent == "" {
+		http.Error(w, "agent, start and end are required", http.StatusBadRequest)
+		return
+	}
+	periods := []rrule.Period{{Start: start, End: end}}
+	if q.Get("rrule") != "" {
+		var err error
+		periods, err = expandRRule(q.Get("rrule"), q.Get("duration"), q.Get("timezone"), start, end)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mtx.RLock()
+	list := s.recurringConflicts(agent, periods)
+	s.mtx.RUnlock()
+
+	if len(list) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, list)
+}
+
+func formMillis(r *http.Request, key string) (time.Time, error) {
+	ms, err := strconv.ParseInt(r.FormValue(key), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func (s *Server) createRecording(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	start, errStart := formMillis(r, "start")
+	end, errEnd := formMillis(r, "end")
+	agent := r.FormValue("agent")
+	if errStart != nil || errEnd != nil || agent == "" || !end.After(start) {
+		http.Error(w, "agent, start and end are required", http.StatusBadRequest)
+		return
+	}
+	mp := &mediapackage.MediaPackage{}
+	if err := xml.Unmarshal([]byte(r.FormValue("mediaPackage")), mp); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if list := s.conflicts(agent, start, end); len(list) > 0 {
+		writeJSONContentType(w, jsonContentType, http.StatusConflict, list)
+		return
+	}
+	if mp.ID == "" {
+		mp.ID = s.newIdentifier()
+	}
+	if _, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == mp.ID }); e != nil {
+		http.Error(w, "event already exists", http.StatusConflict)
+		return
+	}
+	s.st.events = append(s.st.events, scheduledEvent(mp, agent, start, end))
+	w.Header().Set("Location", s.URL+"/recordings/"+mp.ID+"/mediapackage.xml")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func scheduledEvent(mp *mediapackage.MediaPackage, agent string, start, end time.Time) *EventFixture {
+	e := &EventFixture{}
+	e.Identifier = mp.ID
+	e.Title = mp.Title
+	e.IsPartOf = mp.Series
+	e.Presenter = mp.CreatorList()
+	e.Contributor = mp.ContributorList()
+	e.Subjects = mp.SubjectList()
+	e.License = mp.License
+	e.Language = mp.Language
+	e.Location = agent
+	e.Start = base.DateTime{Time: start.UTC()}
+	e.Duration = new(base.Int(end.Sub(start).Milliseconds()))
+	e.Status = extapiv1.ScheduledEventStatus
+	e.ProcessingState = extapiv1.UndefinedProcessingState
+	e.Scheduling = extapiv1.Scheduling{
+		Start:   base.DateTime{Time: start.UTC()},
+		End:     base.DateTime{Time: end.UTC()},
+		AgentID: agent,
+	}
+	return e
+}
+
+func (s *Server) createMultipleRecordings(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	start, errStart := formMillis(r, "start")
+	end, errEnd := formMillis(r, "end")
+	agent := r.FormValue("agent")
+	if errStart != nil || errEnd != nil || agent == "" || !end.After(start) {
+		http.Error(w, "agent, start and end are required", http.StatusBadRequest)
+		return
+	}
+	periods, err := expandRRule(r.FormValue("rrule"), r.FormValue("duration"), r.FormValue("tz"), start, end)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(periods) == 0 {
+		http.Error(w, "rrule yields no recordings", http.StatusBadRequest)
+		return
+	}
+	template := &mediapackage.MediaPackage{}
+	if err := xml.Unmarshal([]byte(r.FormValue("templateMp")), template); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if list := s.recurringConflicts(agent, periods); len(list) > 0 {
+		writeJSONContentType(w, jsonContentType, http.StatusConflict, list)
+		return
+	}
+	for _, p := range periods {
+		mp := *template
+		mp.ID = s.newIdentifier()
+		s.st.events = append(s.st.events, scheduledEvent(&mp, agent, p.Start, p.End))
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) getRecording(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	_, e := s.findEvent(r)
+	if e == nil || !isRecording(e) {
+		s.mtx.RUnlock()
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	mp := s.mediaPackage(e)
+	s.mtx.RUnlock()
+
+	b, err := xml.Marshal(mp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	_, _ = w.Write(b)
+}
+
+func (s *Server) deleteRecording(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, e := s.findEvent(r)
+	if e == nil || !isRecording(e) {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.events = slices.Delete(s.st.events, i, i+1)
+	w.WriteHeader(http.StatusOK)
+}
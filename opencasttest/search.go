@@ -0,0 +1,159 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/search"
+)
+
+// registerSearch serves the search service. Events with a publication to the
+// engage player channel are considered published.
+func (s *Server) registerSearch() {
+	s.mux.HandleFunc("GET /search/episode.json", s.searchPublishedEpisodes)
+	s.mux.HandleFunc("GET /search/series.json", s.searchPublishedSeries)
+}
+
+func (s *Server) searchPublishedEpisodes(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mtx.RLock()
+	var results []search.Result
+	for _, e := range s.st.events {
+		if !slices.ContainsFunc(e.Publications, func(p extapiv1.Publication) bool {
+			return p.Channel == extapiv1.EngagePlayerChannel
+		}) {
+			continue
+		}
+		if id := q.Get("id"); id != "" && e.Identifier != id {
+			continue
+		}
+		if sid := q.Get("sid"); sid != "" && e.IsPartOf != sid {
+			continue
+		}
+		if sname := q.Get("sname"); sname != "" && s.seriesTitle(e.IsPartOf) != sname {
+			continue
+		}
+		if text := q.Get("q"); text != "" && !containsFold(e.Title, text) && !containsFold(e.Description, text) {
+			continue
+		}
+		results = append(results, s.episodeResult(e))
+	}
+	s.mtx.RUnlock()
+
+	writeSearchResults(w, r, results)
+}
+
+func (s *Server) episodeResult(e *EventFixture) search.Result {
+	// s.mtx is assumed to be locked
+	mp := s.mediaPackage(e)
+
+	dc := search.DublinCore{
+		search.IdentifierDublinCoreTerm: {e.Identifier},
+		search.TitleDublinCoreTerm:      {e.Title},
+	}
+	if e.Description != "" {
+		dc[search.DescriptionDublinCoreTerm] = []string{e.Description}
+	}
+	if len(e.Presenter) > 0 {
+		dc[search.CreatorDublinCoreTerm] = e.Presenter
+	}
+	if e.IsPartOf != "" {
+		dc[search.IsPartOfDublinCoreTerm] = []string{e.IsPartOf}
+	}
+	if !e.Created.IsZero() {
+		dc[search.CreatedDublinCoreTerm] = []string{e.Created.Time.Format(e.Created.Layout())}
+	}
+
+	return search.Result{
+		Type:         search.EpisodeResultType,
+		Org:          s.st.organization.ID,
+		Modified:     e.Created,
+		MediaPackage: mp,
+		DublinCore:   dc,
+	}
+}
+
+func (s *Server) searchPublishedSeries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mtx.RLock()
+	var results []search.Result
+	for _, sf := range s.st.series {
+		if id := q.Get("id"); id != "" && sf.Identifier != id {
+			continue
+		}
+		if text := q.Get("q"); text != "" && !containsFold(sf.Title, text) && !containsFold(sf.Description, text) {
+			continue
+		}
+		dc := search.DublinCore{
+			search.IdentifierDublinCoreTerm: {sf.Identifier},
+			search.TitleDublinCoreTerm:      {sf.Title},
+		}
+		if sf.Description != "" {
+			dc[search.DescriptionDublinCoreTerm] = []string{sf.Description}
+		}
+		if sf.Creator != "" {
+			dc[search.CreatorDublinCoreTerm] = []string{sf.Creator}
+		}
+		results = append(results, search.Result{
+			Type:       search.SeriesResultType,
+			Org:        s.st.organization.ID,
+			Modified:   sf.Created,
+			DublinCore: dc,
+		})
+	}
+	s.mtx.RUnlock()
+
+	writeSearchResults(w, r, results)
+}
+
+func writeSearchResults(w http.ResponseWriter, r *http.Request, results []search.Result) {
+	if by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), " "); by != "" {
+		slices.SortStableFunc(results, func(a, b search.Result) int {
+			var c int
+			if by == "modified" {
+				c = a.Modified.Time.Compare(b.Modified.Time)
+			} else {
+				term := search.DublinCoreTerm(by)
+				c = cmp.Compare(a.DublinCore.Get(term), b.DublinCore.Get(term))
+			}
+			if strings.EqualFold(dir, "desc") {
+				return -c
+			}
+			return c
+		})
+	}
+
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	page := paginate(r, results)
+	writeJSONContentType(w, jsonContentType, http.StatusOK, search.Results{
+		Total:  base.Int(len(results)),
+		Offset: base.Int(max(offset, 0)),
+		Limit:  base.Int(max(limit, 0)),
+		Result: page,
+	})
+}
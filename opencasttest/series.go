@@ -0,0 +1,455 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func (s *Server) registerSeries() {
+	s.mux.HandleFunc("GET /api/series", s.listSeries)
+	s.mux.HandleFunc("GET /api/series/series.json", s.searchSeries)
+	s.mux.HandleFunc("POST /api/series", s.createSeries)
+	s.mux.HandleFunc("GET /api/series/{id}", s.getSeries)
+	s.mux.HandleFunc("PUT /api/series/{id}", s.updateSeries)
+	s.mux.HandleFunc("DELETE /api/series/{id}", s.deleteSeries)
+
+	s.mux.HandleFunc("GET /api/series/{id}/acl", s.getSeriesACL)
+	s.mux.HandleFunc("PUT /api/series/{id}/acl", s.updateSeriesACL)
+
+	s.mux.HandleFunc("GET /api/series/{id}/metadata", s.getSeriesMetadata)
+	s.mux.HandleFunc("PUT /api/series/{id}/metadata", s.updateSeriesMetadata)
+	s.mux.HandleFunc("DELETE /api/series/{id}/metadata", s.deleteSeriesMetadata)
+
+	s.mux.HandleFunc("GET /api/series/{id}/properties", s.getSeriesProperties)
+	s.mux.HandleFunc("PUT /api/series/{id}/properties", s.updateSeriesProperties)
+}
+
+func (s *Server) findSeries(r *http.Request) (int, *SeriesFixture) {
+	// s.mtx is assumed to be locked
+	id := r.PathValue("id")
+	return find(s.st.series, func(sf *SeriesFixture) bool { return sf.Identifier == id })
+}
+
+func seriesView(r *http.Request, sf *SeriesFixture) extapiv1.Series {
+	series := sf.Series
+	if !queryBool(r, "withacl") {
+		series.ACL = nil
+	}
+	return series
+}
+
+func matchSeries(sf *SeriesFixture, filter map[string]string) bool {
+	for k, v := range filter {
+		switch k {
+		case "identifier":
+			if sf.Identifier != v {
+				return false
+			}
+		case "title":
+			if !containsFold(sf.Title, v) {
+				return false
+			}
+		case "description":
+			if !containsFold(sf.Description, v) {
+				return false
+			}
+		case "language":
+			if sf.Language != v {
+				return false
+			}
+		case "license":
+			if sf.License != v {
+				return false
+			}
+		case "organizers", "creator":
+			if !slices.Contains(sf.Organizers, v) {
+				return false
+			}
+		case "contributors":
+			if !slices.Contains(sf.Contributors, v) {
+				return false
+			}
+		case "textFilter":
+			if !containsFold(sf.Title, v) && !containsFold(sf.Description, v) && !containsFold(sf.Identifier, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sortSeries(series []extapiv1.Series, rawSort string) {
+	by, dir, _ := strings.Cut(rawSort, ":")
+	var cmp func(a, b extapiv1.Series) int
+	switch by {
+	case "title":
+		cmp = func(a, b extapiv1.Series) int { return strings.Compare(a.Title, b.Title) }
+	case "creator":
+		cmp = func(a, b extapiv1.Series) int { return strings.Compare(a.Creator, b.Creator) }
+	case "created":
+		cmp = func(a, b extapiv1.Series) int { return a.Created.Time.Compare(b.Created.Time) }
+	default:
+		return
+	}
+	slices.SortStableFunc(series, func(a, b extapiv1.Series) int {
+		if strings.EqualFold(dir, "DESC") {
+			return -cmp(a, b)
+		}
+		return cmp(a, b)
+	})
+}
+
+func (s *Server) listSeries(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	series := make([]extapiv1.Series, 0, len(s.st.series))
+	for _, sf := range s.st.series {
+		if matchSeries(sf, filter) {
+			series = append(series, seriesView(r, sf))
+		}
+	}
+	s.mtx.RUnlock()
+
+	sortSeries(series, r.URL.Query().Get("sort"))
+	writeJSON(w, http.StatusOK, paginate(r, series))
+}
+
+func (s *Server) searchSeries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := map[string]string{}
+	if v := q.Get("q"); v != "" {
+		filter["textFilter"] = v
+	}
+	if v := q.Get("seriesId"); v != "" {
+		filter["identifier"] = v
+	}
+	if v := q.Get("seriesTitle"); v != "" {
+		filter["title"] = v
+	}
+	if v := q.Get("language"); v != "" {
+		filter["language"] = v
+	}
+	if v := q.Get("license"); v != "" {
+		filter["license"] = v
+	}
+	if v := q.Get("description"); v != "" {
+		filter["description"] = v
+	}
+
+	s.mtx.RLock()
+	series := make([]extapiv1.Series, 0, len(s.st.series))
+	for _, sf := range s.st.series {
+		if matchSeries(sf, filter) {
+			series = append(series, sf.Series)
+		}
+	}
+	s.mtx.RUnlock()
+
+	switch q.Get("sort") {
+	case "TITLE":
+		sortSeries(series, "title:ASC")
+	case "TITLE_DESC":
+		sortSeries(series, "title:DESC")
+	}
+
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	count, _ := strconv.Atoi(q.Get("count"))
+	if offset >= len(series) {
+		series = []extapiv1.Series{}
+	} else if offset > 0 {
+		series = series[offset:]
+	}
+	if count > 0 && count < len(series) {
+		series = series[:count]
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+func (s *Server) createSeries(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sf := &SeriesFixture{}
+	sf.Identifier = s.newIdentifier()
+	sf.Created = base.DateTime{Time: time.Now().UTC().Truncate(time.Second)}
+	sf.Creator = s.currentUsername(r)
+	sf.Properties = base.Properties{}
+	sf.Metadata = []extapiv1.Catalog{newCatalog(base.DublinCoreSeriesFlavor, "Opencast Series DublinCore", seriesFields)}
+
+	if _, err := unmarshalFormValue(r, "acl", &sf.ACL); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var catalogs []rawCatalog
+	if ok, err := unmarshalFormValue(r, "metadata", &catalogs); err != nil || !ok {
+		http.Error(w, "metadata is required", http.StatusBadRequest)
+		return
+	}
+	for _, rc := range catalogs {
+		_, c := findCatalog(sf.Metadata, rc.Flavor)
+		if c == nil {
+			sf.Metadata = append(sf.Metadata, extapiv1.Catalog{Label: rc.Label, Flavor: rc.Flavor})
+			c = &sf.Metadata[len(sf.Metadata)-1]
+		}
+		setValues(c, rc.Fields)
+	}
+	_, dc := findCatalog(sf.Metadata, base.DublinCoreSeriesFlavor)
+	setValues(dc, []extapiv1.Value{{ID: extapiv1.IdentifierFieldID, Value: sf.Identifier}})
+	syncSeriesFromMetadata(sf)
+	if sf.Title == "" {
+		http.Error(w, "title is required", http.StatusBadRequest)
+		return
+	}
+
+	if theme := r.FormValue("theme"); theme != "" {
+		sf.Properties["theme"] = theme
+	}
+
+	s.mtx.Lock()
+	sf.Organization = s.st.organization.ID
+	s.st.series = append(s.st.series, sf)
+	s.mtx.Unlock()
+
+	w.Header().Set("Location", s.URL+"/api/series/"+sf.Identifier)
+	writeJSON(w, http.StatusCreated, extapiv1.Identifier{Identifier: sf.Identifier})
+}
+
+func (s *Server) getSeries(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, seriesView(r, sf))
+}
+
+func (s *Server) updateSeries(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var catalogs []rawCatalog
+	if _, err := unmarshalFormValue(r, "metadata", &catalogs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	for _, rc := range catalogs {
+		_, c := findCatalog(sf.Metadata, rc.Flavor)
+		if c == nil {
+			sf.Metadata = append(sf.Metadata, extapiv1.Catalog{Label: rc.Label, Flavor: rc.Flavor})
+			c = &sf.Metadata[len(sf.Metadata)-1]
+		}
+		setValues(c, rc.Fields)
+	}
+	syncSeriesFromMetadata(sf)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteSeries(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	s.st.series = slices.Delete(s.st.series, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getSeriesACL(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	acl := sf.ACL
+	if acl == nil {
+		acl = extapiv1.ACL{}
+	}
+	writeJSON(w, http.StatusOK, acl)
+}
+
+func (s *Server) updateSeriesACL(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	acl := extapiv1.ACL{}
+	if ok, err := unmarshalFormValue(r, "acl", &acl); err != nil || !ok {
+		http.Error(w, "acl is required", http.StatusBadRequest)
+		return
+	}
+	override, _ := strconv.ParseBool(r.FormValue("override"))
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	sf.ACL = acl
+	if override {
+		for _, e := range s.st.events {
+			if e.IsPartOf == sf.Identifier {
+				e.ACL = acl
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, acl)
+}
+
+func (s *Server) getSeriesMetadata(w http.ResponseWriter, r *http.Request) {
+	flavor := base.Flavor(r.URL.Query().Get("type"))
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if flavor == "" {
+		writeJSON(w, http.StatusOK, sf.Metadata)
+		return
+	}
+	_, c := findCatalog(sf.Metadata, flavor)
+	if c == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) updateSeriesMetadata(w http.ResponseWriter, r *http.Request) {
+	flavor := base.Flavor(r.URL.Query().Get("type"))
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var values []extapiv1.Value
+	if _, err := unmarshalFormValue(r, "metadata", &values); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	_, c := findCatalog(sf.Metadata, flavor)
+	if c == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	setValues(c, values)
+	syncSeriesFromMetadata(sf)
+	writeJSON(w, http.StatusOK, sf.Metadata)
+}
+
+func (s *Server) deleteSeriesMetadata(w http.ResponseWriter, r *http.Request) {
+	flavor := base.Flavor(r.URL.Query().Get("type"))
+	if flavor == base.DublinCoreSeriesFlavor {
+		http.Error(w, "the main catalog cannot be deleted", http.StatusForbidden)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	i, _ := findCatalog(sf.Metadata, flavor)
+	if i < 0 {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	sf.Metadata = slices.Delete(sf.Metadata, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getSeriesProperties(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	props := sf.Properties
+	if props == nil {
+		props = base.Properties{}
+	}
+	writeJSON(w, http.StatusOK, props)
+}
+
+func (s *Server) updateSeriesProperties(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	props := base.Properties{}
+	if ok, err := unmarshalFormValue(r, "properties", &props); err != nil || !ok {
+		http.Error(w, "properties are required", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, sf := s.findSeries(r)
+	if sf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if sf.Properties == nil {
+		sf.Properties = base.Properties{}
+	}
+	for k, v := range props {
+		sf.Properties[k] = v
+	}
+	writeJSON(w, http.StatusOK, sf.Properties)
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package opencasttest provides an in-memory fake of the Opencast External API,
+// the search, scheduler, capture-admin, ingest, workflow and asset manager
+// services, the user, role and theme endpoints and the service registry for
+// use in tests.
+package opencasttest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/jwt"
+)
+
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "opencast"
+)
+
+const (
+	jsonContentType   = "application/json"
+	extAPIContentType = "application/" + extapiv1.Version + "+json"
+
+	sudoRole = "ROLE_SUDO"
+)
+
+type Server struct {
+	*httptest.Server
+
+	username string
+	password string
+
+	mux *http.ServeMux
+
+	mtx      sync.RWMutex
+	st       state                 // protected by mtx
+	services map[string][]string   // protected by mtx
+	faults   []*faultRule          // protected by mtx
+	hooks    []func(*http.Request) // protected by mtx
+	sessions map[string]struct{}   // protected by mtx
+	nonces   map[string]struct{}   // protected by mtx
+
+	oidcClients map[string]string // client ID -> secret
+	tokenTTL    time.Duration
+	tokens      map[string]time.Time // protected by mtx
+	tokenSigner *jwt.Signer
+	jwtKeys     []any
+
+	etags       bool
+	ignoreRunAs bool
+	timeZone    *time.Location
+	apiVersions []string // oldest first
+}
+
+func NewServer(opts ...Option) *Server {
+	s := NewUnstartedServer(opts...)
+	s.Start()
+	return s
+}
+
+func NewUnstartedServer(opts ...Option) *Server {
+	s := &Server{
+		username: DefaultUsername,
+		password: DefaultPassword,
+		mux:      http.NewServeMux(),
+		st:       newState(),
+		services: make(map[string][]string),
+		sessions: make(map[string]struct{}),
+		nonces:   make(map[string]struct{}),
+
+		oidcClients: make(map[string]string),
+		tokenTTL:    DefaultTokenTTL,
+		tokens:      make(map[string]time.Time),
+
+		timeZone:    time.UTC,
+		apiVersions: []string{extapiv1.Version},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.registerServiceRegistry()
+	s.registerSession()
+	s.registerToken()
+	s.registerBase()
+	s.registerInfo()
+	s.registerEvents()
+	s.registerSeries()
+	s.registerGroups()
+	s.registerPlaylists()
+	s.registerWorkflows()
+	s.registerAgents()
+	s.registerSearch()
+	s.registerScheduler()
+	s.registerCaptureAdmin()
+	s.registerIngest()
+	s.registerWorkflowService()
+	s.registerAssetManager()
+	s.registerUsers()
+	s.registerThemes()
+
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+type Option func(*Server)
+
+// WithCredentials sets the Basic auth credentials accepted by the server. An
+// empty username disables authentication.
+func WithCredentials(username, password string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithTimeZone sets the time zone of the server recurring recordings are
+// expanded in. Defaults to UTC.
+func WithTimeZone(loc *time.Location) Option {
+	return func(s *Server) {
+		s.timeZone = loc
+	}
+}
+
+// WithAPIVersions sets the External API versions supported by the server,
+// oldest first. The last one is the default. Defaults to v1.11.0.
+func WithAPIVersions(versions ...string) Option {
+	return func(s *Server) {
+		s.apiVersions = slices.Clone(versions)
+	}
+}
+
+// WithoutRunAs makes the server ignore the run-as headers, like a proxy
+// dropping them would.
+func WithoutRunAs() Option {
+	return func(s *Server) {
+		s.ignoreRunAs = true
+	}
+}
+
+func WithFixtures(f *Fixtures) Option {
+	return func(s *Server) {
+		s.st.seed(f)
+	}
+}
+
+// ServiceMapper returns a service mapper that sends all requests to the server.
+func (s *Server) ServiceMapper() *oc.StaticServiceMapper {
+	return &oc.StaticServiceMapper{Default: s.URL}
+}
+
+// Client returns an Opencast client talking to the server using the
+// configured credentials.
+func (s *Server) Client(opts ...oc.ClientOpts) (oc.Client, error) {
+	reqOpts := []oc.RequestOpts{oc.WithHeader("User-Agent", oc.UserAgent)}
+	if s.username != "" {
+		reqOpts = append(reqOpts, oc.WithBasicAuth(s.username, s.password))
+	}
+	return oc.New(
+		s.ServiceMapper(),
+		append([]oc.ClientOpts{oc.WithRequestOptions(reqOpts...)}, opts...)...,
+	)
+}
+
+// Seed adds the given fixtures to the server state.
+func (s *Server) Seed(f *Fixtures) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.st.seed(f)
+}
+
+// Reset removes all state from the server.
+func (s *Server) Reset() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.st = newState()
+}
+
+// OnRequest registers a hook called for every incoming request before it is
+// handled.
+func (s *Server) OnRequest(hook func(*http.Request)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+// Handle registers an additional handler, e.g. to fake endpoints not covered
+// by the server.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	hooks := s.hooks
+	s.mtx.RUnlock()
+	for _, hook := range hooks {
+		hook(r)
+	}
+
+	if s.applyFaults(w, r) {
+		return
+	}
+
+	if s.ignoreRunAs {
+		r.Header.Del(oc.RunAsUserHeader)
+		r.Header.Del(oc.RunWithRolesHeader)
+	}
+
+	if !s.authenticate(r) {
+		if wantsDigest(r) {
+			s.digestChallenge(w, r)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="Opencast"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// like Opencast, only users with the sudo role may run as another user
+	if _, _, ok := runAs(r); ok && !s.hasRole(sudoRole) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if !s.negotiateAPIVersion(w, r) {
+		return
+	}
+
+	if s.etags && r.Method == http.MethodGet {
+		s.serveWithETag(w, r)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// negotiateAPIVersion rejects External API requests for unsupported versions
+// and sets the content type of the requested version, which writeJSON keeps.
+func (s *Server) negotiateAPIVersion(w http.ResponseWriter, r *http.Request) bool {
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		return true
+	}
+	version := s.apiVersions[len(s.apiVersions)-1]
+	accept := r.Header.Get("Accept")
+	if v, ok := strings.CutPrefix(accept, "application/v"); ok && strings.HasSuffix(v, "+json") {
+		version = "v" + strings.TrimSuffix(v, "+json")
+		if !slices.Contains(s.apiVersions, version) {
+			writeStatus(w, http.StatusNotAcceptable)
+			return false
+		}
+	}
+	w.Header().Set("Content-Type", "application/"+version+"+json")
+	return true
+}
+
+func (s *Server) authenticate(r *http.Request) bool {
+	if s.username == "" {
+		return true
+	}
+	switch r.URL.Path {
+	case "/services/available.json", oc.SessionLoginPath, oc.SessionLoginPage, oc.OIDCDiscoveryPath, TokenPath:
+		return true
+	}
+	if s.validSession(r) || s.validToken(r) || s.validDigest(r) {
+		return true
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && username == s.username && password == s.password
+}
+
+func (s *Server) newIdentifier() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// currentUsername returns the user the request is acting as.
+func (s *Server) currentUsername(r *http.Request) string {
+	if username, _, ok := runAs(r); ok {
+		return username
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.st.me.Username
+}
+
+func (s *Server) hasRole(role string) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return slices.Contains(s.st.roles, role)
+}
+
+// runAs returns the user and roles requested by the run-as headers.
+func runAs(r *http.Request) (username string, roles []string, ok bool) {
+	username = r.Header.Get(oc.RunAsUserHeader)
+	if v := r.Header.Get(oc.RunWithRolesHeader); v != "" {
+		roles = strings.Split(v, ",")
+	}
+	return username, roles, username != "" || len(roles) > 0
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	contentType := w.Header().Get("Content-Type")
+	if contentType == "" {
+		contentType = extAPIContentType
+	}
+	writeJSONContentType(w, contentType, status, v)
+}
+
+func writeJSONContentType(w http.ResponseWriter, contentType string, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+func writeStatus(w http.ResponseWriter, status int) {
+	http.Error(w, http.StatusText(status), status)
+}
+
+func parseForm(r *http.Request) error {
+	if err := r.ParseMultipartForm(32 << 20); err != nil && err != http.ErrNotMultipart {
+		return err
+	}
+	return r.ParseForm()
+}
+
+func unmarshalFormValue(r *http.Request, key string, v any) (bool, error) {
+	raw := r.FormValue(key)
+	if raw == "" {
+		return false, nil
+	}
+	return true, json.Unmarshal([]byte(raw), v)
+}
+
+func hasFile(r *http.Request, key string) bool {
+	if r.MultipartForm == nil {
+		return false
+	}
+	return len(r.MultipartForm.File[key]) > 0
+}
+
+func queryBool(r *http.Request, key string) bool {
+	b, _ := strconv.ParseBool(r.URL.Query().Get(key))
+	return b
+}
+
+func paginate[T any](r *http.Request, list []T) []T {
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(list) {
+		return []T{}
+	}
+	list = list[offset:]
+	if limit > 0 && limit < len(list) {
+		list = list[:limit]
+	}
+	return list
+}
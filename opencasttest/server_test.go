@@ -0,0 +1,159 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	extapiclientv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11/client"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+)
+
+func newExtAPI(t *testing.T, srv *opencasttest.Server, opts ...oc.ClientOpts) extapiclientv1.Client {
+	t.Helper()
+	c, err := srv.Client(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return extapiclientv1.New(c)
+}
+
+func TestServerRejectsWrongCredentials(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+
+	c, err := oc.New(srv.ServiceMapper(), oc.WithRequestOptions(oc.WithBasicAuth(opencasttest.DefaultUsername, "wrong")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, resp, err := extapiclientv1.New(c).GetAPIVersion(context.Background())
+	if err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want status %d", err, http.StatusUnauthorized)
+	}
+}
+
+func TestServerEventLifecycle(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+	api := newExtAPI(t, srv)
+	ctx := context.Background()
+
+	ids, _, err := api.CreateEvent(ctx, &extapiclientv1.CreateEventRequestBody{
+		Metadata: []extapiv1.Catalog{{
+			Flavor: base.DublinCoreEpisodeFlavor,
+			Fields: []extapiv1.Field{{
+				ID:    extapiv1.TitleFieldID,
+				Value: extapiv1.TextFieldValue("Lecture 1"),
+			}},
+		}},
+		PresenterStreamFilename: "lecture.mp4",
+		PresenterStream:         io.NopCloser(strings.NewReader("video")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := ids.ObjectVal.Identifier
+
+	event, _, err := api.GetEvent(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Title != "Lecture 1" {
+		t.Fatalf("got title %q, want %q", event.Title, "Lecture 1")
+	}
+
+	events, _, err := api.ListEvent(ctx, extapiclientv1.WithFilter{extapiclientv1.EventTitleFilterKey: "lecture"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Identifier != id {
+		t.Fatalf("got events %v, want %s", events, id)
+	}
+
+	if _, err := api.DeleteEvent(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	_, resp, err := api.GetEvent(ctx, id)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("got error %v, want status %d", err, http.StatusNotFound)
+	}
+}
+
+func TestServerFixturesAndReset(t *testing.T) {
+	srv := opencasttest.NewServer(opencasttest.WithFixtures(&opencasttest.Fixtures{
+		Events: []opencasttest.EventFixture{
+			{Event: extapiv1.Event{Identifier: "e1", Title: "B"}},
+			{Event: extapiv1.Event{Identifier: "e2", Title: "A"}},
+		},
+	}))
+	defer srv.Close()
+	api := newExtAPI(t, srv)
+	ctx := context.Background()
+
+	events, _, err := api.ListEvent(ctx,
+		extapiclientv1.WithSort{{By: "title"}},
+		extapiclientv1.WithPagination{Limit: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Identifier != "e2" {
+		t.Fatalf("got events %v, want e2 only", events)
+	}
+
+	srv.Reset()
+	events, _, err = api.ListEvent(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("got %d events after reset, want 0", len(events))
+	}
+}
+
+func TestServerInjectFault(t *testing.T) {
+	srv := opencasttest.NewServer()
+	defer srv.Close()
+	api := newExtAPI(t, srv)
+	ctx := context.Background()
+
+	srv.InjectFault(opencasttest.Fault{Path: "/api/version", StatusCode: http.StatusServiceUnavailable, Times: 1})
+	_, resp, err := api.GetAPIVersion(ctx)
+	if err == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got error %v, want status %d", err, http.StatusServiceUnavailable)
+	}
+	if _, _, err := api.GetAPIVersion(ctx); err != nil {
+		t.Fatalf("fault applied more than once: %v", err)
+	}
+
+	remove := srv.InjectFailure(http.MethodGet, "/api/version")
+	if _, _, err := api.GetAPIVersion(ctx); err == nil {
+		t.Fatal("got no error for dropped connection")
+	}
+	remove()
+	_, _, err = api.GetAPIVersion(ctx)
+	if err != nil {
+		t.Fatalf("fault not removed: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/objlist"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/strobj"
+	"shio.solutions/tales.media/opencast-client-go/apis/serviceregistry"
+)
+
+// RegisterService sets the hosts announced by the service registry for the
+// given service type. By default, every service type is announced on the
+// server itself. Registering no hosts makes the service type unknown.
+func (s *Server) RegisterService(serviceType string, hosts ...string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.services[serviceType] = slices.Clone(hosts)
+}
+
+// UnregisterService restores the default announcement for the service type.
+func (s *Server) UnregisterService(serviceType string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.services, serviceType)
+}
+
+func (s *Server) registerServiceRegistry() {
+	s.mux.HandleFunc("GET /services/available.json", s.getAvailableServices)
+}
+
+func (s *Server) getAvailableServices(w http.ResponseWriter, r *http.Request) {
+	svcType := r.URL.Query().Get("serviceType")
+
+	s.mtx.RLock()
+	hosts, ok := s.services[svcType]
+	s.mtx.RUnlock()
+	if !ok {
+		hosts = []string{s.URL}
+	}
+
+	resp := serviceregistry.AvailableServicesResponse{}
+	services := make([]serviceregistry.Service, 0, len(hosts))
+	for _, host := range hosts {
+		services = append(services, serviceregistry.Service{
+			Type:         svcType,
+			Host:         host,
+			Path:         "/",
+			Active:       true,
+			Online:       true,
+			JobProducer:  false,
+			OnlineFrom:   time.Now().UTC(),
+			ServiceState: serviceregistry.NormalServiceState,
+			StateChanged: time.Now().UTC(),
+		})
+	}
+
+	switch len(services) {
+	case 0:
+		resp.Services = strobj.FromString[serviceregistry.AvailableServicesList]("")
+	case 1:
+		resp.Services = strobj.FromObject(serviceregistry.AvailableServicesList{
+			Service: objlist.FromObject(services[0]),
+		})
+	default:
+		resp.Services = strobj.FromObject(serviceregistry.AvailableServicesList{
+			Service: objlist.FromList(services),
+		})
+	}
+
+	writeJSONContentType(w, jsonContentType, http.StatusOK, resp)
+}
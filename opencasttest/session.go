@@ -0,0 +1,70 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+func (s *Server) registerSession() {
+	s.mux.HandleFunc("POST "+oc.SessionLoginPath, s.login)
+	s.mux.HandleFunc("GET "+oc.SessionLoginPage, s.loginPage)
+}
+
+// ExpireSessions invalidates all sessions created by form login.
+func (s *Server) ExpireSessions() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	clear(s.sessions)
+}
+
+func (s *Server) validSession(r *http.Request) bool {
+	c, err := r.Cookie(oc.SessionCookie)
+	if err != nil {
+		return false
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, ok := s.sessions[c.Value]
+	return ok
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.PostFormValue("j_username") != s.username || r.PostFormValue("j_password") != s.password {
+		http.Redirect(w, r, oc.SessionLoginPage+"?error", http.StatusFound)
+		return
+	}
+
+	sessionID := s.newIdentifier()
+	s.mtx.Lock()
+	s.sessions[sessionID] = struct{}{}
+	s.mtx.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: oc.SessionCookie, Value: sessionID, Path: "/", HttpOnly: true})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (s *Server) loginPage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html;charset=UTF-8")
+	_, _ = w.Write([]byte("<html><body><form action=\"" + oc.SessionLoginPath + "\" method=\"post\"></form></body></html>"))
+}
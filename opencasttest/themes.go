@@ -0,0 +1,279 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/themes"
+)
+
+// registerThemes serves the admin UI theme endpoints and the static file
+// uploads themes refer to. Series store their theme in the "theme" property.
+func (s *Server) registerThemes() {
+	s.mux.HandleFunc("POST /staticfiles", s.createStaticFile)
+	s.mux.HandleFunc("GET /admin-ng/themes/themes.json", s.listThemes)
+	s.mux.HandleFunc("POST /admin-ng/themes", s.createTheme)
+	s.mux.HandleFunc("GET /admin-ng/themes/{id}", s.getTheme)
+	s.mux.HandleFunc("PUT /admin-ng/themes/{id}", s.updateTheme)
+	s.mux.HandleFunc("DELETE /admin-ng/themes/{id}", s.deleteTheme)
+	s.mux.HandleFunc("GET /admin-ng/themes/{id}/usage.json", s.getThemeUsage)
+	s.mux.HandleFunc("PUT /admin-ng/series/{id}/theme", s.updateSeriesTheme)
+}
+
+func (s *Server) findTheme(id string) (int, *themes.Theme) {
+	// s.mtx is assumed to be locked
+	return find(s.st.themes, func(t *themes.Theme) bool {
+		return strconv.FormatInt(int64(t.ID), 10) == strings.TrimSuffix(id, ".json")
+	})
+}
+
+func (s *Server) createStaticFile(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !hasFile(r, "BODY") {
+		http.Error(w, "BODY is required", http.StatusBadRequest)
+		return
+	}
+	b, err := readFile(r.MultipartForm.File["BODY"][0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := s.newIdentifier()
+	s.mtx.Lock()
+	s.st.staticFiles[id] = b
+	s.mtx.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(id))
+}
+
+func (s *Server) listThemes(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	list := make([]themes.Theme, 0, len(s.st.themes))
+	for _, t := range s.st.themes {
+		if text, ok := filter["textFilter"]; ok &&
+			!containsFold(t.Name, text) && !containsFold(t.Description, text) && !containsFold(t.Creator, text) {
+			continue
+		}
+		if creator, ok := filter["creator"]; ok && t.Creator != creator {
+			continue
+		}
+		list = append(list, *t)
+	}
+	s.mtx.RUnlock()
+
+	by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), ":")
+	var key func(t themes.Theme) string
+	switch by {
+	case "name":
+		key = func(t themes.Theme) string { return t.Name }
+	case "description":
+		key = func(t themes.Theme) string { return t.Description }
+	case "creator":
+		key = func(t themes.Theme) string { return t.Creator }
+	case "default":
+		key = func(t themes.Theme) string { return strconv.FormatBool(t.Default) }
+	case "creationDate":
+		key = func(t themes.Theme) string { return t.CreationDate.Time.Format(time.RFC3339) }
+	}
+	if key != nil {
+		slices.SortStableFunc(list, func(a, b themes.Theme) int {
+			if strings.EqualFold(dir, "DESC") {
+				return strings.Compare(key(b), key(a))
+			}
+			return strings.Compare(key(a), key(b))
+		})
+	}
+
+	page := paginate(r, list)
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	writeJSONContentType(w, jsonContentType, http.StatusOK, &themes.ThemesResponse{
+		Total:   len(list),
+		Offset:  offset,
+		Count:   len(page),
+		Limit:   limit,
+		Results: page,
+	})
+}
+
+// setThemeFromForm replaces the settings of the theme. The form must be
+// parsed.
+func (s *Server) setThemeFromForm(t *themes.Theme, r *http.Request) error {
+	// s.mtx is assumed to be locked
+	if r.FormValue("name") == "" {
+		return fmt.Errorf("name is required")
+	}
+	t.Name = r.FormValue("name")
+	t.Description = r.FormValue("description")
+	t.Default, _ = strconv.ParseBool(r.FormValue("default"))
+	t.BumperActive, _ = strconv.ParseBool(r.FormValue("bumperActive"))
+	t.TrailerActive, _ = strconv.ParseBool(r.FormValue("trailerActive"))
+	t.TitleSlideActive, _ = strconv.ParseBool(r.FormValue("titleSlideActive"))
+	t.TitleSlideMetadata = r.FormValue("titleSlideMetadata")
+	t.LicenseSlideActive, _ = strconv.ParseBool(r.FormValue("licenseSlideActive"))
+	t.LicenseSlideDescription = r.FormValue("licenseSlideDescription")
+	t.WatermarkActive, _ = strconv.ParseBool(r.FormValue("watermarkActive"))
+	t.WatermarkPosition = themes.WatermarkPosition(r.FormValue("watermarkPosition"))
+	for field, file := range map[string]*string{
+		"bumperFile":             &t.BumperFile,
+		"trailerFile":            &t.TrailerFile,
+		"titleSlideBackground":   &t.TitleSlideBackground,
+		"licenseSlideBackground": &t.LicenseSlideBackground,
+		"watermarkFile":          &t.WatermarkFile,
+	} {
+		id := r.FormValue(field)
+		if _, ok := s.st.staticFiles[id]; id != "" && !ok {
+			return fmt.Errorf("unknown static file %s", id)
+		}
+		*file = id
+	}
+	if t.Default {
+		for _, other := range s.st.themes {
+			other.Default = false
+		}
+	}
+	return nil
+}
+
+func (s *Server) createTheme(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	username := s.currentUsername(r)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var id base.Int = 1
+	for _, t := range s.st.themes {
+		id = max(id, t.ID+1)
+	}
+	t := &themes.Theme{
+		ID:           id,
+		CreationDate: base.DateTime{Time: time.Now().UTC().Truncate(time.Second)},
+		Creator:      username,
+	}
+	if err := s.setThemeFromForm(t, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.st.themes = append(s.st.themes, t)
+	writeJSONContentType(w, jsonContentType, http.StatusOK, t)
+}
+
+func (s *Server) getTheme(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, t := s.findTheme(r.PathValue("id"))
+	if t == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, t)
+}
+
+func (s *Server) updateTheme(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, t := s.findTheme(r.PathValue("id"))
+	if t == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	updated := *t
+	if err := s.setThemeFromForm(&updated, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	*t = updated
+	writeJSONContentType(w, jsonContentType, http.StatusOK, t)
+}
+
+func (s *Server) deleteTheme(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, t := s.findTheme(r.PathValue("id"))
+	if t == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	for _, sf := range s.st.series {
+		if sf.Properties["theme"] == t.SeriesTheme() {
+			delete(sf.Properties, "theme")
+		}
+	}
+	s.st.themes = slices.Delete(s.st.themes, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getThemeUsage(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, t := s.findTheme(r.PathValue("id"))
+	if t == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	usage := &themes.Usage{Series: []themes.UsageSeries{}}
+	for _, sf := range s.st.series {
+		if sf.Properties["theme"] == t.SeriesTheme() {
+			usage.Series = append(usage.Series, themes.UsageSeries{ID: sf.Identifier, Title: sf.Title})
+		}
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, usage)
+}
+
+func (s *Server) updateSeriesTheme(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, sf := s.findSeries(r)
+	_, t := s.findTheme(r.FormValue("themeId"))
+	if sf == nil || t == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if sf.Properties == nil {
+		sf.Properties = base.Properties{}
+	}
+	sf.Properties["theme"] = t.SeriesTheme()
+	writeJSONContentType(w, jsonContentType, http.StatusOK, map[string]string{t.SeriesTheme(): t.Name})
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"crypto/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+	"shio.solutions/tales.media/opencast-client-go/pkg/jwt"
+)
+
+const (
+	TokenPath       = "/oauth2/token"
+	DefaultTokenTTL = 10 * time.Minute
+)
+
+// WithOIDCClient registers a client allowed to request tokens using the client
+// credentials grant at the token endpoint of the server. Issued tokens are
+// accepted as bearer token or jwt query parameter.
+func WithOIDCClient(clientID, clientSecret string) Option {
+	return func(s *Server) {
+		s.oidcClients[clientID] = clientSecret
+	}
+}
+
+// WithTokenTTL sets the lifetime of issued tokens.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.tokenTTL = ttl
+	}
+}
+
+// WithTrustedJWTKey makes the server accept JWTs signed with the key, like the
+// JWT auth provider of Opencast does. key is the public key or HMAC secret as
+// understood by [jwt.Verify].
+func WithTrustedJWTKey(key any) Option {
+	return func(s *Server) {
+		s.jwtKeys = append(s.jwtKeys, key)
+	}
+}
+
+func (s *Server) registerToken() {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		panic(err)
+	}
+	s.tokenSigner, _ = jwt.NewSigner(key[:])
+
+	s.mux.HandleFunc("GET "+oc.OIDCDiscoveryPath, s.getOIDCConfiguration)
+	s.mux.HandleFunc("POST "+TokenPath, s.createToken)
+}
+
+// ExpireTokens invalidates all issued tokens.
+func (s *Server) ExpireTokens() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	clear(s.tokens)
+}
+
+func (s *Server) validToken(r *http.Request) bool {
+	token := r.URL.Query().Get("jwt")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if token == "" {
+		return false
+	}
+
+	for _, key := range s.jwtKeys {
+		if _, err := jwt.Verify(token, key); err == nil {
+			return true
+		}
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	expiry, ok := s.tokens[token]
+	return ok && time.Now().Before(expiry)
+}
+
+func (s *Server) getOIDCConfiguration(w http.ResponseWriter, r *http.Request) {
+	writeJSONContentType(w, jsonContentType, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"token_endpoint":                        s.URL + TokenPath,
+		"grant_types_supported":                 []string{"client_credentials"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+	})
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	if grantType := r.PostFormValue("grant_type"); grantType != "client_credentials" {
+		writeTokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	secret, ok := s.oidcClients[clientID]
+	if !ok || secret != clientSecret {
+		writeTokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	now := time.Now()
+	expiry := now.Add(s.tokenTTL)
+
+	s.mtx.Lock()
+	claims := jwt.Claims{
+		Issuer:    s.URL,
+		Subject:   clientID,
+		ExpiresAt: expiry.Unix(),
+		IssuedAt:  now.Unix(),
+		ID:        s.newIdentifier(),
+		Username:  s.st.me.Username,
+		Name:      s.st.me.Name,
+		Email:     s.st.me.Email,
+		Roles:     s.st.roles,
+	}
+	token, err := s.tokenSigner.Sign(&claims)
+	if err == nil {
+		s.tokens[token] = expiry
+	}
+	s.mtx.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSONContentType(w, jsonContentType, http.StatusOK, map[string]any{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int64(s.tokenTTL / time.Second),
+		"scope":        r.PostFormValue("scope"),
+	})
+}
+
+func writeTokenError(w http.ResponseWriter, status int, code string) {
+	writeJSONContentType(w, jsonContentType, status, map[string]string{"error": code})
+}
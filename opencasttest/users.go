@@ -0,0 +1,279 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/userdirectory"
+)
+
+// registerUsers serves the admin UI user endpoints, the user directory and
+// the role listing. Group roles are derived from the External API groups.
+func (s *Server) registerUsers() {
+	s.mux.HandleFunc("GET /admin-ng/users/users.json", s.listUsers)
+	s.mux.HandleFunc("POST /admin-ng/users", s.createUser)
+	s.mux.HandleFunc("GET /admin-ng/users/{username}", s.getUser)
+	s.mux.HandleFunc("PUT /admin-ng/users/{username}", s.updateUser)
+	s.mux.HandleFunc("DELETE /admin-ng/users/{username}", s.deleteUser)
+	s.mux.HandleFunc("GET /users/{username}", s.getEffectiveUser)
+	s.mux.HandleFunc("GET /roles/roles.json", s.listRoles)
+}
+
+func (s *Server) findUser(r *http.Request) (int, *UserFixture) {
+	// s.mtx is assumed to be locked
+	username := strings.TrimSuffix(r.PathValue("username"), ".json")
+	return find(s.st.users, func(u *UserFixture) bool { return u.Username == username })
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	users := make([]userdirectory.User, 0, len(s.st.users))
+	for _, u := range s.st.users {
+		if text, ok := filter["textFilter"]; ok &&
+			!containsFold(u.Username, text) && !containsFold(u.Name, text) && !containsFold(u.Email, text) {
+			continue
+		}
+		if role, ok := filter["role"]; ok && !slices.Contains(u.RoleNames(), role) {
+			continue
+		}
+		if provider, ok := filter["provider"]; ok && u.Provider != provider {
+			continue
+		}
+		users = append(users, u.User)
+	}
+	s.mtx.RUnlock()
+
+	by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), ":")
+	var key func(u userdirectory.User) string
+	switch by {
+	case "name":
+		key = func(u userdirectory.User) string { return u.Name }
+	case "username":
+		key = func(u userdirectory.User) string { return u.Username }
+	case "email":
+		key = func(u userdirectory.User) string { return u.Email }
+	case "provider":
+		key = func(u userdirectory.User) string { return u.Provider }
+	}
+	if key != nil {
+		slices.SortStableFunc(users, func(a, b userdirectory.User) int {
+			if strings.EqualFold(dir, "DESC") {
+				return strings.Compare(key(b), key(a))
+			}
+			return strings.Compare(key(a), key(b))
+		})
+	}
+
+	page := paginate(r, users)
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	writeJSONContentType(w, jsonContentType, http.StatusOK, &userdirectory.UsersResponse{
+		Total:   len(users),
+		Offset:  offset,
+		Count:   len(page),
+		Limit:   limit,
+		Results: page,
+	})
+}
+
+// formRoles parses the roles form value. The form must be parsed.
+func formRoles(r *http.Request) ([]userdirectory.Role, error) {
+	var roles []userdirectory.Role
+	if _, err := unmarshalFormValue(r, "roles", &roles); err != nil {
+		return nil, err
+	}
+	for i := range roles {
+		if roles[i].Type == "" {
+			roles[i].Type = userdirectory.InternalRoleType
+		}
+	}
+	return roles, nil
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	username, password := r.FormValue("username"), r.FormValue("password")
+	if username == "" || password == "" {
+		http.Error(w, "username and password are required", http.StatusBadRequest)
+		return
+	}
+	roles, err := formRoles(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, u := find(s.st.users, func(u *UserFixture) bool { return u.Username == username }); u != nil {
+		writeStatus(w, http.StatusConflict)
+		return
+	}
+	s.st.users = append(s.st.users, &UserFixture{
+		User: userdirectory.User{
+			Username:   username,
+			Name:       r.FormValue("name"),
+			Email:      r.FormValue("email"),
+			Provider:   userdirectory.InternalProvider,
+			Manageable: true,
+			Roles:      roles,
+		},
+		Password: password,
+	})
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, u := s.findUser(r)
+	if u == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, &u.User)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	roles, err := formRoles(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, u := s.findUser(r)
+	switch {
+	case u == nil:
+		writeStatus(w, http.StatusNotFound)
+		return
+	case !u.Manageable:
+		writeStatus(w, http.StatusForbidden)
+		return
+	}
+	if r.Form.Has("password") {
+		u.Password = r.FormValue("password")
+	}
+	u.Name = r.FormValue("name")
+	u.Email = r.FormValue("email")
+	u.Roles = roles
+	writeJSONContentType(w, jsonContentType, http.StatusOK, &u.User)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, u := s.findUser(r)
+	switch {
+	case u == nil:
+		writeStatus(w, http.StatusNotFound)
+		return
+	case !u.Manageable:
+		writeStatus(w, http.StatusForbidden)
+		return
+	}
+	s.st.users = slices.Delete(s.st.users, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getEffectiveUser returns the user with the roles of its groups added.
+func (s *Server) getEffectiveUser(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, u := s.findUser(r)
+	if u == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	user := u.User
+	user.Roles = slices.Clone(u.Roles)
+	for _, g := range s.st.groups {
+		if !slices.Contains(splitList(g.Members), u.Username) {
+			continue
+		}
+		user.Roles = appendRole(user.Roles, userdirectory.Role{Name: g.Role, Organization: g.Organization, Type: userdirectory.GroupRoleType})
+		for _, name := range splitList(g.Roles) {
+			user.Roles = appendRole(user.Roles, userdirectory.Role{Name: name, Organization: g.Organization, Type: userdirectory.DerivedRoleType})
+		}
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, &user)
+}
+
+func appendRole(roles []userdirectory.Role, role userdirectory.Role) []userdirectory.Role {
+	if slices.ContainsFunc(roles, func(r userdirectory.Role) bool { return r.Name == role.Name }) {
+		return roles
+	}
+	return append(roles, role)
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	var all []userdirectory.Role
+	for _, name := range s.st.roles {
+		all = appendRole(all, userdirectory.Role{Name: name, Organization: s.st.organization.ID, Type: userdirectory.SystemRoleType})
+	}
+	for _, g := range s.st.groups {
+		all = appendRole(all, userdirectory.Role{Name: g.Role, Description: g.Description, Organization: g.Organization, Type: userdirectory.GroupRoleType})
+	}
+	for _, u := range s.st.users {
+		for _, role := range u.Roles {
+			role.Organization = s.st.organization.ID
+			all = appendRole(all, role)
+		}
+	}
+	s.mtx.RUnlock()
+
+	roles := make([]userdirectory.Role, 0, len(all))
+	for _, role := range all {
+		if t, ok := filter["type"]; ok && string(role.Type) != t {
+			continue
+		}
+		if text, ok := filter["textFilter"]; ok && !containsFold(role.Name, text) && !containsFold(role.Description, text) {
+			continue
+		}
+		roles = append(roles, role)
+	}
+
+	by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), ":")
+	slices.SortStableFunc(roles, func(a, b userdirectory.Role) int {
+		if by == "type" {
+			a.Name, b.Name = string(a.Type), string(b.Type)
+		}
+		if strings.EqualFold(dir, "DESC") {
+			return strings.Compare(b.Name, a.Name)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	writeJSONContentType(w, jsonContentType, http.StatusOK, paginate(r, roles))
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+)
+
+func (s *Server) registerWorkflows() {
+	s.mux.HandleFunc("POST /api/workflows", s.createWorkflow)
+	s.mux.HandleFunc("GET /api/workflows/{id}", s.getWorkflow)
+	s.mux.HandleFunc("PUT /api/workflows/{id}", s.updateWorkflow)
+	s.mux.HandleFunc("DELETE /api/workflows/{id}", s.deleteWorkflow)
+
+	s.mux.HandleFunc("GET /api/workflow-definitions", s.listWorkflowDefinitions)
+	s.mux.HandleFunc("GET /api/workflow-definitions/{id}", s.getWorkflowDefinition)
+}
+
+func (s *Server) findWorkflow(r *http.Request) (int, *extapiv1.WorkflowInstance) {
+	// s.mtx is assumed to be locked
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return -1, nil
+	}
+	return find(s.st.workflows, func(wf *extapiv1.WorkflowInstance) bool { return int64(wf.Identifier) == id })
+}
+
+// startWorkflow creates a running workflow instance for the event.
+func (s *Server) startWorkflow(eventID, definitionID string, config base.Properties) *extapiv1.WorkflowInstance {
+	// s.mtx is assumed to be locked
+	var id base.Int = 1
+	for _, wf := range s.st.workflows {
+		id = max(id, wf.Identifier+1)
+	}
+
+	wf := &extapiv1.WorkflowInstance{
+		Identifier:                   id,
+		WorkflowDefinitionIdentifier: definitionID,
+		EventIdentifier:              eventID,
+		Creator:                      s.st.me.Username,
+		State:                        extapiv1.RunningWorkflowState,
+		Configuration:                config,
+	}
+	if _, wd := find(s.st.workflowDefinitions, func(wd *extapiv1.WorkflowDefinition) bool {
+		return wd.Identifier == definitionID
+	}); wd != nil {
+		wf.Title = wd.Title
+		wf.Description = wd.Description
+		for _, op := range wd.Operations {
+			wf.Operations = append(wf.Operations, extapiv1.OperationInstance{
+				Operation:            op.Operation,
+				Description:          op.Description,
+				State:                extapiv1.InstantiatedWorkflowOperationState,
+				If:                   op.If,
+				FailWorkflowOnError:  op.FailWorkflowOnError,
+				ErrorHandlerWorkflow: op.ErrorHandlerWorkflow,
+				RetryStrategy:        op.RetryStrategy,
+				MaxAttempts:          op.MaxAttempts,
+				Configuration:        op.Configuration,
+			})
+		}
+	}
+	s.st.workflows = append(s.st.workflows, wf)
+	return wf
+}
+
+func workflowView(r *http.Request, wf *extapiv1.WorkflowInstance) extapiv1.WorkflowInstance {
+	view := *wf
+	if !queryBool(r, "withoperations") {
+		view.Operations = nil
+	}
+	if !queryBool(r, "withconfiguration") {
+		view.Configuration = nil
+	}
+	return view
+}
+
+func (s *Server) createWorkflow(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	eventID := r.FormValue("event_identifier")
+	definitionID := r.FormValue("workflow_definition_identifier")
+	config := base.Properties{}
+	if _, err := unmarshalFormValue(r, "configuration", &config); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == eventID }); e == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if _, wd := find(s.st.workflowDefinitions, func(wd *extapiv1.WorkflowDefinition) bool {
+		return wd.Identifier == definitionID
+	}); wd == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	wf := s.startWorkflow(eventID, definitionID, config)
+	w.Header().Set("Location", s.URL+"/api/workflows/"+strconv.FormatInt(int64(wf.Identifier), 10))
+	writeJSON(w, http.StatusCreated, workflowView(r, wf))
+}
+
+func (s *Server) getWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, wf := s.findWorkflow(r)
+	if wf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, workflowView(r, wf))
+}
+
+func (s *Server) updateWorkflow(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	config := base.Properties{}
+	hasConfig, err := unmarshalFormValue(r, "configuration", &config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	state := extapiv1.WorkflowState(r.FormValue("state"))
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, wf := s.findWorkflow(r)
+	if wf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	switch state {
+	case "":
+	case extapiv1.RunningWorkflowState, extapiv1.StoppedWorkflowState:
+		if wf.State == extapiv1.SucceededWorkflowState || wf.State == extapiv1.FailedWorkflowState {
+			writeStatus(w, http.StatusConflict)
+			return
+		}
+		wf.State = state
+	default:
+		http.Error(w, "invalid state "+string(state), http.StatusBadRequest)
+		return
+	}
+	if hasConfig {
+		wf.Configuration = config
+	}
+	writeJSON(w, http.StatusOK, workflowView(r, wf))
+}
+
+func (s *Server) deleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i, wf := s.findWorkflow(r)
+	if wf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	if wf.State == extapiv1.RunningWorkflowState {
+		writeStatus(w, http.StatusConflict)
+		return
+	}
+	s.st.workflows = slices.Delete(s.st.workflows, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func workflowDefinitionView(r *http.Request, wd *extapiv1.WorkflowDefinition) extapiv1.WorkflowDefinition {
+	view := *wd
+	if !queryBool(r, "withoperations") {
+		view.Operations = nil
+	}
+	if !queryBool(r, "withconfigurationpanel") {
+		view.ConfigurationPanel = nil
+	}
+	if !queryBool(r, "withconfigurationpaneljson") {
+		view.ConfigurationPanelJSON = nil
+	}
+	return view
+}
+
+func (s *Server) listWorkflowDefinitions(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	s.mtx.RLock()
+	definitions := make([]extapiv1.WorkflowDefinition, 0, len(s.st.workflowDefinitions))
+	for _, wd := range s.st.workflowDefinitions {
+		if tag, ok := filter["tag"]; ok && !slices.Contains(wd.Tags, tag) {
+			continue
+		}
+		definitions = append(definitions, workflowDefinitionView(r, wd))
+	}
+	s.mtx.RUnlock()
+
+	by, dir, _ := strings.Cut(r.URL.Query().Get("sort"), ":")
+	var key func(wd extapiv1.WorkflowDefinition) string
+	switch by {
+	case "identifier":
+		key = func(wd extapiv1.WorkflowDefinition) string { return wd.Identifier }
+	case "title":
+		key = func(wd extapiv1.WorkflowDefinition) string { return wd.Title }
+	}
+	if key != nil {
+		slices.SortStableFunc(definitions, func(a, b extapiv1.WorkflowDefinition) int {
+			if strings.EqualFold(dir, "DESC") {
+				return strings.Compare(key(b), key(a))
+			}
+			return strings.Compare(key(a), key(b))
+		})
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, definitions))
+}
+
+func (s *Server) getWorkflowDefinition(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, wd := find(s.st.workflowDefinitions, func(wd *extapiv1.WorkflowDefinition) bool { return wd.Identifier == id })
+	if wd == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, workflowDefinitionView(r, wd))
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opencasttest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	extapiv1 "shio.solutions/tales.media/opencast-client-go/apis/external-api/v1.11"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/workflow"
+)
+
+// registerWorkflowService serves the workflow service on top of the
+// workflows of the External API.
+func (s *Server) registerWorkflowService() {
+	s.mux.HandleFunc("GET /workflow/instances.json", s.listWorkflowInstances)
+	s.mux.HandleFunc("GET /workflow/instance/{file}", s.getWorkflowInstance)
+	s.mux.HandleFunc("POST /workflow/stop", s.changeWorkflowInstance)
+	s.mux.HandleFunc("POST /workflow/suspend", s.changeWorkflowInstance)
+	s.mux.HandleFunc("POST /workflow/resume", s.changeWorkflowInstance)
+	s.mux.HandleFunc("POST /workflow/cleanup", s.cleanupWorkflowInstances)
+	s.mux.HandleFunc("GET /workflow/statistics.json", s.getWorkflowStatistics)
+	s.mux.HandleFunc("GET /workflow/handlers.json", s.listOperationHandlers)
+}
+
+func configurations(props base.Properties) *workflow.Configurations {
+	if len(props) == 0 {
+		return nil
+	}
+	c := &workflow.Configurations{}
+	for k, v := range props {
+		c.Configuration = append(c.Configuration, workflow.Configuration{Key: k, Value: v})
+	}
+	slices.SortFunc(c.Configuration, func(a, b workflow.Configuration) int { return strings.Compare(a.Key, b.Key) })
+	return c
+}
+
+func (s *Server) workflowInstance(wf *extapiv1.WorkflowInstance, compact bool) workflow.Instance {
+	// s.mtx is assumed to be locked
+	instance := workflow.Instance{
+		ID:             workflow.Int(wf.Identifier),
+		State:          workflow.StateOf(wf.State),
+		Template:       wf.WorkflowDefinitionIdentifier,
+		Title:          wf.Title,
+		Description:    wf.Description,
+		CreatorID:      wf.Creator,
+		OrganizationID: s.st.organization.ID,
+	}
+	if compact {
+		instance.MediaPackage = &mediapackage.MediaPackage{ID: wf.EventIdentifier}
+		return instance
+	}
+	instance.Configurations = configurations(wf.Configuration)
+	if _, e := find(s.st.events, func(e *EventFixture) bool { return e.Identifier == wf.EventIdentifier }); e != nil {
+		instance.MediaPackage = s.mediaPackage(e)
+	} else {
+		instance.MediaPackage = &mediapackage.MediaPackage{ID: wf.EventIdentifier}
+	}
+	if len(wf.Operations) > 0 {
+		instance.Operations = &workflow.Operations{}
+	}
+	for _, op := range wf.Operations {
+		instance.Operations.Operation = append(instance.Operations.Operation, workflow.Operation{
+			ID:                       op.Operation,
+			State:                    strings.ToUpper(string(op.State)),
+			Description:              op.Description,
+			If:                       op.If,
+			FailOnError:              op.FailWorkflowOnError,
+			ExceptionHandlerWorkflow: op.ErrorHandlerWorkflow,
+			RetryStrategy:            string(op.RetryStrategy),
+			MaxAttempts:              workflow.Int(op.MaxAttempts),
+			FailedAttempts:           workflow.Int(op.FailedAttempts),
+			ExecutionHost:            op.Host,
+			Started:                  op.Start,
+			Completed:                op.Completion,
+			TimeInQueue:              workflow.Int(op.TimeInQueue),
+			Configurations:           configurations(op.Configuration),
+		})
+	}
+	return instance
+}
+
+func (s *Server) listWorkflowInstances(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var states, excluded []workflow.State
+	for _, st := range q["state"] {
+		if st, ok := strings.CutPrefix(st, "-"); ok {
+			excluded = append(excluded, workflow.State(strings.ToUpper(st)))
+		} else {
+			states = append(states, workflow.State(strings.ToUpper(st)))
+		}
+	}
+	count, _ := strconv.Atoi(q.Get("count"))
+	page, _ := strconv.Atoi(q.Get("startPage"))
+	if count <= 0 {
+		count = 20
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	var list []workflow.Instance
+	for _, wf := range s.st.workflows {
+		state := workflow.StateOf(wf.State)
+		if (len(states) > 0 && !slices.Contains(states, state)) || slices.Contains(excluded, state) ||
+			(q.Has("mp") && wf.EventIdentifier != q.Get("mp")) ||
+			(q.Has("workflowdefinition") && wf.WorkflowDefinitionIdentifier != q.Get("workflowdefinition")) {
+			continue
+		}
+		list = append(list, s.workflowInstance(wf, queryBool(r, "compact")))
+	}
+
+	total := len(list)
+	list = list[min(page*count, total):min((page+1)*count, total)]
+	writeJSONContentType(w, jsonContentType, http.StatusOK, workflow.InstancesResponse{Workflows: workflow.Instances{
+		TotalCount: workflow.Int(total),
+		StartPage:  workflow.Int(page),
+		Count:      workflow.Int(count),
+		Workflow:   list,
+	}})
+}
+
+func (s *Server) getWorkflowInstance(w http.ResponseWriter, r *http.Request) {
+	id, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+	if !ok {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	r.SetPathValue("id", id)
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	_, wf := s.findWorkflow(r)
+	if wf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	writeJSONContentType(w, jsonContentType, http.StatusOK, workflow.InstanceResponse{Workflow: s.workflowInstance(wf, false)})
+}
+
+// changeWorkflowInstance stops, suspends or resumes an instance depending on
+// the last path segment.
+func (s *Server) changeWorkflowInstance(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.SetPathValue("id", r.FormValue("id"))
+	action := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, wf := s.findWorkflow(r)
+	if wf == nil {
+		writeStatus(w, http.StatusNotFound)
+		return
+	}
+	switch action {
+	case "stop":
+		if wf.State == extapiv1.SucceededWorkflowState || wf.State == extapiv1.FailedWorkflowState {
+			writeStatus(w, http.StatusConflict)
+			return
+		}
+		wf.State = extapiv1.StoppedWorkflowState
+	case "suspend":
+		if wf.State != extapiv1.RunningWorkflowState {
+			writeStatus(w, http.StatusConflict)
+			return
+		}
+		wf.State = extapiv1.PausedWorkflowState
+	case "resume":
+		if wf.State != extapiv1.PausedWorkflowState {
+			writeStatus(w, http.StatusConflict)
+			return
+		}
+		wf.State = extapiv1.RunningWorkflowState
+		if props := base.ParseProperties(r.FormValue("properties")); len(props) > 0 {
+			if wf.Configuration == nil {
+				wf.Configuration = base.Properties{}
+			}
+			for k, v := range props {
+				wf.Configuration[k] = v
+			}
+		}
+	}
+	instance := s.workflowInstance(wf, false)
+	writeXML(w, http.StatusOK, &instance)
+}
+
+// cleanupWorkflowInstances removes instances in the given state. Instances
+// are not timestamped, so only a buffer of 0 days removes them.
+func (s *Server) cleanupWorkflowInstances(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	state := workflow.State(r.FormValue("state"))
+	switch state {
+	case workflow.SucceededState, workflow.FailedState, workflow.StoppedState:
+	default:
+		http.Error(w, "state must be SUCCEEDED, FAILED or STOPPED", http.StatusBadRequest)
+		return
+	}
+	buffer, err := strconv.Atoi(r.FormValue("buffer"))
+	if err != nil || buffer < 0 {
+		http.Error(w, "invalid buffer", http.StatusBadRequest)
+		return
+	}
+
+	s.mtx.Lock()
+	if buffer == 0 {
+		s.st.workflows = slices.DeleteFunc(s.st.workflows, func(wf *extapiv1.WorkflowInstance) bool {
+			return workflow.StateOf(wf.State) == state
+		})
+	}
+	s.mtx.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func countWorkflow(c *workflow.Counts, state workflow.State) {
+	c.Total++
+	switch state {
+	case workflow.InstantiatedState:
+		c.Instantiated++
+	case workflow.RunningState:
+		c.Running++
+	case workflow.PausedState:
+		c.Paused++
+	case workflow.StoppedState:
+		c.Stopped++
+	case workflow.SucceededState:
+		c.Finished++
+	case workflow.FailingState:
+		c.Failing++
+	case workflow.FailedState:
+		c.Failed++
+	}
+}
+
+func (s *Server) getWorkflowStatistics(w http.ResponseWriter, r *http.Request) {
+	stats := workflow.Statistics{Definitions: &workflow.DefinitionStatisticsList{}}
+
+	s.mtx.RLock()
+	for _, wf := range s.st.workflows {
+		state := workflow.StateOf(wf.State)
+		countWorkflow(&stats.Counts, state)
+
+		defs := &stats.Definitions.Definition
+		i := slices.IndexFunc(*defs, func(d workflow.DefinitionStatistics) bool { return d.ID == wf.WorkflowDefinitionIdentifier })
+		if i < 0 {
+			*defs = append(*defs, workflow.DefinitionStatistics{ID: wf.WorkflowDefinitionIdentifier})
+			i = len(*defs) - 1
+		}
+		countWorkflow(&(*defs)[i].Counts, state)
+	}
+	s.mtx.RUnlock()
+
+	writeJSONContentType(w, jsonContentType, http.StatusOK, workflow.StatisticsResponse{Statistics: stats})
+}
+
+// listOperationHandlers lists the operations used by the workflow
+// definitions.
+func (s *Server) listOperationHandlers(w http.ResponseWriter, r *http.Request) {
+	list := []workflow.OperationHandler{}
+	s.mtx.RLock()
+	for _, wd := range s.st.workflowDefinitions {
+		for _, op := range wd.Operations {
+			if !slices.ContainsFunc(list, func(h workflow.OperationHandler) bool { return h.ID == op.Operation }) {
+				list = append(list, workflow.OperationHandler{ID: op.Operation})
+			}
+		}
+	}
+	s.mtx.RUnlock()
+
+	slices.SortFunc(list, func(a, b workflow.OperationHandler) int { return strings.Compare(a.ID, b.ID) })
+	writeJSONContentType(w, jsonContentType, http.StatusOK, list)
+}
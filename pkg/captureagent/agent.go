@@ -0,0 +1,321 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package captureagent emulates an Opencast capture agent. The agent
+// heartbeats, polls its calendar and "records" scheduled events by ingesting
+// a local media file, which allows to test scheduling end to end.
+package captureagent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/captureadmin"
+	captureadminclient "shio.solutions/tales.media/opencast-client-go/apis/captureadmin/client"
+	ingestclient "shio.solutions/tales.media/opencast-client-go/apis/ingest/client"
+	"shio.solutions/tales.media/opencast-client-go/apis/meta/base"
+	"shio.solutions/tales.media/opencast-client-go/apis/scheduler"
+	schedulerclient "shio.solutions/tales.media/opencast-client-go/apis/scheduler/client"
+	oc "shio.solutions/tales.media/opencast-client-go/client"
+)
+
+var CaptureCanceledErr = errors.New("capture canceled")
+
+const (
+	DefaultHeartbeatInterval = 10 * time.Second
+	DefaultCalendarInterval  = time.Minute
+	DefaultCalendarCutoff    = 24 * time.Hour
+)
+
+type Config struct {
+	Name string
+	// Address is the URL the agent reports to Opencast.
+	Address string
+	// Inputs are the names of the capture devices. Every input records the
+	// media file as track with flavor <input>/source. Defaults to
+	// presenter.
+	Inputs []string
+	// MediaFile is the path of the file ingested for every recording.
+	MediaFile string
+
+	HeartbeatInterval time.Duration
+	CalendarInterval  time.Duration
+	// CalendarCutoff limits the calendar to recordings starting within the
+	// duration.
+	CalendarCutoff time.Duration
+
+	// OnRecorded is called after a recording was ingested or failed.
+	OnRecorded func(id string, err error)
+	// OnError is called if a heartbeat or calendar update failed or an event
+	// of the calendar could not be decoded.
+	OnError func(err error)
+}
+
+type Agent struct {
+	cfg       Config
+	admin     captureadminclient.Client
+	scheduler schedulerclient.Client
+	ingest    ingestclient.Client
+
+	mtx        sync.Mutex
+	state      captureadmin.AgentState      // protected by mtx
+	capturing  int                          // protected by mtx
+	recordings map[string]*scheduledCapture // protected by mtx
+	wg         sync.WaitGroup
+}
+
+type scheduledCapture struct {
+	scheduler.CalendarEvent
+	started bool // protected by Agent.mtx
+	cancel  context.CancelFunc
+}
+
+func New(opencastClient oc.Client, cfg Config) *Agent {
+	if len(cfg.Inputs) == 0 {
+		cfg.Inputs = []string{"presenter"}
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if cfg.CalendarInterval <= 0 {
+		cfg.CalendarInterval = DefaultCalendarInterval
+	}
+	if cfg.CalendarCutoff <= 0 {
+		cfg.CalendarCutoff = DefaultCalendarCutoff
+	}
+	return &Agent{
+		cfg:        cfg,
+		admin:      captureadminclient.New(opencastClient),
+		scheduler:  schedulerclient.New(opencastClient),
+		ingest:     ingestclient.New(opencastClient),
+		state:      captureadmin.IdleAgentState,
+		recordings: make(map[string]*scheduledCapture),
+	}
+}
+
+// Run registers the agent and runs it until ctx is done. Running recordings
+// are canceled and the agent is reported offline before Run returns.
+func (a *Agent) Run(ctx context.Context) error {
+	if err := a.register(ctx); err != nil {
+		return err
+	}
+	a.updateCalendar(ctx)
+
+	heartbeat := time.NewTicker(a.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+	calendar := time.NewTicker(a.cfg.CalendarInterval)
+	defer calendar.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.shutdown(context.WithoutCancel(ctx))
+			return nil
+		case <-heartbeat.C:
+			a.reportError(a.heartbeat(ctx))
+		case <-calendar.C:
+			a.updateCalendar(ctx)
+		}
+	}
+}
+
+func (a *Agent) register(ctx context.Context) error {
+	if err := a.heartbeat(ctx); err != nil {
+		return err
+	}
+	config := base.Properties{
+		"capture.device.names": strings.Join(a.cfg.Inputs, ","),
+	}
+	for _, input := range a.cfg.Inputs {
+		config["capture.device."+input+".flavor"] = input + "/source"
+	}
+	_, err := a.admin.UpdateAgentConfiguration(ctx, a.cfg.Name, config)
+	return err
+}
+
+func (a *Agent) heartbeat(ctx context.Context) error {
+	a.mtx.Lock()
+	state := a.state
+	a.mtx.Unlock()
+	return a.setState(ctx, state)
+}
+
+func (a *Agent) setState(ctx context.Context, state captureadmin.AgentState) error {
+	_, err := a.admin.UpdateAgentState(ctx, a.cfg.Name, &captureadminclient.UpdateAgentStateRequestBody{
+		State:   state,
+		Address: a.cfg.Address,
+	})
+	return err
+}
+
+func (a *Agent) shutdown(ctx context.Context) {
+	a.mtx.Lock()
+	for _, rec := range a.recordings {
+		rec.cancel()
+	}
+	a.mtx.Unlock()
+	a.wg.Wait()
+	a.reportError(a.setState(ctx, captureadmin.OfflineAgentState))
+}
+
+func (a *Agent) reportError(err error) {
+	if err != nil && a.cfg.OnError != nil {
+		a.cfg.OnError(err)
+	}
+}
+
+// updateCalendar schedules new recordings and cancels recordings which were
+// removed or rescheduled before they started.
+func (a *Agent) updateCalendar(ctx context.Context) {
+	events, resp, err := a.scheduler.ListCalendarEvent(
+		ctx,
+		schedulerclient.WithCalendarAgent(a.cfg.Name),
+		schedulerclient.WithCalendarCutoff(time.Now().Add(a.cfg.CalendarCutoff)),
+	)
+	if err != nil {
+		a.reportError(err)
+		return
+	}
+	for _, d := range resp.Meta.Diagnostics {
+		a.reportError(d.Err)
+	}
+	upcoming := make(map[string]*scheduledCapture, len(events))
+	for _, e := range events {
+		upcoming[e.EventID] = &scheduledCapture{CalendarEvent: e}
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	for id, rec := range a.recordings {
+		next, ok := upcoming[id]
+		if !rec.started && (!ok || !next.Start.Equal(rec.Start) || !next.End.Equal(rec.End)) {
+			rec.cancel()
+			delete(a.recordings, id)
+		}
+	}
+	for id, rec := range upcoming {
+		if _, ok := a.recordings[id]; ok || !rec.End.After(time.Now()) {
+			continue
+		}
+		var recCtx context.Context
+		recCtx, rec.cancel = context.WithCancel(ctx)
+		a.recordings[id] = rec
+		a.wg.Go(func() { a.record(recCtx, rec) })
+	}
+}
+
+func (a *Agent) record(ctx context.Context, rec *scheduledCapture) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(time.Until(rec.Start)):
+	}
+
+	a.mtx.Lock()
+	rec.started = true
+	a.capturing++
+	a.state = captureadmin.CapturingAgentState
+	a.mtx.Unlock()
+	a.reportError(a.setState(ctx, captureadmin.CapturingAgentState))
+
+	err := a.capture(ctx, rec)
+
+	a.mtx.Lock()
+	delete(a.recordings, rec.EventID)
+	a.capturing--
+	idle := a.capturing == 0
+	if idle {
+		a.state = captureadmin.IdleAgentState
+	}
+	a.mtx.Unlock()
+	if idle {
+		a.reportError(a.setState(context.WithoutCancel(ctx), captureadmin.IdleAgentState))
+	}
+
+	if a.cfg.OnRecorded != nil {
+		a.cfg.OnRecorded(rec.EventID, err)
+	}
+}
+
+// capture waits for the end of the recording and ingests it, reporting the
+// recording state along the way.
+func (a *Agent) capture(ctx context.Context, rec *scheduledCapture) error {
+	setState := func(ctx context.Context, state captureadmin.RecordingState) {
+		_, err := a.admin.UpdateRecordingState(ctx, rec.EventID, state)
+		a.reportError(err)
+	}
+
+	setState(ctx, captureadmin.CapturingRecordingState)
+	select {
+	case <-ctx.Done():
+		setState(context.WithoutCancel(ctx), captureadmin.CaptureErrorRecordingState)
+		return CaptureCanceledErr
+	case <-time.After(time.Until(rec.End)):
+	}
+	setState(ctx, captureadmin.CaptureFinishedRecordingState)
+
+	setState(ctx, captureadmin.UploadingRecordingState)
+	if err := a.upload(ctx, rec); err != nil {
+		setState(context.WithoutCancel(ctx), captureadmin.UploadErrorRecordingState)
+		return err
+	}
+	setState(ctx, captureadmin.UploadFinishedRecordingState)
+	return nil
+}
+
+func (a *Agent) upload(ctx context.Context, rec *scheduledCapture) error {
+	mp, _, err := a.ingest.CreateMediaPackageWithID(ctx, rec.EventID)
+	if err != nil {
+		return err
+	}
+	for filename, flavor := range map[string]base.Flavor{
+		scheduler.EpisodeAttachment: base.DublinCoreEpisodeFlavor,
+		scheduler.SeriesAttachment:  base.DublinCoreSeriesFlavor,
+	} {
+		dc, ok := rec.Attachment(filename)
+		if !ok {
+			continue
+		}
+		mp, _, err = a.ingest.AddDCCatalog(ctx, &ingestclient.AddDCCatalogRequestBody{
+			MediaPackage: mp,
+			DublinCore:   dc,
+			Flavor:       flavor,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, input := range a.cfg.Inputs {
+		mp, _, err = a.ingest.AddTrack(ctx, &ingestclient.AddTrackRequestBody{
+			MediaPackage: mp,
+			Flavor:       base.Flavor(input + "/source"),
+			File:         a.cfg.MediaFile,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = a.ingest.Ingest(ctx, &ingestclient.IngestRequestBody{
+		MediaPackage:          mp,
+		WorkflowDefinitionID:  rec.AgentProperties()[scheduler.WorkflowDefinitionAgentProperty],
+		WorkflowConfiguration: rec.WorkflowConfiguration(),
+	})
+	return err
+}
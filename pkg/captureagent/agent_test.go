@@ -0,0 +1,105 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package captureagent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/apis/captureadmin"
+	captureadminclient "shio.solutions/tales.media/opencast-client-go/apis/captureadmin/client"
+	"shio.solutions/tales.media/opencast-client-go/apis/mediapackage"
+	schedulerclient "shio.solutions/tales.media/opencast-client-go/apis/scheduler/client"
+	"shio.solutions/tales.media/opencast-client-go/opencasttest"
+	"shio.solutions/tales.media/opencast-client-go/pkg/captureagent"
+)
+
+func TestAgentRecordsScheduledEvent(t *testing.T) {
+	srv := opencasttest.NewServer()
+	t.Cleanup(srv.Close)
+	occ, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// the calendar has a resolution of seconds
+	start := time.Now().Truncate(time.Second).Add(time.Second)
+	if _, err := schedulerclient.New(occ).CreateRecording(ctx, &schedulerclient.CreateRecordingRequestBody{
+		Start:        start,
+		End:          start.Add(time.Second),
+		Agent:        "room-1",
+		MediaPackage: &mediapackage.MediaPackage{ID: "r1", Title: "Lecture"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaFile := filepath.Join(t.TempDir(), "lecture.mp4")
+	if err := os.WriteFile(mediaFile, []byte("not really a video"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	recorded := make(chan error, 1)
+	agent := captureagent.New(occ, captureagent.Config{
+		Name:              "room-1",
+		MediaFile:         mediaFile,
+		HeartbeatInterval: 50 * time.Millisecond,
+		CalendarInterval:  50 * time.Millisecond,
+		OnRecorded: func(id string, err error) {
+			if id == "r1" {
+				recorded <- err
+			}
+		},
+		OnError: func(err error) { t.Error(err) },
+	})
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- agent.Run(runCtx) }()
+
+	select {
+	case err := <-recorded:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-ctx.Done():
+		t.Fatal("recording was not ingested")
+	}
+
+	admin := captureadminclient.New(occ)
+	rec, _, err := admin.GetRecordingState(ctx, "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.State != captureadmin.UploadFinishedRecordingState {
+		t.Fatalf("got recording state %s, want %s", rec.State, captureadmin.UploadFinishedRecordingState)
+	}
+
+	stop()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	a, _, err := admin.GetAgent(ctx, "room-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.State != captureadmin.OfflineAgentState {
+		t.Fatalf("got agent state %s after shutdown, want %s", a.State, captureadmin.OfflineAgentState)
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ical reads and writes iCalendar data (RFC 5545) as used by Opencast
+// for capture agent calendars.
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+var MalformedCalendarErr = errors.New("ical: malformed calendar")
+
+const (
+	CalendarComponent = "VCALENDAR"
+	EventComponent    = "VEVENT"
+)
+
+const (
+	dateTimeLayout    = "20060102T150405"
+	utcDateTimeLayout = "20060102T150405Z"
+	dateLayout        = "20060102"
+)
+
+// Unmarshaler is implemented by types that can decode themselves from a
+// VCALENDAR.
+type Unmarshaler interface {
+	UnmarshalICal(cal *Component) error
+}
+
+// Component is a calendar component like VCALENDAR or VEVENT.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []Component
+}
+
+type Property struct {
+	Name string
+	// Params are the parameters of the property by upper case name.
+	Params map[string]string
+	Value  string
+}
+
+// Parse parses a single VCALENDAR.
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []*Component
+	var cal *Component
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		p, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", MalformedCalendarErr, i+1, err)
+		}
+		switch p.Name {
+		case "BEGIN":
+			stack = append(stack, &Component{Name: strings.ToUpper(p.Value)})
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", MalformedCalendarErr, i+1, p.Value)
+			}
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				cal = c
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, *c)
+			}
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: property outside of component", MalformedCalendarErr, i+1)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+		if cal != nil {
+			break
+		}
+	}
+	if cal == nil || cal.Name != CalendarComponent {
+		return nil, fmt.Errorf("%w: no %s", MalformedCalendarErr, CalendarComponent)
+	}
+	return cal, nil
+}
+
+// unfold reads the content lines of r, joining folded lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSuffix(sc.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, sc.Err()
+}
+
+func parseProperty(line string) (Property, error) {
+	p := Property{Params: make(map[string]string)}
+
+	// name
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return p, errors.New("missing property name")
+	}
+	p.Name = strings.ToUpper(line[:i])
+	line = line[i:]
+
+	// parameters
+	for line[0] == ';' {
+		line = line[1:]
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 {
+			return p, errors.New("malformed parameter")
+		}
+		name := strings.ToUpper(line[:eq])
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				return p, errors.New("unterminated quoted parameter value")
+			}
+			value = line[1 : end+1]
+			line = line[end+2:]
+		} else {
+			end := strings.IndexAny(line, ";:")
+			if end < 0 {
+				return p, errors.New("missing property value")
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+		p.Params[name] = value
+		if line == "" {
+			return p, errors.New("missing property value")
+		}
+	}
+
+	if line[0] != ':' {
+		return p, errors.New("missing property value")
+	}
+	p.Value = line[1:]
+	return p, nil
+}
+
+// Property returns the first property with the given name.
+func (c *Component) Property(name string) (*Property, bool) {
+	name = strings.ToUpper(name)
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i], true
+		}
+	}
+	return nil, false
+}
+
+// PropertyList returns all properties with the given name.
+func (c *Component) PropertyList(name string) []Property {
+	name = strings.ToUpper(name)
+	var list []Property
+	for _, p := range c.Properties {
+		if p.Name == name {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// ComponentList returns all sub-components with the given name.
+func (c *Component) ComponentList(name string) []Component {
+	name = strings.ToUpper(name)
+	var list []Component
+	for _, sub := range c.Components {
+		if sub.Name == name {
+			list = append(list, sub)
+		}
+	}
+	return list
+}
+
+// Text returns the value of a TEXT property with escaping removed.
+func (p *Property) Text() string {
+	if !strings.Contains(p.Value, `\`) {
+		return p.Value
+	}
+	var sb strings.Builder
+	for i := 0; i < len(p.Value); i++ {
+		ch := p.Value[i]
+		if ch == '\\' && i+1 < len(p.Value) {
+			i++
+			switch p.Value[i] {
+			case 'n', 'N':
+				sb.WriteByte('\n')
+			default:
+				sb.WriteByte(p.Value[i])
+			}
+			continue
+		}
+		sb.WriteByte(ch)
+	}
+	return sb.String()
+}
+
+// Binary returns the value of a property with ENCODING=BASE64 decoded.
+func (p *Property) Binary() ([]byte, error) {
+	if !strings.EqualFold(p.Params["ENCODING"], "BASE64") {
+		return []byte(p.Value), nil
+	}
+	return base64.StdEncoding.DecodeString(p.Value)
+}
+
+// Time returns the value of a DATE-TIME or DATE property. Local times are
+// interpreted in the time zone of the TZID parameter, or in loc if the
+// parameter is missing. TZIDs of the IANA time zone database are resolved
+// with time.LoadLocation, others with the VTIMEZONE definitions tzs.
+func (p *Property) Time(loc *time.Location, tzs Timezones) (time.Time, error) {
+	if strings.HasSuffix(p.Value, "Z") {
+		return time.Parse(utcDateTimeLayout, p.Value)
+	}
+	layout := dateTimeLayout
+	if len(p.Value) == len(dateLayout) {
+		layout = dateLayout
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if tzid := p.Params["TZID"]; tzid != "" {
+		tz, err := time.LoadLocation(tzid)
+		if err != nil {
+			def, ok := tzs[tzid]
+			if !ok {
+				return time.Time{}, err
+			}
+			wall, err := time.Parse(layout, p.Value)
+			if err != nil {
+				return time.Time{}, err
+			}
+			tz = def.Location(wall)
+		}
+		loc = tz
+	}
+	return time.ParseInLocation(layout, p.Value, loc)
+}
+
+// NewTextProperty returns a property with value escaped as TEXT.
+func NewTextProperty(name, value string) Property {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return Property{Name: name, Value: r.Replace(value)}
+}
+
+// NewTimeProperty returns a DATE-TIME property in UTC.
+func NewTimeProperty(name string, t time.Time) Property {
+	return Property{Name: name, Value: t.UTC().Format(utcDateTimeLayout)}
+}
+
+// NewBinaryProperty returns a property with b encoded as BASE64.
+func NewBinaryProperty(name string, b []byte, params map[string]string) Property {
+	p := Property{
+		Name:   name,
+		Params: map[string]string{"VALUE": "BINARY", "ENCODING": "BASE64"},
+		Value:  base64.StdEncoding.EncodeToString(b),
+	}
+	for k, v := range params {
+		p.Params[strings.ToUpper(k)] = v
+	}
+	return p
+}
+
+// maxLineLength is the maximum length of content lines in octets.
+const maxLineLength = 75
+
+// Encode writes the component with folded content lines.
+func (c *Component) Encode(w io.Writer) error {
+	var buf bytes.Buffer
+	c.encode(&buf)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (c *Component) encode(buf *bytes.Buffer) {
+	writeLine(buf, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		writeLine(buf, p.String())
+	}
+	for _, sub := range c.Components {
+		sub.encode(buf)
+	}
+	writeLine(buf, "END:"+c.Name)
+}
+
+func (p Property) String() string {
+	var sb strings.Builder
+	sb.WriteString(p.Name)
+	for _, name := range slices.Sorted(maps.Keys(p.Params)) {
+		value := p.Params[name]
+		sb.WriteString(";" + name + "=")
+		if strings.ContainsAny(value, ";:,") {
+			sb.WriteString(`"` + value + `"`)
+		} else {
+			sb.WriteString(value)
+		}
+	}
+	sb.WriteString(":" + p.Value)
+	return sb.String()
+}
+
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		// do not split UTF-8 sequences
+		n := limit
+		for n > 0 && line[n]&0xC0 == 0x80 {
+			n--
+		}
+		buf.WriteString(line[:n])
+		buf.WriteString("\r\n ")
+		line = line[n:]
+		limit = maxLineLength - 1 // leading space
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ical_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+func TestParseMalformed(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty":              "",
+		"no calendar":        "BEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\n",
+		"unterminated":       "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\n",
+		"mismatched end":     "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n",
+		"outside component":  "UID:1\r\nBEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+		"missing value":      "BEGIN:VCALENDAR\r\nUID\r\nEND:VCALENDAR\r\n",
+		"unterminated quote": "BEGIN:VCALENDAR\r\nX-A;P=\"x:1\r\nEND:VCALENDAR\r\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ical.Parse(strings.NewReader(input)); !errors.Is(err, ical.MalformedCalendarErr) {
+				t.Fatalf("got error %v, want %v", err, ical.MalformedCalendarErr)
+			}
+		})
+	}
+}
+
+func TestEncodeParseRoundTrip(t *testing.T) {
+	long := strings.Repeat("Grüße, ", 30)
+	cal := ical.Component{
+		Name: ical.CalendarComponent,
+		Components: []ical.Component{{
+			Name: ical.EventComponent,
+			Properties: []ical.Property{
+				ical.NewTextProperty("UID", "e1"),
+				ical.NewTextProperty("SUMMARY", long+"\nsecond line; with separators"),
+				ical.NewBinaryProperty("ATTACH", []byte("key=value"), map[string]string{"x-apple-filename": "a.properties"}),
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for line := range strings.SplitSeq(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line of %d octets not folded: %q", len(line), line)
+		}
+	}
+
+	parsed, err := ical.Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := parsed.ComponentList(ical.EventComponent)
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	summary, _ := events[0].Property("summary")
+	if got, want := summary.Text(), long+"\nsecond line; with separators"; got != want {
+		t.Fatalf("got summary %q, want %q", got, want)
+	}
+	attach, _ := events[0].Property("ATTACH")
+	if b, err := attach.Binary(); err != nil || string(b) != "key=value" || attach.Params["X-APPLE-FILENAME"] != "a.properties" {
+		t.Fatalf("got attachment %q %v, err %v", b, attach.Params, err)
+	}
+}
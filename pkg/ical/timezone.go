@@ -0,0 +1,234 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimezoneComponent = "VTIMEZONE"
+	StandardComponent = "STANDARD"
+	DaylightComponent = "DAYLIGHT"
+)
+
+// Timezones are the VTIMEZONE definitions of a calendar by TZID.
+type Timezones map[string]*Timezone
+
+// Timezone is a VTIMEZONE definition. Only yearly rules with BYMONTH and
+// BYDAY, as written by common calendar software, are supported.
+type Timezone struct {
+	ID          string
+	observances []observance
+}
+
+// observance is a STANDARD or DAYLIGHT sub-component of a VTIMEZONE.
+type observance struct {
+	start      time.Time // local wall clock time as UTC
+	offsetFrom time.Duration
+	offsetTo   time.Duration
+
+	// yearly rule, recurring is false for a single onset
+	recurring bool
+	month     time.Month
+	weekday   time.Weekday
+	nth       int // negative counts from the end of the month
+}
+
+// Timezones returns the time zones defined in the calendar. Definitions that
+// cannot be parsed are skipped; references to them fail to resolve.
+func (c *Component) Timezones() Timezones {
+	tzs := make(Timezones)
+	for _, vtz := range c.ComponentList(TimezoneComponent) {
+		if tz, err := ParseTimezone(&vtz); err == nil {
+			tzs[tz.ID] = tz
+		}
+	}
+	return tzs
+}
+
+func ParseTimezone(vtz *Component) (*Timezone, error) {
+	id, ok := vtz.Property("TZID")
+	if !ok {
+		return nil, fmt.Errorf("%w: %s without TZID", MalformedCalendarErr, TimezoneComponent)
+	}
+	tz := &Timezone{ID: id.Value}
+	for _, sub := range vtz.Components {
+		if sub.Name != StandardComponent && sub.Name != DaylightComponent {
+			continue
+		}
+		o, err := parseObservance(&sub)
+		if err != nil {
+			return nil, fmt.Errorf("%w: time zone %s: %v", MalformedCalendarErr, tz.ID, err)
+		}
+		tz.observances = append(tz.observances, o)
+	}
+	if len(tz.observances) == 0 {
+		return nil, fmt.Errorf("%w: time zone %s without observances", MalformedCalendarErr, tz.ID)
+	}
+	return tz, nil
+}
+
+func parseObservance(c *Component) (observance, error) {
+	var o observance
+	start, ok := c.Property("DTSTART")
+	if !ok {
+		return o, fmt.Errorf("%s without DTSTART", c.Name)
+	}
+	var err error
+	if o.start, err = time.Parse(dateTimeLayout, start.Value); err != nil {
+		return o, err
+	}
+	for name, v := range map[string]*time.Duration{"TZOFFSETFROM": &o.offsetFrom, "TZOFFSETTO": &o.offsetTo} {
+		p, ok := c.Property(name)
+		if !ok {
+			return o, fmt.Errorf("%s without %s", c.Name, name)
+		}
+		if *v, err = parseUTCOffset(p.Value); err != nil {
+			return o, err
+		}
+	}
+	if rule, ok := c.Property("RRULE"); ok {
+		if err := o.parseRule(rule.Value); err != nil {
+			return o, err
+		}
+	}
+	return o, nil
+}
+
+// parseUTCOffset parses an offset like +0100 or -053000.
+func parseUTCOffset(v string) (time.Duration, error) {
+	if (len(v) != 5 && len(v) != 7) || (v[0] != '+' && v[0] != '-') {
+		return 0, fmt.Errorf("malformed UTC offset %q", v)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if 1+2*i >= len(v) {
+			break
+		}
+		n, err := strconv.Atoi(v[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, fmt.Errorf("malformed UTC offset %q", v)
+		}
+		d += time.Duration(n) * unit
+	}
+	if v[0] == '-' {
+		d = -d
+	}
+	return d, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func (o *observance) parseRule(rule string) error {
+	for part := range strings.SplitSeq(rule, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			if !strings.EqualFold(value, "YEARLY") {
+				return fmt.Errorf("unsupported time zone rule %q", rule)
+			}
+		case "BYMONTH":
+			m, err := strconv.Atoi(value)
+			if err != nil || m < 1 || m > 12 {
+				return fmt.Errorf("malformed BYMONTH in %q", rule)
+			}
+			o.month = time.Month(m)
+		case "BYDAY":
+			if len(value) < 3 {
+				return fmt.Errorf("malformed BYDAY in %q", rule)
+			}
+			wd, ok := weekdays[strings.ToUpper(value[len(value)-2:])]
+			nth, err := strconv.Atoi(value[:len(value)-2])
+			if !ok || err != nil || nth == 0 {
+				return fmt.Errorf("malformed BYDAY in %q", rule)
+			}
+			o.weekday, o.nth = wd, nth
+		case "UNTIL", "WKST":
+		default:
+			return fmt.Errorf("unsupported time zone rule %q", rule)
+		}
+	}
+	if o.month == 0 || o.nth == 0 {
+		return fmt.Errorf("unsupported time zone rule %q", rule)
+	}
+	o.recurring = true
+	return nil
+}
+
+// onset returns the last onset of the observance at or before the local wall
+// clock time t, given as UTC.
+func (o *observance) onset(t time.Time) (time.Time, bool) {
+	if !o.recurring {
+		return o.start, !o.start.After(t)
+	}
+	for _, year := range []int{t.Year(), t.Year() - 1} {
+		onset := o.onsetIn(year)
+		if !onset.After(t) {
+			return onset, !onset.Before(o.start)
+		}
+	}
+	return time.Time{}, false
+}
+
+func (o *observance) onsetIn(year int) time.Time {
+	h, m, s := o.start.Clock()
+	if o.nth > 0 {
+		first := time.Date(year, o.month, 1, h, m, s, 0, time.UTC)
+		days := (int(o.weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, days+7*(o.nth-1))
+	}
+	last := time.Date(year, o.month+1, 0, h, m, s, 0, time.UTC)
+	days := (int(last.Weekday()) - int(o.weekday) + 7) % 7
+	return last.AddDate(0, 0, -days+7*(o.nth+1))
+}
+
+// Offset returns the UTC offset in effect at the local wall clock time t.
+func (tz *Timezone) Offset(t time.Time) time.Duration {
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	var latest *observance
+	var latestOnset time.Time
+	for i := range tz.observances {
+		o := &tz.observances[i]
+		if onset, ok := o.onset(t); ok && (latest == nil || onset.After(latestOnset)) {
+			latest, latestOnset = o, onset
+		}
+	}
+	if latest != nil {
+		return latest.offsetTo
+	}
+	// before the first onset
+	first := &tz.observances[0]
+	for i := range tz.observances {
+		if tz.observances[i].start.Before(first.start) {
+			first = &tz.observances[i]
+		}
+	}
+	return first.offsetFrom
+}
+
+// Location returns a fixed zone with the UTC offset in effect at the local
+// wall clock time t.
+func (tz *Timezone) Location(t time.Time) *time.Location {
+	return time.FixedZone(tz.ID, int(tz.Offset(t).Seconds()))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/pkg/ical"
+)
+
+// windowsCalendar uses a time zone that is not part of the IANA database,
+// as written by Outlook.
+const windowsCalendar = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:W. Europe Standard Time\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:16011028T030000\r\n" +
+	"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=10\r\n" +
+	"TZOFFSETFROM:+0200\r\n" +
+	"TZOFFSETTO:+0100\r\n" +
+	"END:STANDARD\r\n" +
+	"BEGIN:DAYLIGHT\r\n" +
+	"DTSTART:16010325T020000\r\n" +
+	"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=3\r\n" +
+	"TZOFFSETFROM:+0100\r\n" +
+	"TZOFFSETTO:+0200\r\n" +
+	"END:DAYLIGHT\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestTimeWithVTimezone(t *testing.T) {
+	cal, err := ical.Parse(strings.NewReader(windowsCalendar))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tzs := cal.Timezones()
+
+	for value, want := range map[string]time.Time{
+		"20260115T100000": time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC),
+		"20260715T100000": time.Date(2026, 7, 15, 8, 0, 0, 0, time.UTC),
+		// around the changes on the last Sundays of March and October 2026
+		"20260329T010000": time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC),
+		"20260329T040000": time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC),
+		"20261025T020000": time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+		"20261025T040000": time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC),
+	} {
+		p := ical.Property{Name: "DTSTART", Params: map[string]string{"TZID": "W. Europe Standard Time"}, Value: value}
+		got, err := p.Time(time.UTC, tzs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: got %s, want %s", value, got.UTC(), want)
+		}
+	}
+}
+
+func TestTimeWithIANATimezone(t *testing.T) {
+	p := ical.Property{Name: "DTSTART", Params: map[string]string{"TZID": "America/New_York"}, Value: "20260715T100000"}
+	got, err := p.Time(time.UTC, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 7, 15, 14, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got.UTC(), want)
+	}
+	if got.Location().String() != "America/New_York" {
+		t.Fatalf("got location %s, want America/New_York", got.Location())
+	}
+}
+
+func TestTimeWithUnknownTimezone(t *testing.T) {
+	p := ical.Property{Name: "DTSTART", Params: map[string]string{"TZID": "Nowhere"}, Value: "20260715T100000"}
+	if _, err := p.Time(time.UTC, nil); err == nil {
+		t.Fatal("got no error for unknown time zone")
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var MalformedTokenErr = errors.New("jwt: malformed token")
+
+type Header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// Claims contains the registered claims and the claims used by Opencast to
+// identify the user.
+type Claims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  []string `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+
+	Username string   `json:"username,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Roles    []string `json:"roles,omitempty"`
+
+	// Extra contains further claims, e.g. used by custom claim mappings of
+	// Opencast.
+	Extra map[string]any `json:"-"`
+}
+
+var registeredClaims = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "username", "name", "email", "roles"}
+
+func (c Claims) MarshalJSON() ([]byte, error) {
+	type claims Claims
+	b, err := json.Marshal(claims(c))
+	if err != nil || len(c.Extra) == 0 {
+		return b, err
+	}
+
+	m := make(map[string]any, len(c.Extra))
+	for k, v := range c.Extra {
+		if !slices.Contains(registeredClaims, k) {
+			m[k] = v
+		}
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	type claims Claims
+	var partial struct {
+		claims
+		Audience json.RawMessage `json:"aud,omitempty"`
+	}
+	if err := json.Unmarshal(data, &partial); err != nil {
+		return err
+	}
+	*c = Claims(partial.claims)
+
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, k := range registeredClaims {
+		delete(all, k)
+	}
+	if len(all) > 0 {
+		c.Extra = all
+	}
+
+	// aud is either a string or a list of strings
+	c.Audience = nil
+	if len(partial.Audience) > 0 && partial.Audience[0] == '"' {
+		var aud string
+		if err := json.Unmarshal(partial.Audience, &aud); err != nil {
+			return err
+		}
+		c.Audience = []string{aud}
+	} else if len(partial.Audience) > 0 {
+		if err := json.Unmarshal(partial.Audience, &c.Audience); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Expiry returns the time of the exp claim or the zero time if not set.
+func (c *Claims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Decode decodes the header and claims of the token without verifying the
+// signature.
+func Decode(token string) (*Header, *Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, MalformedTokenErr
+	}
+
+	header := &Header{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, nil, err
+	}
+	claims := &Claims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, nil, err
+	}
+	return header, claims, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(seg, "="))
+	if err != nil {
+		return errors.Join(MalformedTokenErr, err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Join(MalformedTokenErr, err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	ES256 = "ES256"
+	ES384 = "ES384"
+	ES512 = "ES512"
+)
+
+var (
+	UnsupportedKeyErr   = errors.New("jwt: unsupported key")
+	InvalidSignatureErr = errors.New("jwt: invalid signature")
+	ExpiredTokenErr     = errors.New("jwt: token expired")
+	NotYetValidErr      = errors.New("jwt: token not yet valid")
+)
+
+// Signer signs tokens with an RSA, ECDSA or HMAC key.
+type Signer struct {
+	alg   string
+	keyID string
+	key   any
+}
+
+// NewSigner returns a signer for the key. RSA keys sign with RS256, ECDSA keys
+// with ES256, ES384 or ES512 depending on the curve and byte slices are used
+// as HMAC secret with HS256.
+func NewSigner(key any) (*Signer, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &Signer{alg: RS256, key: k}, nil
+	case *ecdsa.PrivateKey:
+		alg, err := ecdsaAlgorithm(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		return &Signer{alg: alg, key: k}, nil
+	case []byte:
+		if len(k) == 0 {
+			return nil, UnsupportedKeyErr
+		}
+		return &Signer{alg: HS256, key: k}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", UnsupportedKeyErr, key)
+	}
+}
+
+// WithKeyID sets the kid header of signed tokens.
+func (s *Signer) WithKeyID(kid string) *Signer {
+	s.keyID = kid
+	return s
+}
+
+func (s *Signer) Algorithm() string {
+	return s.alg
+}
+
+func (s *Signer) Sign(claims *Claims) (string, error) {
+	header, err := json.Marshal(Header{Algorithm: s.alg, Type: "JWT", KeyID: s.keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+
+	var sig []byte
+	switch k := s.key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		_, _ = mac.Write([]byte(signingInput))
+		sig = mac.Sum(nil)
+
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signingInput))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			return "", err
+		}
+
+	case *ecdsa.PrivateKey:
+		h, size := ecdsaHash(s.alg)
+		_, _ = h.Write([]byte(signingInput))
+		r, ss, err := ecdsa.Sign(rand.Reader, k, h.Sum(nil))
+		if err != nil {
+			return "", err
+		}
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		ss.FillBytes(sig[size:])
+	}
+
+	return signingInput + "." + enc.EncodeToString(sig), nil
+}
+
+// Verify verifies the signature of the token with the public key matching the
+// signing key, or the HMAC secret, and checks the exp and nbf claims.
+func Verify(token string, key any) (*Claims, error) {
+	header, claims, err := Decode(token)
+	if err != nil {
+		return nil, err
+	}
+	i := strings.LastIndexByte(token, '.')
+	signingInput := token[:i]
+	sig, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return nil, errors.Join(MalformedTokenErr, err)
+	}
+
+	switch k := key.(type) {
+	case []byte:
+		if header.Algorithm != HS256 {
+			return nil, InvalidSignatureErr
+		}
+		mac := hmac.New(sha256.New, k)
+		_, _ = mac.Write([]byte(signingInput))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return nil, InvalidSignatureErr
+		}
+
+	case *rsa.PublicKey:
+		if header.Algorithm != RS256 {
+			return nil, InvalidSignatureErr
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig); err != nil {
+			return nil, InvalidSignatureErr
+		}
+
+	case *ecdsa.PublicKey:
+		alg, err := ecdsaAlgorithm(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		h, size := ecdsaHash(alg)
+		if header.Algorithm != alg || len(sig) != 2*size {
+			return nil, InvalidSignatureErr
+		}
+		_, _ = h.Write([]byte(signingInput))
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, h.Sum(nil), r, s) {
+			return nil, InvalidSignatureErr
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %T", UnsupportedKeyErr, key)
+	}
+
+	now := time.Now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return nil, ExpiredTokenErr
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, NotYetValidErr
+	}
+	return claims, nil
+}
+
+// ParsePrivateKeyPEM parses an RSA or ECDSA private key in PKCS #1, SEC 1 or
+// PKCS #8 form.
+func ParsePrivateKeyPEM(b []byte) (any, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", UnsupportedKeyErr)
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: %s", UnsupportedKeyErr, block.Type)
+	}
+}
+
+func ecdsaAlgorithm(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P256():
+		return ES256, nil
+	case elliptic.P384():
+		return ES384, nil
+	case elliptic.P521():
+		return ES512, nil
+	default:
+		return "", fmt.Errorf("%w: curve %s", UnsupportedKeyErr, curve.Params().Name)
+	}
+}
+
+func ecdsaHash(alg string) (hash.Hash, int) {
+	switch alg {
+	case ES384:
+		return sha512.New384(), 48
+	case ES512:
+		return sha512.New(), 66
+	default:
+		return sha256.New(), 32
+	}
+}
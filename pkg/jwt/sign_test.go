@@ -0,0 +1,130 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/pkg/jwt"
+)
+
+func TestSignVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+
+	tests := []struct {
+		name   string
+		key    any
+		public any
+		alg    string
+	}{
+		{"HMAC", secret, secret, jwt.HS256},
+		{"RSA", rsaKey, &rsaKey.PublicKey, jwt.RS256},
+		{"ECDSA", ecKey, &ecKey.PublicKey, jwt.ES384},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := jwt.NewSigner(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Algorithm() != tt.alg {
+				t.Fatalf("got algorithm %s, want %s", s.Algorithm(), tt.alg)
+			}
+
+			token, err := s.Sign(&jwt.Claims{
+				Username:  "admin",
+				Roles:     []string{"ROLE_ADMIN"},
+				ExpiresAt: time.Now().Add(time.Minute).Unix(),
+				Extra:     map[string]any{"org": "mh_default_org"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			claims, err := jwt.Verify(token, tt.public)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.Username != "admin" || claims.Extra["org"] != "mh_default_org" {
+				t.Fatalf("got claims %+v", claims)
+			}
+
+			if _, err := jwt.Verify(token[:len(token)-4]+"AAAA", tt.public); !errors.Is(err, jwt.InvalidSignatureErr) {
+				t.Fatalf("got error %v for tampered token, want %v", err, jwt.InvalidSignatureErr)
+			}
+		})
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	s, err := jwt.NewSigner([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.Sign(&jwt.Claims{ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwt.Verify(token, []byte("secret")); !errors.Is(err, jwt.ExpiredTokenErr) {
+		t.Fatalf("got error %v, want %v", err, jwt.ExpiredTokenErr)
+	}
+}
+
+func TestVerifyNotYetValid(t *testing.T) {
+	s, err := jwt.NewSigner([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.Sign(&jwt.Claims{NotBefore: time.Now().Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwt.Verify(token, []byte("secret")); !errors.Is(err, jwt.NotYetValidErr) {
+		t.Fatalf("got error %v, want %v", err, jwt.NotYetValidErr)
+	}
+}
+
+func TestVerifyAlgorithmMismatch(t *testing.T) {
+	s, err := jwt.NewSigner([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.Sign(&jwt.Claims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwt.Verify(token, &rsaKey.PublicKey); !errors.Is(err, jwt.InvalidSignatureErr) {
+		t.Fatalf("got error %v, want %v", err, jwt.InvalidSignatureErr)
+	}
+}
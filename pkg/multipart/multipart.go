@@ -70,6 +70,17 @@ func (mp *Multipart) Len() int64 {
 	return n
 }
 
+// Rewindable reports whether the multipart can be read more than once, i.e.
+// it contains no stream parts.
+func (mp *Multipart) Rewindable() bool {
+	for _, part := range mp.parts {
+		if _, ok := part.(*StreamPart); ok {
+			return false
+		}
+	}
+	return true
+}
+
 type reader struct {
 	mp *Multipart
 	pr *io.PipeReader
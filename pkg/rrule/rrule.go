@@ -0,0 +1,248 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rrule parses and expands the subset of iCalendar recurrence rules
+// (RFC 5545) Opencast accepts for recurring recordings, e.g.
+// FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=8;BYMINUTE=15.
+//
+// As in Opencast, BYDAY, BYHOUR and BYMINUTE are given in UTC as of the start
+// of the recurrence. Occurrences keep this local time in the time zone of the
+// recording, so lectures stay at 10:15 across daylight saving time changes.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	InvalidRuleErr        = errors.New("rrule: invalid rule")
+	UnsupportedRuleErr    = errors.New("rrule: unsupported rule")
+	TooManyOccurrencesErr = errors.New("rrule: too many occurrences")
+)
+
+// MaxOccurrences bounds the expansion of a rule.
+const MaxOccurrences = 5000
+
+type Frequency string
+
+const WeeklyFrequency Frequency = "WEEKLY"
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+type Rule struct {
+	Freq     Frequency
+	ByDay    []time.Weekday
+	ByHour   int
+	ByMinute int
+}
+
+// Period is a single occurrence of a recurring recording.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Parse parses and validates a rule. An RRULE: prefix is ignored.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", InvalidRuleErr)
+	}
+
+	r := &Rule{ByHour: -1, ByMinute: -1}
+	seen := make(map[string]bool)
+	for part := range strings.SplitSeq(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(key)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", InvalidRuleErr, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate %s", InvalidRuleErr, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "BYDAY":
+			for day := range strings.SplitSeq(value, ",") {
+				day = strings.ToUpper(day)
+				wd, ok := weekdays[day]
+				if !ok {
+					// ordinals such as 1MO or -1FR
+					if _, ok := weekdays[strings.TrimLeft(day, "+-0123456789")]; ok {
+						return nil, fmt.Errorf("%w: BYDAY value %q", UnsupportedRuleErr, day)
+					}
+					return nil, fmt.Errorf("%w: BYDAY value %q", InvalidRuleErr, day)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYHOUR":
+			r.ByHour, err = strconv.Atoi(value)
+		case "BYMINUTE":
+			r.ByMinute, err = strconv.Atoi(value)
+		case "INTERVAL", "COUNT", "UNTIL", "WKST", "BYSECOND", "BYMONTHDAY", "BYYEARDAY",
+			"BYWEEKNO", "BYMONTH", "BYSETPOS":
+			return nil, fmt.Errorf("%w: %s", UnsupportedRuleErr, key)
+		default:
+			return nil, fmt.Errorf("%w: unknown part %s", InvalidRuleErr, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", InvalidRuleErr, key, err)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Rule) Validate() error {
+	switch {
+	case r.Freq == "":
+		return fmt.Errorf("%w: FREQ is required", InvalidRuleErr)
+	case r.Freq != WeeklyFrequency:
+		return fmt.Errorf("%w: FREQ %s", UnsupportedRuleErr, r.Freq)
+	case len(r.ByDay) == 0:
+		return fmt.Errorf("%w: BYDAY is required", InvalidRuleErr)
+	case r.ByHour < 0 || r.ByHour > 23:
+		return fmt.Errorf("%w: BYHOUR between 0 and 23 is required", InvalidRuleErr)
+	case r.ByMinute < 0 || r.ByMinute > 59:
+		return fmt.Errorf("%w: BYMINUTE between 0 and 59 is required", InvalidRuleErr)
+	}
+	for i, wd := range r.ByDay {
+		if wd < time.Sunday || wd > time.Saturday {
+			return fmt.Errorf("%w: invalid weekday %d", InvalidRuleErr, wd)
+		}
+		if slices.Contains(r.ByDay[:i], wd) {
+			return fmt.Errorf("%w: duplicate weekday %s", InvalidRuleErr, wd)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) String() string {
+	days := make([]string, 0, len(r.ByDay))
+	for _, wd := range r.ByDay {
+		days = append(days, strings.ToUpper(wd.String()[:2]))
+	}
+	return fmt.Sprintf("FREQ=%s;BYDAY=%s;BYHOUR=%d;BYMINUTE=%d", r.Freq, strings.Join(days, ","), r.ByHour, r.ByMinute)
+}
+
+// local returns the weekdays and time of day of the occurrences in loc as of
+// start.
+func (r *Rule) local(start time.Time, loc *time.Location) ([]time.Weekday, int, int) {
+	y, m, d := start.UTC().Date()
+	ref := time.Date(y, m, d, r.ByHour, r.ByMinute, 0, 0, time.UTC)
+	local := ref.In(loc)
+
+	ly, lm, ld := local.Date()
+	shift := int(time.Date(ly, lm, ld, 0, 0, 0, 0, time.UTC).Sub(time.Date(y, m, d, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+
+	days := make([]time.Weekday, 0, len(r.ByDay))
+	for _, wd := range r.ByDay {
+		days = append(days, time.Weekday((int(wd)+shift+7)%7))
+	}
+	return days, local.Hour(), local.Minute()
+}
+
+// Occurrences returns the start times of the occurrences from start to end,
+// both inclusive, in loc. A nil loc means UTC. Rules with more than
+// MaxOccurrences occurrences fail with TooManyOccurrencesErr.
+func (r *Rule) Occurrences(start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	days, hour, minute := r.local(start, loc)
+
+	var list []time.Time
+	y, m, d := start.In(loc).Date()
+	for i := 0; ; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		if day.After(end) {
+			return list, nil
+		}
+		if !slices.Contains(days, day.Weekday()) {
+			continue
+		}
+		// time.Date moves times in a DST gap forward
+		occ := time.Date(y, m, d+i, hour, minute, 0, 0, loc)
+		if occ.Before(start) || occ.After(end) {
+			continue
+		}
+		if len(list) == MaxOccurrences {
+			return nil, fmt.Errorf("%w: more than %d from %s to %s", TooManyOccurrencesErr, MaxOccurrences, start, end)
+		}
+		list = append(list, occ)
+	}
+}
+
+// Periods returns the occurrences from start to end with the given duration.
+func (r *Rule) Periods(start, end time.Time, d time.Duration, loc *time.Location) ([]Period, error) {
+	occs, err := r.Occurrences(start, end, loc)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Period, 0, len(occs))
+	for _, occ := range occs {
+		list = append(list, Period{Start: occ, End: occ.Add(d)})
+	}
+	return list, nil
+}
+
+// Summary describes the rule in English as of start, e.g. "weekly on Monday
+// and Wednesday at 10:15 (Europe/Berlin)". A nil loc means UTC.
+func (r *Rule) Summary(start time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	days, hour, minute := r.local(start, loc)
+	slices.SortFunc(days, func(a, b time.Weekday) int {
+		// weeks start on Monday
+		return (int(a)+6)%7 - (int(b)+6)%7
+	})
+
+	names := make([]string, 0, len(days))
+	for _, wd := range days {
+		names = append(names, wd.String())
+	}
+	var on string
+	switch len(names) {
+	case 7:
+		on = "daily"
+	case 1:
+		on = "weekly on " + names[0]
+	default:
+		on = "weekly on " + strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+	return fmt.Sprintf("%s at %02d:%02d (%s)", on, hour, minute, loc)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 shio solutions GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rrule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shio.solutions/tales.media/opencast-client-go/pkg/rrule"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		rule string
+		err  error
+	}{
+		{"", rrule.InvalidRuleErr},
+		{"FREQ=WEEKLY;BYDAY=MO;BYHOUR=8", rrule.InvalidRuleErr},
+		{"FREQ=WEEKLY;BYDAY=XX;BYHOUR=8;BYMINUTE=15", rrule.InvalidRuleErr},
+		{"FREQ=WEEKLY;BYDAY=MO;BYHOUR=24;BYMINUTE=15", rrule.InvalidRuleErr},
+		{"FREQ=WEEKLY;FREQ=WEEKLY;BYDAY=MO;BYHOUR=8;BYMINUTE=15", rrule.InvalidRuleErr},
+		{"FREQ=WEEKLY;BYDAY=MO;BYHOUR=8;BYMINUTE=15;FOO=1", rrule.InvalidRuleErr},
+		{"FREQ=DAILY;BYDAY=MO;BYHOUR=8;BYMINUTE=15", rrule.UnsupportedRuleErr},
+		{"FREQ=WEEKLY;BYDAY=1MO;BYHOUR=8;BYMINUTE=15", rrule.UnsupportedRuleErr},
+		{"FREQ=WEEKLY;BYDAY=MO;BYHOUR=8;BYMINUTE=15;INTERVAL=2", rrule.UnsupportedRuleErr},
+		{"FREQ=WEEKLY;BYDAY=MO;BYHOUR=8;BYMINUTE=15;UNTIL=20250101T000000Z", rrule.UnsupportedRuleErr},
+	}
+	for _, tt := range tests {
+		_, err := rrule.Parse(tt.rule)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.rule, err, tt.err)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	r, err := rrule.Parse("RRULE:freq=weekly;byday=mo,we;byhour=8;byminute=15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.String(), "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=8;BYMINUTE=15"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestOccurrencesKeepLocalTimeAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	// 10:15 in Berlin is 09:15 UTC in winter
+	r, err := rrule.Parse("FREQ=WEEKLY;BYDAY=TH;BYHOUR=9;BYMINUTE=15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, berlin)
+	end := time.Date(2025, time.November, 1, 0, 0, 0, 0, berlin)
+	periods, err := r.Periods(start, end, 90*time.Minute, berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(periods) != 35 {
+		t.Fatalf("got %d periods, want 35", len(periods))
+	}
+	for _, p := range periods {
+		if p.Start.Weekday() != time.Thursday || p.Start.Hour() != 10 || p.Start.Minute() != 15 {
+			t.Errorf("occurrence at %s, want Thursday 10:15", p.Start)
+		}
+		if p.End.Sub(p.Start) != 90*time.Minute {
+			t.Errorf("period %s to %s, want 90 minutes", p.Start, p.End)
+		}
+	}
+	// 08:15 UTC in summer, 09:15 UTC in winter
+	if got := periods[0].Start.UTC().Hour(); got != 9 {
+		t.Errorf("first occurrence at %d UTC, want 9", got)
+	}
+	if got := periods[10].Start.UTC().Hour(); got != 8 {
+		t.Errorf("summer occurrence at %d UTC, want 8", got)
+	}
+	if got := periods[len(periods)-1].Start.UTC().Hour(); got != 9 {
+		t.Errorf("last occurrence at %d UTC, want 9", got)
+	}
+}
+
+func TestOccurrencesShiftWeekday(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// 23:00 UTC on Monday is 08:00 on Tuesday in Tokyo
+	r, err := rrule.Parse("FREQ=WEEKLY;BYDAY=MO;BYHOUR=23;BYMINUTE=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	occs, err := r.Occurrences(start, start.AddDate(0, 0, 14), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occs) != 2 {
+		t.Fatalf("got %d occurrences, want 2", len(occs))
+	}
+	for _, occ := range occs {
+		if occ.Weekday() != time.Tuesday || occ.Hour() != 8 {
+			t.Errorf("occurrence at %s, want Tuesday 08:00", occ)
+		}
+	}
+}
+
+func TestOccurrencesBound(t *testing.T) {
+	r, err := rrule.Parse("FREQ=WEEKLY;BYDAY=SU,MO,TU,WE,TH,FR,SA;BYHOUR=8;BYMINUTE=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	occs, err := r.Occurrences(start, start.AddDate(0, 0, rrule.MaxOccurrences), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occs) != rrule.MaxOccurrences {
+		t.Errorf("got %d occurrences, want %d", len(occs), rrule.MaxOccurrences)
+	}
+
+	_, err = r.Periods(start, start.AddDate(100, 0, 0), time.Hour, nil)
+	if !errors.Is(err, rrule.TooManyOccurrencesErr) {
+		t.Errorf("Periods() = %v, want %v", err, rrule.TooManyOccurrencesErr)
+	}
+}